@@ -0,0 +1,154 @@
+package acceptance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getCesAlarmTemplateResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	region := OS_REGION_NAME
+	// getAlarmTemplate: Query CES alarm template
+	var (
+		getAlarmTemplateHttpUrl = "v2/{project_id}/alarm-templates/{template_id}"
+		getAlarmTemplateProduct = "ces"
+	)
+	getAlarmTemplateClient, err := cfg.NewServiceClient(getAlarmTemplateProduct, region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CES Client: %s", err)
+	}
+
+	getAlarmTemplatePath := getAlarmTemplateClient.Endpoint + getAlarmTemplateHttpUrl
+	getAlarmTemplatePath = strings.ReplaceAll(getAlarmTemplatePath, "{project_id}", getAlarmTemplateClient.ProjectID)
+	getAlarmTemplatePath = strings.ReplaceAll(getAlarmTemplatePath, "{template_id}", state.Primary.ID)
+
+	getAlarmTemplateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes: []int{
+			200,
+		},
+	}
+	getAlarmTemplateResp, err := getAlarmTemplateClient.Request("GET", getAlarmTemplatePath, &getAlarmTemplateOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving CES alarm template: %s", err)
+	}
+	return utils.FlattenResponse(getAlarmTemplateResp)
+}
+
+func TestAccCesAlarmTemplate_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	resourceName := "flexibleengine_ces_alarm_template.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getCesAlarmTemplateResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testCesAlarmTemplate_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "description", "It is a test template"),
+					resource.TestCheckResourceAttr(resourceName, "policies.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "policies.0.namespace", "SYS.ECS"),
+					resource.TestCheckResourceAttr(resourceName, "policies.0.metric_name", "cpu_util"),
+					resource.TestCheckResourceAttr(resourceName, "policies.1.metric_name", "mem_util"),
+					resource.TestCheckResourceAttrSet(resourceName, "type"),
+				),
+			},
+			{
+				Config: testCesAlarmTemplate_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", name+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "description", "It is an updated template"),
+					resource.TestCheckResourceAttr(resourceName, "policies.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policies.0.value", "90"),
+					resource.TestCheckResourceAttr(resourceName, "policies.0.alarm_level", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCesAlarmTemplate_basic(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_ces_alarm_template" "test" {
+  name        = "%s"
+  description = "It is a test template"
+
+  policies {
+    namespace           = "SYS.ECS"
+    dimension_name      = "instance_id"
+    metric_name         = "cpu_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 80
+    unit                = "%%"
+    count               = 3
+    alarm_level         = 2
+    suppress_duration   = 300
+  }
+
+  policies {
+    namespace           = "SYS.ECS"
+    dimension_name      = "instance_id"
+    metric_name         = "mem_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 80
+    unit                = "%%"
+    count               = 3
+    alarm_level         = 2
+    suppress_duration   = 300
+  }
+}
+`, name)
+}
+
+func testCesAlarmTemplate_update(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_ces_alarm_template" "test" {
+  name        = "%s-update"
+  description = "It is an updated template"
+
+  policies {
+    namespace           = "SYS.ECS"
+    dimension_name      = "instance_id"
+    metric_name         = "cpu_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 90
+    unit                = "%%"
+    count               = 3
+    alarm_level         = 1
+    suppress_duration   = 300
+  }
+}
+`, name)
+}
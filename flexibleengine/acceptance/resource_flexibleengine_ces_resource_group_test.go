@@ -0,0 +1,147 @@
+package acceptance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getCesResourceGroupResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	region := OS_REGION_NAME
+	// getResourceGroup: Query the CES resource group detail
+	var (
+		getResourceGroupHttpUrl = "v2/{project_id}/resource-groups/{id}"
+		getResourceGroupProduct = "ces"
+	)
+	getResourceGroupClient, err := cfg.NewServiceClient(getResourceGroupProduct, region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CES Client: %s", err)
+	}
+
+	getResourceGroupPath := getResourceGroupClient.Endpoint + getResourceGroupHttpUrl
+	getResourceGroupPath = strings.ReplaceAll(getResourceGroupPath, "{project_id}", getResourceGroupClient.ProjectID)
+	getResourceGroupPath = strings.ReplaceAll(getResourceGroupPath, "{id}", state.Primary.ID)
+
+	getResourceGroupOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes: []int{
+			200,
+		},
+	}
+	getResourceGroupResp, err := getResourceGroupClient.Request("GET", getResourceGroupPath, &getResourceGroupOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving CES resource group: %s", err)
+	}
+	return utils.FlattenResponse(getResourceGroupResp)
+}
+
+func TestAccCesResourceGroup_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	resourceName := "flexibleengine_ces_resource_group.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getCesResourceGroupResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testCesResourceGroup_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				Config: testCesResourceGroup_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", name+"-update"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"resources",
+				},
+			},
+		},
+	})
+}
+
+func testCesResourceGroup_base(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_compute_instance_v2" "test" {
+  count = 2
+
+  name              = "%s-${count.index}"
+  image_id          = data.flexibleengine_images_image.test.id
+  flavor_id         = data.flexibleengine_compute_flavors_v2.test.flavors[0]
+  security_groups   = [flexibleengine_networking_secgroup_v2.test.name]
+  availability_zone = data.flexibleengine_availability_zones.test.names[0]
+
+  network {
+    uuid = flexibleengine_vpc_subnet_v1.test.id
+  }
+}
+`, testBaseComputeResources(name), name)
+}
+
+func testCesResourceGroup_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_ces_resource_group" "test" {
+  name = "%s"
+
+  resources {
+    namespace = "SYS.ECS"
+    dimensions {
+      name  = "instance_id"
+      value = flexibleengine_compute_instance_v2.test[0].id
+    }
+  }
+}
+`, testCesResourceGroup_base(name), name)
+}
+
+func testCesResourceGroup_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_ces_resource_group" "test" {
+  name = "%s-update"
+
+  dynamic "resources" {
+    for_each = flexibleengine_compute_instance_v2.test[*].id
+    content {
+      namespace = "SYS.ECS"
+      dimensions {
+        name  = "instance_id"
+        value = resources.value
+      }
+    }
+  }
+}
+`, testCesResourceGroup_base(name), name)
+}
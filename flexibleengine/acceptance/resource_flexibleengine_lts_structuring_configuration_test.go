@@ -0,0 +1,176 @@
+package acceptance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getLtsStructConfigResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	var (
+		region      = OS_REGION_NAME
+		httpUrl     = "v2/{project_id}/lts/struct/template"
+		product     = "lts"
+		logGroupId  = state.Primary.Attributes["log_group_id"]
+		logStreamId = state.Primary.Attributes["log_stream_id"]
+	)
+	client, err := cfg.NewServiceClient(product, region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating LTS client: %s", err)
+	}
+
+	getPath := client.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath += fmt.Sprintf("?logGroupId=%s&logStreamId=%s", logGroupId, logStreamId)
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json;charset=UTF-8"},
+	}
+
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	getRespBody, err := utils.FlattenResponse(getResp)
+	if err != nil {
+		return nil, err
+	}
+
+	rawString, isString := getRespBody.(string)
+	if !isString {
+		return nil, fmt.Errorf("the detail API response is not string")
+	}
+	if rawString == "" {
+		// the structuring configuration is not exist
+		return nil, golangsdk.ErrDefault404{}
+	}
+	return getRespBody, nil
+}
+
+func TestAccLtsStructConfig_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	resourceName := "flexibleengine_lts_structuring_configuration.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getLtsStructConfigResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testLtsStructConfig_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "log_group_id",
+						"flexibleengine_lts_group.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "log_stream_id",
+						"flexibleengine_lts_topic.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "template_type", "built_in"),
+					resource.TestCheckResourceAttr(resourceName, "template_name", "VPC"),
+					resource.TestCheckResourceAttrSet(resourceName, "demo_log"),
+				),
+			},
+			{
+				Config: testLtsStructConfig_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "template_type", "built_in"),
+					resource.TestCheckResourceAttr(resourceName, "template_name", "ELB"),
+					resource.TestCheckResourceAttr(resourceName, "quick_analysis", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "demo_log"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testLtsStructConfigImportState(resourceName),
+				ImportStateVerifyIgnore: []string{
+					"template_type",
+					"template_id",
+					"demo_fields",
+					"tag_fields",
+					"quick_analysis",
+				},
+			},
+		},
+	})
+}
+
+func testLtsStructConfigImportState(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource (%s) not found", name)
+		}
+
+		logGroupId := rs.Primary.Attributes["log_group_id"]
+		logStreamId := rs.Primary.Attributes["log_stream_id"]
+		if logGroupId == "" || logStreamId == "" {
+			return "", fmt.Errorf("invalid format specified for import ID, want '<log_group_id>/<log_stream_id>', "+
+				"but got '%s/%s'", logGroupId, logStreamId)
+		}
+		return fmt.Sprintf("%s/%s", logGroupId, logStreamId), nil
+	}
+}
+
+func testLtsTopic_base(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_lts_group" "test" {
+  group_name  = "%[1]s"
+  ttl_in_days = 1
+}
+
+resource "flexibleengine_lts_topic" "test" {
+  group_id   = flexibleengine_lts_group.test.id
+  topic_name = "%[1]s"
+}
+`, name)
+}
+
+func testLtsStructConfig_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_lts_structuring_configuration" "test" {
+  log_group_id  = flexibleengine_lts_group.test.id
+  log_stream_id = flexibleengine_lts_topic.test.id
+  template_type = "built_in"
+  template_name = "VPC"
+
+  demo_fields {
+    field_name  = "srcaddr"
+    is_analysis = true
+  }
+}
+`, testLtsTopic_base(name))
+}
+
+func testLtsStructConfig_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_lts_structuring_configuration" "test" {
+  log_group_id   = flexibleengine_lts_group.test.id
+  log_stream_id  = flexibleengine_lts_topic.test.id
+  template_type  = "built_in"
+  template_name  = "ELB"
+  quick_analysis = true
+}
+`, testLtsTopic_base(name))
+}
@@ -0,0 +1,206 @@
+package acceptance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getLtsTransferResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	region := OS_REGION_NAME
+	// getTransfer: Query the log transfer task.
+	var (
+		getTransferHttpUrl = "v2/{project_id}/transfers"
+		getTransferProduct = "lts"
+	)
+	getTransferClient, err := cfg.NewServiceClient(getTransferProduct, region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating LTS client: %s", err)
+	}
+
+	getTransferPath := getTransferClient.Endpoint + getTransferHttpUrl
+	getTransferPath = strings.ReplaceAll(getTransferPath, "{project_id}", getTransferClient.ProjectID)
+
+	getTransferOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes: []int{
+			200,
+		},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	}
+	getTransferResp, err := getTransferClient.Request("GET", getTransferPath, &getTransferOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving LTS transfer: %s", err)
+	}
+
+	getTransferRespBody, err := utils.FlattenResponse(getTransferResp)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving LTS transfer: %s", err)
+	}
+
+	jsonPath := fmt.Sprintf("log_transfers[?log_transfer_id =='%s']|[0]", state.Primary.ID)
+	getTransferRespBody = utils.PathSearch(jsonPath, getTransferRespBody, nil)
+	if getTransferRespBody == nil {
+		return nil, golangsdk.ErrDefault404{}
+	}
+	return getTransferRespBody, nil
+}
+
+func TestAccLtsTransfer_obs(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	bucketName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "flexibleengine_lts_transfer.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getLtsTransferResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testLtsTransfer_obs(name, bucketName, "ENABLE", 3, "hour"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "log_group_id", "flexibleengine_lts_group.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "log_streams.0.log_stream_id",
+						"flexibleengine_lts_topic.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_type", "OBS"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_status", "ENABLE"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_detail.0.obs_period", "3"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_detail.0.obs_period_unit", "hour"),
+					resource.TestCheckResourceAttrPair(resourceName, "log_transfer_info.0.log_transfer_detail.0.obs_bucket_name",
+						"flexibleengine_obs_bucket.test", "bucket"),
+					resource.TestCheckResourceAttrSet(resourceName, "log_group_name"),
+				),
+			},
+			{
+				Config: testLtsTransfer_obs(name, bucketName, "DISABLE", 30, "min"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_status", "DISABLE"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_detail.0.obs_period", "30"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_detail.0.obs_period_unit", "min"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLtsTransfer_dis(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	resourceName := "flexibleengine_lts_transfer.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getLtsTransferResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testLtsTransfer_dis(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_type", "DIS"),
+					resource.TestCheckResourceAttr(resourceName, "log_transfer_info.0.log_transfer_mode", "realTime"),
+					resource.TestCheckResourceAttrPair(resourceName, "log_transfer_info.0.log_transfer_detail.0.dis_id",
+						"flexibleengine_dis_stream.test", "stream_id"),
+				),
+			},
+		},
+	})
+}
+
+func testLtsTransfer_obs(name, bucketName, status string, period int, unit string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "flexibleengine_obs_bucket" "test" {
+  bucket        = "%[2]s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "flexibleengine_lts_transfer" "test" {
+  log_group_id = flexibleengine_lts_group.test.id
+
+  log_streams {
+    log_stream_id = flexibleengine_lts_topic.test.id
+  }
+
+  log_transfer_info {
+    log_transfer_type   = "OBS"
+    log_transfer_mode   = "cycle"
+    log_storage_format  = "RAW"
+    log_transfer_status = "%[3]s"
+
+    log_transfer_detail {
+      obs_period          = %[4]d
+      obs_period_unit     = "%[5]s"
+      obs_bucket_name     = flexibleengine_obs_bucket.test.bucket
+      obs_dir_prefix_name = "lts_transfer_"
+      obs_prefix_name     = "obs_"
+      obs_time_zone       = "UTC"
+      obs_time_zone_id    = "Etc/GMT"
+    }
+  }
+}
+`, testLtsTopic_base(name), bucketName, status, period, unit)
+}
+
+func testLtsTransfer_dis(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "flexibleengine_dis_stream" "test" {
+  name            = "%[2]s"
+  partition_count = 1
+}
+
+resource "flexibleengine_lts_transfer" "test" {
+  log_group_id = flexibleengine_lts_group.test.id
+
+  log_streams {
+    log_stream_id = flexibleengine_lts_topic.test.id
+  }
+
+  log_transfer_info {
+    log_transfer_type   = "DIS"
+    log_transfer_mode   = "realTime"
+    log_storage_format  = "RAW"
+    log_transfer_status = "ENABLE"
+
+    log_transfer_detail {
+      dis_id   = flexibleengine_dis_stream.test.stream_id
+      dis_name = flexibleengine_dis_stream.test.name
+    }
+  }
+}
+`, testLtsTopic_base(name), name)
+}
@@ -0,0 +1,100 @@
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	vpc "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getAddressGroupResourceFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	c, err := conf.HcVpcV3Client(OS_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v3 client: %s", err)
+	}
+
+	request := &vpc.ShowAddressGroupRequest{
+		AddressGroupId: state.Primary.ID,
+	}
+	return c.ShowAddressGroup(request)
+}
+
+func TestAccVpcAddressGroup_basic(t *testing.T) {
+	var group vpc.ShowAddressGroupResponse
+
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "flexibleengine_vpc_address_group.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&group,
+		getAddressGroupResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcAddressGroup_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "created by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "ip_version", "4"),
+					resource.TestCheckResourceAttr(resourceName, "addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccVpcAddressGroup_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "addresses.#", "3"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+		},
+	})
+}
+
+func testAccVpcAddressGroup_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_vpc_address_group" "test" {
+  name        = "%s"
+  description = "created by acc test"
+
+  addresses = [
+    "192.168.10.12",
+    "192.168.11.0/24",
+  ]
+}
+`, rName)
+}
+
+func testAccVpcAddressGroup_update(rName string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_vpc_address_group" "test" {
+  name        = "%s-update"
+  description = "updated by acc test"
+
+  addresses = [
+    "192.168.10.12",
+    "192.168.11.0/24",
+    "192.168.12.10-192.168.12.20",
+  ]
+}
+`, rName)
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 
 	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine"
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 func TestAccVpcV1_basic(t *testing.T) {
@@ -54,6 +57,44 @@ func TestAccVpcV1_basic(t *testing.T) {
 	})
 }
 
+func TestUnitVpcV1_basic(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	resourceName := "flexibleengine_vpc_v1.vpc_1"
+	rName := "vpc-unit-test"
+	rNameUpdate := rName + "-updated"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockcloud.PreCheck(t) },
+		ProviderFactories: srv.ProviderFactories(flexibleengine.Provider),
+		CheckDestroy:      srv.CheckDestroy("flexibleengine_vpc_v1", "vpcs"),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccVpcV1_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "cidr", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "status", "OK"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccVpcV1_update(rNameUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rNameUpdate),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value_updated"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccVpcV1_secondaryCIDR(t *testing.T) {
 	var vpc vpcs.Vpc
 
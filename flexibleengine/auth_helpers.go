@@ -26,6 +26,10 @@ func GetCredentials(c *Config) (*awsCredentials.Credentials, error) {
 			SessionToken:    c.SecurityToken,
 		}},
 		&awsCredentials.EnvProvider{},
+		&sharedProfileCredentialsProvider{
+			Filename: c.SharedConfigFile,
+			Profile:  c.Profile,
+		},
 		&awsCredentials.SharedCredentialsProvider{
 			Filename: "",
 			Profile:  "",
@@ -0,0 +1,454 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/blockstorage/extensions/volumeactions"
+	"github.com/chnsz/golangsdk/openstack/blockstorage/v2/volumes"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/addons"
+	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/volumeattach"
+	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/chnsz/golangsdk/openstack/networking/v2/ports"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cceNodeVolumeMetadataKey marks the EVS volumes which are appended to a CCE node
+// after it was created, the value is the ID of the node.
+const cceNodeVolumeMetadataKey = "cce_node_id"
+
+// cceKubernetesNode is the subset of the Kubernetes node object which is managed by
+// the labels and taints of flexibleengine_cce_node_v3.
+type cceKubernetesNode struct {
+	Metadata struct {
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Spec struct {
+		Taints []cceKubernetesTaint `json:"taints"`
+	} `json:"spec"`
+}
+
+type cceKubernetesTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// the Kubernetes node name of a CCE node is its private IP address
+func cceKubernetesNodeURL(client *golangsdk.ServiceClient, clusterID, nodeName string) string {
+	return addons.CCEServiceURL(client, clusterID, "nodes", nodeName)
+}
+
+func getCCEKubernetesNode(client *golangsdk.ServiceClient, clusterID, nodeName string) (*cceKubernetesNode, error) {
+	var node cceKubernetesNode
+	_, err := client.Get(cceKubernetesNodeURL(client, clusterID, nodeName), &node, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func isCCESystemTaint(key string) bool {
+	return strings.HasPrefix(key, "node.kubernetes.io/") ||
+		strings.HasPrefix(key, "node.cloudprovider.kubernetes.io/")
+}
+
+func buildCCEKubernetesTaints(raw []interface{}) []cceKubernetesTaint {
+	taints := make([]cceKubernetesTaint, len(raw))
+	for i, v := range raw {
+		taint := v.(map[string]interface{})
+		taints[i] = cceKubernetesTaint{
+			Key:    taint["key"].(string),
+			Value:  taint["value"].(string),
+			Effect: taint["effect"].(string),
+		}
+	}
+	return taints
+}
+
+// buildCCEKubernetesNodePatch returns a JSON merge patch which replaces the labels and taints
+// managed by Terraform and keeps the ones added by Kubernetes or other tools.
+func buildCCEKubernetesNodePatch(current *cceKubernetesNode, oldLabels, newLabels map[string]interface{},
+	oldTaints, newTaints []interface{}) map[string]interface{} {
+	labels := make(map[string]interface{})
+	for k := range oldLabels {
+		if _, ok := newLabels[k]; !ok {
+			labels[k] = nil
+		}
+	}
+	for k, v := range newLabels {
+		labels[k] = v
+	}
+
+	managed := make(map[string]bool)
+	for _, t := range buildCCEKubernetesTaints(oldTaints) {
+		managed[t.Key+":"+t.Effect] = true
+	}
+	taints := make([]cceKubernetesTaint, 0)
+	for _, t := range current.Spec.Taints {
+		if !managed[t.Key+":"+t.Effect] {
+			taints = append(taints, t)
+		}
+	}
+	taints = append(taints, buildCCEKubernetesTaints(newTaints)...)
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": labels,
+		},
+		"spec": map[string]interface{}{
+			"taints": taints,
+		},
+	}
+}
+
+func updateCCENodeKubernetesMetadata(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	clusterID := d.Get("cluster_id").(string)
+	nodeName := d.Get("private_ip").(string)
+	current, err := getCCEKubernetesNode(client, clusterID, nodeName)
+	if err != nil {
+		return fmt.Errorf("Error fetching Kubernetes node %s: %s", nodeName, err)
+	}
+
+	oldLabels, newLabels := d.GetChange("labels")
+	oldTaints, newTaints := d.GetChange("taints")
+	patch := buildCCEKubernetesNodePatch(current, oldLabels.(map[string]interface{}), newLabels.(map[string]interface{}),
+		oldTaints.([]interface{}), newTaints.([]interface{}))
+
+	log.Printf("[DEBUG] Patching Kubernetes node %s: %#v", nodeName, patch)
+	_, err = client.Patch(cceKubernetesNodeURL(client, clusterID, nodeName), patch, nil, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: map[string]string{"Content-Type": "application/merge-patch+json"},
+	})
+	return err
+}
+
+// flattenCCEKubernetesNodeMetadata returns the labels and taints of the Kubernetes node,
+// only the labels which are known by the CCE node spec or the configuration are kept.
+func flattenCCEKubernetesNodeMetadata(node *cceKubernetesNode, known map[string]string,
+	configured map[string]interface{}) (map[string]string, []map[string]interface{}) {
+	labels := make(map[string]string)
+	for k, v := range node.Metadata.Labels {
+		_, inSpec := known[k]
+		_, inConfig := configured[k]
+		if inSpec || inConfig {
+			labels[k] = v
+		}
+	}
+
+	taints := make([]map[string]interface{}, 0, len(node.Spec.Taints))
+	for _, t := range node.Spec.Taints {
+		if isCCESystemTaint(t.Key) {
+			continue
+		}
+		taints = append(taints, map[string]interface{}{
+			"key":    t.Key,
+			"value":  t.Value,
+			"effect": t.Effect,
+		})
+	}
+	return labels, taints
+}
+
+func getCCENodePortID(client *golangsdk.ServiceClient, serverID, privateIP string) (string, error) {
+	allPages, err := ports.List(client, ports.ListOpts{DeviceID: serverID}).AllPages()
+	if err != nil {
+		return "", err
+	}
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range allPorts {
+		for _, ip := range p.FixedIPs {
+			if ip.IPAddress == privateIP {
+				return p.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unable to find the port of %s on server %s", privateIP, serverID)
+}
+
+func updateCCENodeEips(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	oldRaw, newRaw := d.GetChange("eip_ids")
+	oldIDs, newIDs := oldRaw.(*schema.Set), newRaw.(*schema.Set)
+
+	for _, id := range oldIDs.Difference(newIDs).List() {
+		log.Printf("[DEBUG] Unbinding EIP %s from CCE node %s", id, d.Id())
+		_, err := floatingips.Update(client, id.(string), floatingips.UpdateOpts{PortID: nil}).Extract()
+		if err != nil {
+			return fmt.Errorf("Error unbinding EIP %s from CCE node %s: %s", id, d.Id(), err)
+		}
+	}
+
+	added := newIDs.Difference(oldIDs).List()
+	if len(added) == 0 {
+		return nil
+	}
+
+	portID, err := getCCENodePortID(client, d.Get("server_id").(string), d.Get("private_ip").(string))
+	if err != nil {
+		return err
+	}
+	for _, id := range added {
+		log.Printf("[DEBUG] Binding EIP %s to CCE node %s", id, d.Id())
+		_, err := floatingips.Update(client, id.(string), floatingips.UpdateOpts{PortID: &portID}).Extract()
+		if err != nil {
+			return fmt.Errorf("Error binding EIP %s to CCE node %s: %s", id, d.Id(), err)
+		}
+	}
+	return nil
+}
+
+// cceNodeServerVolumes are the EVS volumes attached to the ECS of a CCE node.
+type cceNodeServerVolumes struct {
+	Root *volumes.Volume
+	// Data are the data volumes created with the node, ordered by device name
+	Data []volumes.Volume
+	// Appended are the data volumes attached after the node was created, ordered by device name
+	Appended []volumes.Volume
+}
+
+func getCCENodeServerVolumes(computeClient, blockStorageClient *golangsdk.ServiceClient,
+	serverID, nodeID string) (*cceNodeServerVolumes, error) {
+	allPages, err := volumeattach.List(computeClient, serverID).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing volume attachments of server %s: %s", serverID, err)
+	}
+	attachments, err := volumeattach.ExtractVolumeAttachments(allPages)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].Device < attachments[j].Device
+	})
+
+	var result cceNodeServerVolumes
+	for _, attachment := range attachments {
+		volume, err := volumes.Get(blockStorageClient, attachment.VolumeID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching volume %s: %s", attachment.VolumeID, err)
+		}
+
+		switch {
+		case volume.Bootable == "true" && result.Root == nil:
+			result.Root = volume
+		case volume.Metadata[cceNodeVolumeMetadataKey] == nodeID:
+			result.Appended = append(result.Appended, *volume)
+		default:
+			result.Data = append(result.Data, *volume)
+		}
+	}
+	if result.Root == nil {
+		return nil, fmt.Errorf("unable to find the system volume of server %s", serverID)
+	}
+	return &result, nil
+}
+
+// refreshCCENodeVolumes updates the volume sizes with the ones of the EVS volumes and adds
+// the appended data volumes, as both are not reflected in the CCE node spec.
+func refreshCCENodeVolumes(serverVolumes *cceNodeServerVolumes, rootVolume, dataVolumes []map[string]interface{},
+	configured []interface{}) []map[string]interface{} {
+	rootVolume[0]["size"] = serverVolumes.Root.Size
+	for i, volume := range serverVolumes.Data {
+		if i < len(dataVolumes) {
+			dataVolumes[i]["size"] = volume.Size
+		}
+	}
+
+	for _, volume := range serverVolumes.Appended {
+		extendParams := map[string]interface{}{}
+		if index := len(dataVolumes); index < len(configured) {
+			if raw, ok := configured[index].(map[string]interface{}); ok {
+				extendParams = raw["extend_params"].(map[string]interface{})
+			}
+		}
+		dataVolumes = append(dataVolumes, map[string]interface{}{
+			"size":          volume.Size,
+			"volumetype":    volume.VolumeType,
+			"kms_key_id":    volume.Metadata["__system__cmkid"],
+			"extend_params": extendParams,
+		})
+	}
+	return dataVolumes
+}
+
+func refreshCCENodeV3Volumes(d *schema.ResourceData, config *Config, serverID string,
+	rootVolume []map[string]interface{}, dataVolumes *[]map[string]interface{}) error {
+	region := GetRegion(d, config)
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return err
+	}
+	blockStorageClient, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return err
+	}
+
+	serverVolumes, err := getCCENodeServerVolumes(computeClient, blockStorageClient, serverID, d.Id())
+	if err != nil {
+		return err
+	}
+	*dataVolumes = refreshCCENodeVolumes(serverVolumes, rootVolume, *dataVolumes, d.Get("data_volumes").([]interface{}))
+	return nil
+}
+
+func extendCCENodeVolume(client *golangsdk.ServiceClient, volume *volumes.Volume, size int,
+	timeout time.Duration) error {
+	if size <= volume.Size {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Extending volume %s from %d to %d GB", volume.ID, volume.Size, size)
+	err := volumeactions.ExtendSize(client, volume.ID, volumeactions.ExtendSizeOpts{NewSize: size}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error extending volume %s: %s", volume.ID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"extending"},
+		Target:     []string{"available", "in-use"},
+		Refresh:    VolumeV2StateRefreshFunc(client, volume.ID),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume %s to be extended: %s", volume.ID, err)
+	}
+	return nil
+}
+
+func attachCCENodeVolume(computeClient, blockStorageClient *golangsdk.ServiceClient, d *schema.ResourceData,
+	raw map[string]interface{}) error {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	metadata := map[string]string{
+		cceNodeVolumeMetadataKey: d.Id(),
+	}
+	if kmsID := raw["kms_key_id"].(string); kmsID != "" {
+		metadata["__system__encrypted"] = "1"
+		metadata["__system__cmkid"] = kmsID
+	}
+
+	createOpts := volumes.CreateOpts{
+		Name:             d.Get("name").(string),
+		Size:             raw["size"].(int),
+		VolumeType:       raw["volumetype"].(string),
+		AvailabilityZone: d.Get("availability_zone").(string),
+		Metadata:         metadata,
+	}
+	log.Printf("[DEBUG] Creating data volume for CCE node %s: %#v", d.Id(), createOpts)
+	volume, err := volumes.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating data volume for CCE node %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"downloading", "creating"},
+		Target:     []string{"available"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, volume.ID),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume %s to become available: %s", volume.ID, err)
+	}
+
+	serverID := d.Get("server_id").(string)
+	_, err = volumeattach.Create(computeClient, serverID, volumeattach.CreateOpts{VolumeID: volume.ID}).Extract()
+	if err != nil {
+		return fmt.Errorf("Error attaching volume %s to CCE node %s: %s", volume.ID, d.Id(), err)
+	}
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"available", "attaching"},
+		Target:     []string{"in-use"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, volume.ID),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume %s to be attached: %s", volume.ID, err)
+	}
+	return nil
+}
+
+func updateCCENodeVolumes(d *schema.ResourceData, config *Config) error {
+	region := GetRegion(d, config)
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine compute client: %s", err)
+	}
+	blockStorageClient, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	serverVolumes, err := getCCENodeServerVolumes(computeClient, blockStorageClient,
+		d.Get("server_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.HasChange("root_volume.0.size") {
+		if err := extendCCENodeVolume(blockStorageClient, serverVolumes.Root,
+			d.Get("root_volume.0.size").(int), timeout); err != nil {
+			return err
+		}
+	}
+
+	existing := make([]volumes.Volume, 0, len(serverVolumes.Data)+len(serverVolumes.Appended))
+	existing = append(existing, serverVolumes.Data...)
+	existing = append(existing, serverVolumes.Appended...)
+	for i, raw := range d.Get("data_volumes").([]interface{}) {
+		volume := raw.(map[string]interface{})
+		if i < len(existing) {
+			if err := extendCCENodeVolume(blockStorageClient, &existing[i], volume["size"].(int), timeout); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := attachCCENodeVolume(computeClient, blockStorageClient, d, volume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCCENodeAppendedVolumes removes the data volumes attached after the node was created,
+// as they are not released together with the node.
+func deleteCCENodeAppendedVolumes(client *golangsdk.ServiceClient, appended []volumes.Volume,
+	timeout time.Duration) error {
+	for _, volume := range appended {
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"in-use", "detaching"},
+			Target:     []string{"available", "deleted"},
+			Refresh:    VolumeV2StateRefreshFunc(client, volume.ID),
+			Timeout:    timeout,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for volume %s to be detached: %s", volume.ID, err)
+		}
+
+		err := volumes.Delete(client, volume.ID, volumes.DeleteOpts{}).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error deleting volume %s: %s", volume.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+// This set of code handles the in-place changes of block devices and networks
+// on a flexibleengine_compute_instance_v2 resource.
+//
+// Only the boot disk and the primary NIC are bound to the lifecycle of the
+// instance, the data disks and other NICs can be attached or detached online.
+// The volumes of the blank and image data disks are created and deleted with
+// the attachments.
+package flexibleengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/blockstorage/v2/volumes"
+	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/volumeattach"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// instanceBlockDeviceKey returns a string which identifies the block device, the disk_bus and
+// device_type are not included as they only take effect when creating the instance.
+func instanceBlockDeviceKey(bd map[string]interface{}) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%v/%v/%v/%v/%v",
+		bd["source_type"], bd["uuid"], bd["volume_size"], bd["destination_type"], bd["boot_index"],
+		bd["delete_on_termination"], bd["guest_format"], bd["device_name"], bd["volume_type"])
+}
+
+// instanceVolumeKey returns a string which identifies the existing volume attached to the instance.
+func instanceVolumeKey(bd map[string]interface{}) string {
+	return fmt.Sprintf("%v/%v", bd["source_type"], bd["uuid"])
+}
+
+// isBootBlockDevice returns true if the block device is the boot disk of the instance.
+func isBootBlockDevice(bd map[string]interface{}) bool {
+	return bd["boot_index"].(int) == 0
+}
+
+// isAttachableBlockDevice returns true if the block device is an existing volume which can
+// be attached to and detached from a running instance.
+func isAttachableBlockDevice(bd map[string]interface{}) bool {
+	return !isBootBlockDevice(bd) && bd["source_type"].(string) == "volume" &&
+		bd["destination_type"].(string) == "volume"
+}
+
+// isDataBlockDevice returns true if the block device is a data disk created from a blank volume
+// or an image, such a volume is created and attached, or detached and deleted, on a running instance.
+func isDataBlockDevice(bd map[string]interface{}) bool {
+	sourceType := bd["source_type"].(string)
+	return !isBootBlockDevice(bd) && (sourceType == "blank" || sourceType == "image") &&
+		bd["destination_type"].(string) == "volume"
+}
+
+// instanceDataDiskKey returns a string which identifies the data disk, the delete_on_termination
+// is not included as the volume should not be replaced when only the flag is changed.
+func instanceDataDiskKey(bd map[string]interface{}) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%v/%v",
+		bd["source_type"], bd["uuid"], bd["volume_size"], bd["guest_format"], bd["device_name"], bd["volume_type"])
+}
+
+// instanceBlockDeviceChanges is the result of comparing the old and new block devices.
+type instanceBlockDeviceChanges struct {
+	Detach   []map[string]interface{}
+	Attach   []map[string]interface{}
+	Delete   []map[string]interface{}
+	Create   []map[string]interface{}
+	ForceNew bool
+}
+
+// diffInstanceBlockDevices compares the block devices of the instance. Adding or removing
+// the existing volumes and the blank or image data disks is done in place, changing the other
+// fields of an attached volume, the boot disk or any other kind of device (e.g. ephemeral disks)
+// requires a new instance.
+func diffInstanceBlockDevices(oldDevices, newDevices []interface{}) instanceBlockDeviceChanges {
+	var changes instanceBlockDeviceChanges
+
+	isFixedBlockDevice := func(bd map[string]interface{}) bool {
+		return !isAttachableBlockDevice(bd) && !isDataBlockDevice(bd)
+	}
+	oldCount := make(map[string]int)
+	for _, raw := range oldDevices {
+		bd := raw.(map[string]interface{})
+		if isFixedBlockDevice(bd) {
+			oldCount[instanceBlockDeviceKey(bd)]++
+		}
+	}
+	newCount := make(map[string]int)
+	for _, raw := range newDevices {
+		bd := raw.(map[string]interface{})
+		if isFixedBlockDevice(bd) {
+			newCount[instanceBlockDeviceKey(bd)]++
+		}
+	}
+	if len(oldCount) != len(newCount) {
+		changes.ForceNew = true
+	}
+	for key, count := range oldCount {
+		if newCount[key] != count {
+			changes.ForceNew = true
+		}
+	}
+
+	// the volumes are matched by the volume ID, the other fields can not be changed online
+	oldVolumes := make(map[string]map[string]interface{})
+	for _, raw := range oldDevices {
+		if bd := raw.(map[string]interface{}); isAttachableBlockDevice(bd) {
+			oldVolumes[instanceVolumeKey(bd)] = bd
+		}
+	}
+	newVolumes := make(map[string]bool)
+	for _, raw := range newDevices {
+		bd := raw.(map[string]interface{})
+		if !isAttachableBlockDevice(bd) {
+			continue
+		}
+		newVolumes[instanceVolumeKey(bd)] = true
+		if oldVolume, ok := oldVolumes[instanceVolumeKey(bd)]; !ok {
+			changes.Attach = append(changes.Attach, bd)
+		} else if instanceBlockDeviceKey(oldVolume) != instanceBlockDeviceKey(bd) {
+			changes.ForceNew = true
+		}
+	}
+	for _, raw := range oldDevices {
+		if bd := raw.(map[string]interface{}); isAttachableBlockDevice(bd) && !newVolumes[instanceVolumeKey(bd)] {
+			changes.Detach = append(changes.Detach, bd)
+		}
+	}
+
+	// the data disks with the same fields are interchangeable, they are matched in order and
+	// the extra ones are created or deleted
+	oldDataDisks := make(map[string][]map[string]interface{})
+	for _, raw := range oldDevices {
+		if bd := raw.(map[string]interface{}); isDataBlockDevice(bd) {
+			key := instanceDataDiskKey(bd)
+			oldDataDisks[key] = append(oldDataDisks[key], bd)
+		}
+	}
+	newDataDisks := make(map[string]int)
+	for _, raw := range newDevices {
+		bd := raw.(map[string]interface{})
+		if !isDataBlockDevice(bd) {
+			continue
+		}
+		key := instanceDataDiskKey(bd)
+		index := newDataDisks[key]
+		newDataDisks[key]++
+		if index >= len(oldDataDisks[key]) {
+			changes.Create = append(changes.Create, bd)
+		} else if oldDataDisks[key][index]["delete_on_termination"] != bd["delete_on_termination"] {
+			changes.ForceNew = true
+		}
+	}
+	for _, raw := range oldDevices {
+		bd := raw.(map[string]interface{})
+		if !isDataBlockDevice(bd) {
+			continue
+		}
+		key := instanceDataDiskKey(bd)
+		if newDataDisks[key] > 0 {
+			newDataDisks[key]--
+			continue
+		}
+		changes.Delete = append(changes.Delete, bd)
+		// the volume to delete is unknown
+		if volumeID, _ := bd["volume_id"].(string); volumeID == "" {
+			changes.ForceNew = true
+		}
+	}
+
+	return changes
+}
+
+// isVolumeMatchedBlockDevice returns true if the attached volume has the size and type of the data disk.
+func isVolumeMatchedBlockDevice(bd, volume map[string]interface{}) bool {
+	if size := bd["volume_size"].(int); size > 0 && volume["size"].(int) != size {
+		return false
+	}
+	if volumeType := bd["volume_type"].(string); volumeType != "" && volume["type"].(string) != volumeType {
+		return false
+	}
+	return true
+}
+
+// flattenInstanceBlockDevices fills the volume_id of the blank and image data disks with the
+// attached volumes. A volume is only picked when the data disks of the same size and type can
+// be paired one to one with the unused volumes, as the volumes attached by
+// flexibleengine_compute_volume_attach are attached to the instance as well.
+func flattenInstanceBlockDevices(devices []interface{}, volumes []map[string]interface{}) []map[string]interface{} {
+	attached := make(map[string]map[string]interface{})
+	used := make(map[string]bool)
+	for _, volume := range volumes {
+		volumeID := volume["uuid"].(string)
+		attached[volumeID] = volume
+		if volume["boot_index"].(int) == 0 {
+			used[volumeID] = true
+		}
+	}
+
+	result := make([]map[string]interface{}, len(devices))
+	for i, raw := range devices {
+		bd := make(map[string]interface{})
+		for k, v := range raw.(map[string]interface{}) {
+			bd[k] = v
+		}
+		result[i] = bd
+		if isAttachableBlockDevice(bd) {
+			used[bd["uuid"].(string)] = true
+		}
+	}
+
+	var pending []map[string]interface{}
+	for _, bd := range result {
+		volumeID, _ := bd["volume_id"].(string)
+		if !isDataBlockDevice(bd) {
+			bd["volume_id"] = ""
+			continue
+		}
+		if volume, ok := attached[volumeID]; ok && !used[volumeID] && isVolumeMatchedBlockDevice(bd, volume) {
+			used[volumeID] = true
+			continue
+		}
+		bd["volume_id"] = ""
+		pending = append(pending, bd)
+	}
+
+	for matched := true; matched; {
+		matched = false
+		for _, bd := range pending {
+			if bd["volume_id"].(string) != "" {
+				continue
+			}
+
+			var candidates []string
+			for _, volume := range volumes {
+				volumeID := volume["uuid"].(string)
+				if !used[volumeID] && isVolumeMatchedBlockDevice(bd, volume) {
+					candidates = append(candidates, volumeID)
+				}
+			}
+			similar := 0
+			for _, other := range pending {
+				if other["volume_id"].(string) == "" && other["volume_size"] == bd["volume_size"] &&
+					other["volume_type"] == bd["volume_type"] {
+					similar++
+				}
+			}
+			if len(candidates) > 0 && len(candidates) == similar {
+				bd["volume_id"] = candidates[0]
+				used[candidates[0]] = true
+				matched = true
+			}
+		}
+	}
+
+	return result
+}
+
+// isPrimaryNetworkChanged returns true if the first network block refers to another NIC.
+func isPrimaryNetworkChanged(d *schema.ResourceDiff) bool {
+	oldRaw, newRaw := d.GetChange("network")
+	oldNetworks := oldRaw.([]interface{})
+	newNetworks := newRaw.([]interface{})
+	if len(oldNetworks) == 0 {
+		return false
+	}
+	if len(newNetworks) == 0 {
+		return true
+	}
+
+	// the name is resolved to another network when it is changed, while the computed uuid
+	// keeps the old value in the plan
+	for _, key := range []string{"uuid", "name", "port", "fixed_ip_v4"} {
+		path := fmt.Sprintf("network.0.%s", key)
+		if !d.NewValueKnown(path) {
+			return true
+		}
+		oldValue := oldNetworks[0].(map[string]interface{})[key].(string)
+		newValue := newNetworks[0].(map[string]interface{})[key].(string)
+		if oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceComputeInstanceV2CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// the instance is being created
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("network") && isPrimaryNetworkChanged(d) {
+		if err := d.ForceNew("network"); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("block_device") {
+		oldRaw, newRaw := d.GetChange("block_device")
+		changes := diffInstanceBlockDevices(oldRaw.([]interface{}), newRaw.([]interface{}))
+		if changes.ForceNew {
+			if err := d.ForceNew("block_device"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateInstanceBlockDevices detaches the removed volumes and attaches the new ones, the volumes
+// of the new data disks are created before being attached and the volumes of the removed data
+// disks are deleted after being detached.
+func updateInstanceBlockDevices(d *schema.ResourceData, meta interface{}, computeClient *golangsdk.ServiceClient) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	oldRaw, newRaw := d.GetChange("block_device")
+	changes := diffInstanceBlockDevices(oldRaw.([]interface{}), newRaw.([]interface{}))
+
+	for _, bd := range changes.Detach {
+		if err := detachInstanceVolume(d, computeClient, bd["uuid"].(string)); err != nil {
+			return err
+		}
+	}
+
+	for _, bd := range changes.Delete {
+		volumeID := bd["volume_id"].(string)
+		if err := detachInstanceVolume(d, computeClient, volumeID); err != nil {
+			return err
+		}
+		if err := deleteInstanceDataVolume(d, blockStorageClient, volumeID); err != nil {
+			return err
+		}
+	}
+
+	for _, bd := range changes.Create {
+		volumeID, err := createInstanceDataVolume(d, blockStorageClient, bd)
+		if err != nil {
+			return err
+		}
+		if err := attachInstanceVolume(d, computeClient, volumeID, bd["device_name"].(string)); err != nil {
+			return err
+		}
+	}
+
+	for _, bd := range changes.Attach {
+		if err := attachInstanceVolume(d, computeClient, bd["uuid"].(string), bd["device_name"].(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func detachInstanceVolume(d *schema.ResourceData, computeClient *golangsdk.ServiceClient, volumeID string) error {
+	// the attachment ID is the same as the volume ID
+	log.Printf("[DEBUG] Detaching volume %s from instance %s", volumeID, d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{""},
+		Target:     []string{"DETACHED"},
+		Refresh:    resourceComputeVolumeAttachV2DetachFunc(computeClient, d.Id(), volumeID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      15 * time.Second,
+		MinTimeout: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error detaching volume %s from instance %s: %s", volumeID, d.Id(), err)
+	}
+	return nil
+}
+
+func attachInstanceVolume(d *schema.ResourceData, computeClient *golangsdk.ServiceClient, volumeID, device string) error {
+	attachOpts := volumeattach.CreateOpts{
+		Device:   device,
+		VolumeID: volumeID,
+	}
+	log.Printf("[DEBUG] Attaching volume to instance %s: %#v", d.Id(), attachOpts)
+	attachment, err := volumeattach.Create(computeClient, d.Id(), attachOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error attaching volume %s to instance %s: %s", volumeID, d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ATTACHING"},
+		Target:     []string{"ATTACHED"},
+		Refresh:    resourceComputeVolumeAttachV2AttachFunc(computeClient, d.Id(), attachment.ID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      30 * time.Second,
+		MinTimeout: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume %s to be attached to instance %s: %s", volumeID, d.Id(), err)
+	}
+	return nil
+}
+
+// createInstanceDataVolume creates the volume of a blank or image data disk in the
+// availability zone of the instance.
+func createInstanceDataVolume(d *schema.ResourceData, blockStorageClient *golangsdk.ServiceClient,
+	bd map[string]interface{}) (string, error) {
+	createOpts := &volumes.CreateOpts{
+		AvailabilityZone: d.Get("availability_zone").(string),
+		Size:             bd["volume_size"].(int),
+		VolumeType:       bd["volume_type"].(string),
+	}
+	if bd["source_type"].(string) == "image" {
+		createOpts.ImageID = bd["uuid"].(string)
+	}
+
+	log.Printf("[DEBUG] Creating data volume for instance %s: %#v", d.Id(), createOpts)
+	v, err := volumes.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return "", fmt.Errorf("Error creating data volume for instance %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"downloading", "creating"},
+		Target:     []string{"available"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, v.ID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return "", fmt.Errorf("Error waiting for volume (%s) to become ready: %s", v.ID, err)
+	}
+	return v.ID, nil
+}
+
+func deleteInstanceDataVolume(d *schema.ResourceData, blockStorageClient *golangsdk.ServiceClient, volumeID string) error {
+	// the volume is still detaching when the attachment is gone
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"in-use", "detaching"},
+		Target:     []string{"available", "deleted"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, volumeID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume (%s) to be detached: %s", volumeID, err)
+	}
+
+	log.Printf("[DEBUG] Deleting data volume %s of instance %s", volumeID, d.Id())
+	if err := volumes.Delete(blockStorageClient, volumeID, volumes.DeleteOpts{}).ExtractErr(); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("Error deleting volume %s: %s", volumeID, err)
+	}
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"deleting", "downloading", "available"},
+		Target:     []string{"deleted"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, volumeID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume (%s) to delete: %s", volumeID, err)
+	}
+	return nil
+}
@@ -12,10 +12,14 @@ package flexibleengine
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/attachinterfaces"
 	"github.com/chnsz/golangsdk/openstack/compute/v2/servers"
 	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
 	"github.com/chnsz/golangsdk/openstack/networking/v2/ports"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -147,10 +151,15 @@ func getAllInstanceNetworks(d *schema.ResourceData) []InstanceNetwork {
 
 // flattenInstanceNetworks collects instance network information from different
 // sources and aggregates it all together into a map array.
+// The NICs are matched with the configured networks after the instance is created or updated,
+// as the computed ports in the plan may belong to other NICs.
 func flattenInstanceNetworks(
 	d *schema.ResourceData, meta interface{}, server *cloudservers.CloudServer) ([]map[string]interface{}, error) {
 
-	allInstanceNetworks := getAllInstanceNetworks(d)
+	allInstanceNetworks, err := getConfiguredInstanceNetworks(d, meta)
+	if err != nil {
+		return nil, err
+	}
 	allInstanceNics, _ := getInstanceAddresses(d, meta, server)
 
 	networks := []map[string]interface{}{}
@@ -163,7 +172,8 @@ func flattenInstanceNetworks(
 			if instanceNetwork.Port != "" && instanceNetwork.Port == nic.PortID {
 				nic.Fetched = true
 				isExist = true
-			} else if instanceNetwork.UUID == nic.NetworkID && nic.Fetched == false {
+			} else if instanceNetwork.UUID == nic.NetworkID && nic.Fetched == false &&
+				(instanceNetwork.FixedIP == "" || instanceNetwork.FixedIP == nic.FixedIPv4) {
 				// Only use one NIC since it's possible the user defined another NIC
 				// on this same network in another Terraform network block.
 				nic.Fetched = true
@@ -226,3 +236,166 @@ func getInstanceAccessAddresses(
 
 	return hostv4, hostv6
 }
+
+// getConfiguredInstanceNetworks returns the networks in the Terraform configuration.
+// The computed values of network blocks are kept by index in the planned value, so they
+// may belong to another NIC after an element is inserted or removed, the raw configuration
+// is used to get the values specified by the user.
+func getConfiguredInstanceNetworks(d *schema.ResourceData, meta interface{}) ([]InstanceNetwork, error) {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || raw.GetAttr("network").IsNull() {
+		return getAllInstanceNetworks(d), nil
+	}
+
+	rawNetworks := raw.GetAttr("network").AsValueSlice()
+	instanceNetworks := make([]InstanceNetwork, len(rawNetworks))
+	for i, rawNetwork := range rawNetworks {
+		values := make(map[string]string)
+		for _, key := range []string{"uuid", "name", "port", "fixed_ip_v4"} {
+			v := rawNetwork.GetAttr(key)
+			if !v.IsNull() && v.IsKnown() {
+				values[key] = v.AsString()
+			}
+		}
+
+		network := InstanceNetwork{
+			UUID:          values["uuid"],
+			Name:          values["name"],
+			Port:          values["port"],
+			FixedIP:       values["fixed_ip_v4"],
+			AccessNetwork: d.Get(fmt.Sprintf("network.%d.access_network", i)).(bool),
+		}
+		if network.UUID == "" && network.Port == "" {
+			if network.Name == "" {
+				return nil, fmt.Errorf(
+					"at least one of network.uuid, network.name, or network.port must be set")
+			}
+			networkInfo, err := getInstanceNetworkInfo(d, meta, "name", network.Name)
+			if err != nil {
+				return nil, err
+			}
+			network.UUID = networkInfo["uuid"]
+		}
+		instanceNetworks[i] = network
+	}
+
+	log.Printf("[DEBUG] get the configured Instance Networks: %#v", instanceNetworks)
+	return instanceNetworks, nil
+}
+
+// diffInstanceNetworks matches the desired networks against the NICs in the state.
+// A network block matches the NIC with the same port, or the first unmatched NIC on
+// the same network (and with the same fixed IP if it is specified).
+// The first network is always matched with the primary NIC, which is never detached.
+// It returns the ports of the NICs to detach, and the matched port of each desired
+// network, an empty port means the NIC should be attached.
+func diffInstanceNetworks(oldNetworks []interface{}, desired []InstanceNetwork) ([]string, []string) {
+	matched := make([]bool, len(oldNetworks))
+	ports := make([]string, len(desired))
+
+	oldNIC := func(i int) map[string]interface{} {
+		return oldNetworks[i].(map[string]interface{})
+	}
+	if len(oldNetworks) > 0 {
+		matched[0] = true
+		if len(desired) > 0 {
+			ports[0] = oldNIC(0)["port"].(string)
+		}
+	}
+	for i, network := range desired {
+		if network.Port == "" || ports[i] != "" {
+			continue
+		}
+		for j := range oldNetworks {
+			if !matched[j] && oldNIC(j)["port"].(string) == network.Port {
+				matched[j] = true
+				ports[i] = network.Port
+				break
+			}
+		}
+	}
+	for i, network := range desired {
+		if network.Port != "" || ports[i] != "" {
+			continue
+		}
+		for j := range oldNetworks {
+			nic := oldNIC(j)
+			if matched[j] || nic["uuid"].(string) != network.UUID {
+				continue
+			}
+			if network.FixedIP != "" && nic["fixed_ip_v4"].(string) != network.FixedIP {
+				continue
+			}
+			matched[j] = true
+			ports[i] = nic["port"].(string)
+			break
+		}
+	}
+
+	var detachPorts []string
+	for j := range oldNetworks {
+		if port := oldNIC(j)["port"].(string); !matched[j] && port != "" {
+			detachPorts = append(detachPorts, port)
+		}
+	}
+	return detachPorts, ports
+}
+
+// updateInstanceNetworks hot-plugs the NICs which are added to or removed from the
+// network blocks, the primary NIC is never changed here.
+func updateInstanceNetworks(d *schema.ResourceData, meta interface{}, computeClient *golangsdk.ServiceClient) error {
+	oldRaw, _ := d.GetChange("network")
+	desired, err := getConfiguredInstanceNetworks(d, meta)
+	if err != nil {
+		return err
+	}
+
+	detachPorts, ports := diffInstanceNetworks(oldRaw.([]interface{}), desired)
+	for _, portID := range detachPorts {
+		log.Printf("[DEBUG] Detaching NIC %s from instance %s", portID, d.Id())
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{""},
+			Target:     []string{"DETACHED"},
+			Refresh:    computeInterfaceAttachV2DetachFunc(computeClient, d.Id(), portID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error detaching NIC %s from instance %s: %s", portID, d.Id(), err)
+		}
+	}
+
+	for i, network := range desired {
+		if ports[i] != "" {
+			continue
+		}
+
+		attachOpts := attachinterfaces.CreateOpts{
+			PortID:    network.Port,
+			NetworkID: network.UUID,
+		}
+		if network.Port == "" && network.FixedIP != "" {
+			attachOpts.FixedIPs = []attachinterfaces.FixedIP{{IPAddress: network.FixedIP}}
+		}
+		log.Printf("[DEBUG] Attaching NIC to instance %s: %#v", d.Id(), attachOpts)
+		attachment, err := attachinterfaces.Create(computeClient, d.Id(), attachOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error attaching NIC to instance %s: %s", d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"ATTACHING"},
+			Target:     []string{"ATTACHED"},
+			Refresh:    computeInterfaceAttachV2AttachFunc(computeClient, d.Id(), attachment.PortID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for NIC %s to be attached to instance %s: %s",
+				attachment.PortID, d.Id(), err)
+		}
+	}
+	return nil
+}
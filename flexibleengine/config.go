@@ -9,6 +9,7 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/chnsz/golangsdk"
@@ -23,9 +24,45 @@ import (
 // PublicType indicates that an endpoint is "public" in service catalog
 const PublicType golangsdk.Availability = golangsdk.AvailabilityPublic
 
+const (
+	// defaultAssumeRoleDuration is the default duration in seconds of the agency credentials
+	defaultAssumeRoleDuration = 3600
+	// agencyRefreshAhead is how long before expiry the agency credentials are refreshed
+	agencyRefreshAhead = 5 * time.Minute
+	// agencyRefreshMinBackoff and agencyRefreshMaxBackoff bound the delay before retrying
+	// a failed refresh of the agency credentials
+	agencyRefreshMinBackoff = 10 * time.Second
+	agencyRefreshMaxBackoff = 2 * time.Minute
+)
+
 // Config is the alias of huaweicloud Config
 type Config = huaweiconfig.Config
 
+// providerMetadata holds the provider settings which are not supported by huaweicloud Config,
+// it is stored in the Metadata field of Config.
+type providerMetadata struct {
+	// DefaultTags are the tags applied to all resources which support tags
+	DefaultTags map[string]string
+	// IgnoreTagKeys and IgnoreTagKeyPrefixes are the tags managed outside of Terraform
+	IgnoreTagKeys        []string
+	IgnoreTagKeyPrefixes []string
+	// AssumeRoleDuration is the duration in seconds of the temporary credentials of the agency
+	AssumeRoleDuration int
+
+	agencyCredentials *agencyCredentials
+}
+
+// getProviderMetadata returns the provider metadata stored in the Config, an empty one is
+// returned if the Config was not built by the provider (e.g. in unit tests).
+func getProviderMetadata(c *Config) *providerMetadata {
+	if c != nil {
+		if metadata, ok := c.Metadata.(*providerMetadata); ok {
+			return metadata
+		}
+	}
+	return &providerMetadata{}
+}
+
 // LoadAndValidate overwrites the the c.LoadAndValidate
 func LoadAndValidate(c *Config) error {
 	if c.MaxRetries < 0 {
@@ -50,6 +87,12 @@ func LoadAndValidate(c *Config) error {
 		return err
 	}
 
+	if c.AssumeRoleAgency != "" {
+		if err := buildClientByAgency(c); err != nil {
+			return err
+		}
+	}
+
 	if c.HwClient != nil && c.HwClient.ProjectID != "" {
 		c.RegionProjectIDMap[c.Region] = c.HwClient.ProjectID
 	}
@@ -264,6 +307,202 @@ func genClients(c *Config, pao, dao golangsdk.AuthOptionsProvider) error {
 	return err
 }
 
+// agencyCredentials obtains the temporary credentials of the agency through the IAM client
+// authenticated by the credentials configured in the provider, and refreshes them before expiry.
+// The clients keep the credentials obtained first, the requests are signed again with the latest
+// credentials by agencySigner, which reads them under the same lock as refresh replaces them.
+type agencyCredentials struct {
+	config    *Config
+	iamClient *golangsdk.ServiceClient
+	duration  int
+
+	lock      sync.RWMutex
+	current   *agencySecurityToken
+	expiresAt time.Time
+}
+
+type agencySecurityToken struct {
+	Access        string `json:"access"`
+	Secret        string `json:"secret"`
+	SecurityToken string `json:"securitytoken"`
+	ExpiresAt     string `json:"expires_at"`
+}
+
+// buildClientByAgency rebuilds the clients with the temporary AK/SK and security token of the agency,
+// then the resources are managed in the domain which created the agency.
+func buildClientByAgency(c *Config) error {
+	iamClient, err := c.IAMV3Client(c.Region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine IAM client: %s", err)
+	}
+
+	metadata := getProviderMetadata(c)
+	credentials := &agencyCredentials{
+		config:    c,
+		iamClient: iamClient,
+		duration:  metadata.AssumeRoleDuration,
+	}
+	if credentials.duration == 0 {
+		credentials.duration = defaultAssumeRoleDuration
+	}
+
+	token, expiresAt, err := credentials.create()
+	if err != nil {
+		return err
+	}
+	credentials.current, credentials.expiresAt = token, expiresAt
+
+	c.AccessKey, c.SecretKey, c.SecurityToken = token.Access, token.Secret, token.SecurityToken
+	// the domain ID will be fetched again by the temporary credentials
+	c.DomainID = ""
+	c.DomainName = c.AssumeRoleDomain
+	if err := buildClientByAKSK(c); err != nil {
+		return fmt.Errorf("Error authenticating with the temporary credentials of agency %s: %s",
+			c.AssumeRoleAgency, err)
+	}
+
+	for _, client := range []*golangsdk.ProviderClient{c.HwClient, c.DomainClient} {
+		client.ReauthFunc = credentials.refresh
+		client.HTTPClient.Transport = &agencySigner{
+			Rt:          client.HTTPClient.Transport,
+			credentials: credentials,
+		}
+	}
+	credentials.scheduleRefresh(time.Until(expiresAt)-agencyRefreshAhead, agencyRefreshMinBackoff)
+
+	metadata.agencyCredentials = credentials
+	c.Metadata = metadata
+	return nil
+}
+
+// create requests a new temporary AK/SK and security token of the agency.
+func (a *agencyCredentials) create() (*agencySecurityToken, time.Time, error) {
+	reqBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"assume_role"},
+				"assume_role": map[string]interface{}{
+					"agency_name":      a.config.AssumeRoleAgency,
+					"domain_name":      a.config.AssumeRoleDomain,
+					"duration_seconds": a.duration,
+				},
+			},
+		},
+	}
+
+	var rst struct {
+		Credential agencySecurityToken `json:"credential"`
+	}
+	url := a.iamClient.ServiceURL("OS-CREDENTIAL", "securitytokens")
+	_, err := a.iamClient.Post(url, reqBody, &rst, &golangsdk.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error creating the temporary credentials of agency %s in domain %s: %s",
+			a.config.AssumeRoleAgency, a.config.AssumeRoleDomain, err)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, rst.Credential.ExpiresAt)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error parsing the expiration time of the temporary credentials: %s", err)
+	}
+	log.Printf("[DEBUG] The temporary credentials of agency %s will expire at %s", a.config.AssumeRoleAgency, expiresAt)
+
+	return &rst.Credential, expiresAt, nil
+}
+
+// get returns the latest temporary credentials of the agency and when they expire.
+func (a *agencyCredentials) get() (*agencySecurityToken, time.Time) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.current, a.expiresAt
+}
+
+// refresh replaces the temporary credentials used to sign the requests, it is used as the ReauthFunc
+// of the clients and is also called before the credentials expire.
+func (a *agencyCredentials) refresh() error {
+	// the IAM request is signed by the credentials configured in the provider, so it is sent
+	// without holding the lock and the requests in flight are not blocked
+	token, expiresAt, err := a.create()
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.current, a.expiresAt = token, expiresAt
+	return nil
+}
+
+// scheduleRefresh refreshes the temporary credentials after the delay, so that the requests sent
+// during a long apply are not rejected. A failed refresh is retried with an exponential backoff.
+func (a *agencyCredentials) scheduleRefresh(delay, backoff time.Duration) {
+	time.AfterFunc(delay, func() {
+		if err := a.refresh(); err != nil {
+			log.Printf("[WARN] Error refreshing the temporary credentials of agency %s, retry in %s: %s",
+				a.config.AssumeRoleAgency, backoff, err)
+
+			next := backoff * 2
+			if next > agencyRefreshMaxBackoff {
+				next = agencyRefreshMaxBackoff
+			}
+			a.scheduleRefresh(backoff, next)
+			return
+		}
+
+		_, expiresAt := a.get()
+		a.scheduleRefresh(time.Until(expiresAt)-agencyRefreshAhead, agencyRefreshMinBackoff)
+	})
+}
+
+// agencySigner signs the requests again with the latest temporary credentials of the agency.
+// Only the requests signed with AK/SK by golangsdk are changed, the ones signed by other SDKs
+// (e.g. OBS) are sent as they are.
+type agencySigner struct {
+	Rt          http.RoundTripper
+	credentials *agencyCredentials
+}
+
+// the headers which are set by golangsdk after signing the request
+var agencyUnsignedHeaders = []string{"X-Project-Id", "X-Domain-Id", "X-Security-Token"}
+
+func (s *agencySigner) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.Header.Get("Authorization"), "SDK-HMAC-SHA256") {
+		return s.Rt.RoundTrip(req)
+	}
+
+	token, _ := s.credentials.get()
+	orig := req
+	req = req.Clone(req.Context())
+	unsigned := make(map[string]string)
+	for _, key := range agencyUnsignedHeaders {
+		if v := req.Header.Get(key); v != "" {
+			unsigned[key] = v
+			req.Header.Del(key)
+		}
+	}
+	req.Header.Del("Authorization")
+
+	// the body of the cloned request is replaced with a copy when it is signed
+	err := auth.Sign(req, token.Access, token.Secret)
+	if orig.Body != nil {
+		orig.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for key, v := range unsigned {
+		req.Header.Set(key, v)
+	}
+	req.Header.Set("X-Security-Token", token.SecurityToken)
+	return s.Rt.RoundTrip(req)
+}
+
+// Unwrap returns the RoundTripper wrapped by agencySigner.
+func (s *agencySigner) Unwrap() http.RoundTripper {
+	return s.Rt
+}
+
 func getDomainID(c *Config) (string, error) {
 	identityClient, err := c.IdentityV3Client(c.Region)
 	if err != nil {
@@ -3,12 +3,22 @@ package flexibleengine
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/chnsz/golangsdk/openstack/obs"
 	th "github.com/chnsz/golangsdk/testhelper"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 const (
@@ -559,3 +569,237 @@ func TestCheckOssEndpoint(t *testing.T) {
 	expected = "https://oss.eu-west-1.prod-cloud-ocb.orange-business.com/"
 	th.AssertEquals(t, expected, getOssEndpoint(cfg, "eu-west-1"))
 }
+
+func TestUnitConfig_mockCloud(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	testProvider, _ := srv.ProviderFactories(Provider)["flexibleengine"]()
+
+	raw := map[string]interface{}{
+		"region":     mockcloud.Region,
+		"auth_url":   srv.Endpoint + "/iam/v3",
+		"access_key": mockcloud.AccessKey,
+		"secret_key": mockcloud.SecretKey,
+		"endpoints": map[string]interface{}{
+			"iam": srv.Endpoint + "/iam/",
+			"vpc": srv.Endpoint + "/vpc/",
+			"obs": srv.Endpoint + "/",
+		},
+	}
+	diags := testProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error when configure FlexibleEngine provider: %s", diags[0].Summary)
+	}
+
+	config := testProvider.Meta().(*Config)
+	th.AssertEquals(t, mockcloud.ProjectID, config.RegionProjectIDMap[mockcloud.Region])
+	th.AssertEquals(t, mockcloud.DomainID, config.DomainID)
+
+	// the VPC v1 API is served by the mock cloud
+	vpcClient, err := config.NetworkingV1Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+
+	vpc, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, srv.Exists("vpcs", vpc.ID))
+
+	vpc, err = vpcs.Get(vpcClient, vpc.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "vpc-mock", vpc.Name)
+
+	th.AssertNoErr(t, vpcs.Delete(vpcClient, vpc.ID).ExtractErr())
+	_, err = vpcs.Get(vpcClient, vpc.ID).Extract()
+	if _, ok := err.(golangsdk.ErrDefault404); !ok {
+		t.Fatalf("expected a 404 error after deleting the VPC, got: %v", err)
+	}
+
+	// the OBS virtual-hosted-style requests are routed to the mock cloud
+	obsClient, err := config.ObjectStorageClient(mockcloud.Region)
+	th.AssertNoErr(t, err)
+
+	_, err = obsClient.CreateBucket(&obs.CreateBucketInput{Bucket: "bucket-mock"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, srv.Exists("buckets", "bucket-mock"))
+
+	policy, err := obsClient.GetBucketStoragePolicy("bucket-mock")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "STANDARD", policy.StorageClass)
+
+	_, err = obsClient.GetBucketLifecycleConfiguration("bucket-mock")
+	if obsError, ok := err.(obs.ObsError); !ok || obsError.Code != "NoSuchLifecycleConfiguration" {
+		t.Fatalf("expected NoSuchLifecycleConfiguration error, got: %v", err)
+	}
+
+	_, err = obsClient.DeleteBucket("bucket-mock")
+	th.AssertNoErr(t, err)
+	_, err = obsClient.HeadBucket("bucket-mock")
+	if obsError, ok := err.(obs.ObsError); !ok || obsError.StatusCode != 404 {
+		t.Fatalf("expected a 404 error after deleting the bucket, got: %v", err)
+	}
+}
+
+func TestUnitConfig_assumeRole(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	testProvider, _ := srv.ProviderFactories(Provider)["flexibleengine"]()
+
+	raw := map[string]interface{}{
+		"region":     mockcloud.Region,
+		"auth_url":   srv.Endpoint + "/iam/v3",
+		"access_key": mockcloud.AccessKey,
+		"secret_key": mockcloud.SecretKey,
+		"assume_role": []interface{}{
+			map[string]interface{}{
+				"agency_name": "ci-agency",
+				"domain_name": mockcloud.DomainName,
+				"duration":    900,
+			},
+		},
+		"endpoints": map[string]interface{}{
+			"iam": srv.Endpoint + "/iam/",
+			"vpc": srv.Endpoint + "/vpc/",
+		},
+	}
+	diags := testProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error when configure FlexibleEngine provider: %s", diags[0].Summary)
+	}
+
+	config := testProvider.Meta().(*Config)
+	th.AssertEquals(t, mockcloud.DomainID, config.DomainID)
+	th.AssertEquals(t, mockcloud.DomainName, config.DomainName)
+
+	// both clients use the temporary credentials of the agency
+	accessKey := config.AccessKey
+	if accessKey == mockcloud.AccessKey || config.SecurityToken == "" {
+		t.Fatalf("expected the temporary credentials of the agency, got access key %s", accessKey)
+	}
+	for _, client := range []*golangsdk.ProviderClient{config.HwClient, config.DomainClient} {
+		th.AssertEquals(t, accessKey, client.AKSKAuthOptions.AccessKey)
+		th.AssertEquals(t, config.SecurityToken, client.AKSKAuthOptions.SecurityToken)
+	}
+
+	credentials := getProviderMetadata(config).agencyCredentials
+	if credentials == nil || time.Until(credentials.expiresAt) > 900*time.Second {
+		t.Fatalf("expected the agency credentials to expire in 900 seconds, got %v", credentials)
+	}
+
+	vpcClient, err := config.NetworkingV1Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+	_, err = vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, config.SecurityToken, srv.LastRequestHeader().Get("X-Security-Token"))
+
+	// the refreshed credentials are used by the existing clients, which are never changed
+	// so that the requests in flight are not affected
+	th.AssertNoErr(t, credentials.refresh())
+	token, _ := credentials.get()
+	if token.Access == accessKey {
+		t.Fatalf("expected the temporary credentials to be refreshed")
+	}
+	th.AssertEquals(t, accessKey, config.HwClient.AKSKAuthOptions.AccessKey)
+
+	_, err = vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+	header := srv.LastRequestHeader()
+	th.AssertEquals(t, token.SecurityToken, header.Get("X-Security-Token"))
+	if !strings.Contains(header.Get("Authorization"), "Access="+token.Access) {
+		t.Fatalf("expected the request to be signed by the refreshed credentials, got %s", header.Get("Authorization"))
+	}
+
+	// the credentials can be refreshed while the requests are being sent
+	done := make(chan error)
+	go func() {
+		done <- credentials.refresh()
+	}()
+	_, err = vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertNoErr(t, <-done)
+}
+
+func TestUnitConfig_assumeRoleRefreshRetry(t *testing.T) {
+	// the IAM service is unavailable for the first two requests
+	var count int32
+	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&count, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"credential": {"access": "TEMPACCESSKEY", "secret": "TEMPSECRETKEY",
+			"securitytoken": "token", "expires_at": "%s"}}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	defer iamServer.Close()
+
+	credentials := &agencyCredentials{
+		config: &Config{AssumeRoleAgency: "ci-agency", AssumeRoleDomain: mockcloud.DomainName},
+		iamClient: &golangsdk.ServiceClient{
+			ProviderClient: &golangsdk.ProviderClient{},
+			Endpoint:       iamServer.URL + "/v3/",
+		},
+		duration: 900,
+	}
+
+	credentials.scheduleRefresh(0, 10*time.Millisecond)
+	for i := 0; i < 100; i++ {
+		if token, _ := credentials.get(); token != nil {
+			th.AssertEquals(t, "TEMPACCESSKEY", token.Access)
+			th.AssertEquals(t, int32(3), atomic.LoadInt32(&count))
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected the failed refresh to be retried")
+}
+
+func TestUnitConfig_sharedConfigFile(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	testProvider, _ := srv.ProviderFactories(Provider)["flexibleengine"]()
+
+	for _, env := range []string{"OS_REGION_NAME", "OS_AUTH_URL", "OS_ACCESS_KEY", "OS_SECRET_KEY",
+		"OS_PASSWORD", "OS_AUTH_TOKEN", "OS_TENANT_NAME", "OS_PROJECT_NAME"} {
+		t.Setenv(env, "")
+	}
+
+	filename := filepath.Join(t.TempDir(), "credentials")
+	content := fmt.Sprintf(`[default]
+region = eu-west-0
+
+[mock]
+region = %s
+auth_url = %s/iam/v3
+access_key = %s
+secret_key = %s
+`, mockcloud.Region, srv.Endpoint, mockcloud.AccessKey, mockcloud.SecretKey)
+	th.AssertNoErr(t, os.WriteFile(filename, []byte(content), 0600))
+
+	raw := map[string]interface{}{
+		"shared_config_file": filename,
+		"profile":            "mock",
+		"endpoints": map[string]interface{}{
+			"iam": srv.Endpoint + "/iam/",
+			"vpc": srv.Endpoint + "/vpc/",
+		},
+	}
+	diags := testProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error when configure FlexibleEngine provider: %s", diags[0].Summary)
+	}
+
+	config := testProvider.Meta().(*Config)
+	th.AssertEquals(t, mockcloud.Region, config.Region)
+	th.AssertEquals(t, mockcloud.Region, config.TenantName)
+	th.AssertEquals(t, mockcloud.AccessKey, config.AccessKey)
+
+	vpcClient, err := config.NetworkingV1Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+	_, err = vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+
+	// the same profile is used by the S3 credentials chain
+	creds, err := GetCredentials(&Config{SharedConfigFile: filename, Profile: "mock"})
+	th.AssertNoErr(t, err)
+	value, err := creds.Get()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, sharedProfileCredentialsName, value.ProviderName)
+	th.AssertEquals(t, mockcloud.SecretKey, value.SecretAccessKey)
+}
@@ -0,0 +1,139 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/evs/v2/snapshots"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+func dataSourceEvsSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEvsSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEvsSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	blockStorageClient, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	listOpts := snapshots.ListOpts{
+		ID:       d.Get("snapshot_id").(string),
+		VolumeID: d.Get("volume_id").(string),
+		Name:     d.Get("name").(string),
+		Status:   d.Get("status").(string),
+	}
+
+	pages, err := snapshots.List(blockStorageClient, listOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve EVS snapshots: %s", err)
+	}
+	allSnapshots, err := snapshots.ExtractSnapshots(pages)
+	if err != nil {
+		return fmt.Errorf("Unable to extract EVS snapshots: %s", err)
+	}
+	log.Printf("[DEBUG] Retrieved %d EVS snapshots", len(allSnapshots))
+
+	ids := make([]string, len(allSnapshots))
+	result := make([]map[string]interface{}, len(allSnapshots))
+	for i, snapshot := range allSnapshots {
+		ids[i] = snapshot.ID
+		result[i] = map[string]interface{}{
+			"id":          snapshot.ID,
+			"volume_id":   snapshot.VolumeID,
+			"name":        snapshot.Name,
+			"description": snapshot.Description,
+			"status":      snapshot.Status,
+			"size":        snapshot.Size,
+			"metadata":    snapshot.Metadata,
+			"created_at":  snapshot.CreatedAt.Format(time.RFC3339),
+			"updated_at":  snapshot.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	d.SetId(hashcode.Strings(ids))
+
+	if err := d.Set("region", region); err != nil {
+		return err
+	}
+	if err := d.Set("snapshots", result); err != nil {
+		return fmt.Errorf("Error setting EVS snapshots: %s", err)
+	}
+	return nil
+}
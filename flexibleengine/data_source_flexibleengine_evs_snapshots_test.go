@@ -0,0 +1,42 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEvsSnapshotsDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	dataSourceName := "data.flexibleengine_evs_snapshots.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEvsSnapshotsDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "snapshots.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "snapshots.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "snapshots.0.status", "available"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "snapshots.0.id",
+						"flexibleengine_evs_snapshot.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEvsSnapshotsDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "flexibleengine_evs_snapshots" "test" {
+  volume_id = flexibleengine_evs_snapshot.test.volume_id
+  name      = flexibleengine_evs_snapshot.test.name
+}
+`, testAccEvsSnapshot_basic(rName, "created by acc test"))
+}
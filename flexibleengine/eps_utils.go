@@ -0,0 +1,38 @@
+package flexibleengine
+
+import (
+	"context"
+	"log"
+
+	"github.com/chnsz/golangsdk/openstack/eps/v1/enterpriseprojects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+)
+
+// resourceEnterpriseProjectIDSchema returns the schema of enterprise_project_id which can be
+// updated in place by migrating the resource to another enterprise project.
+func resourceEnterpriseProjectIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+}
+
+// migrateEnterpriseProject moves the resource to the enterprise project specified in the
+// configuration through the EPS migrate API, and waits until the migration is completed.
+// The resourceType is the type defined by EPS, such as ecs, disk, eip and vpcs.
+func migrateEnterpriseProject(d *schema.ResourceData, config *Config, resourceType, resourceID string) error {
+	region := GetRegion(d, config)
+	migrateOpts := enterpriseprojects.MigrateResourceOpts{
+		ResourceId:   resourceID,
+		ResourceType: resourceType,
+		RegionId:     region,
+		ProjectId:    config.GetProjectID(region),
+	}
+
+	log.Printf("[DEBUG] Migrate %s %s to the enterprise project %s", resourceType, resourceID,
+		d.Get("enterprise_project_id").(string))
+	return common.MigrateEnterpriseProject(context.TODO(), config, d, migrateOpts)
+}
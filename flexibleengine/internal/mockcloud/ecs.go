@@ -0,0 +1,375 @@
+package mockcloud
+
+import (
+	"net/http"
+)
+
+func (s *Server) registerECS() {
+	// Nova compatible APIs
+	s.handle(http.MethodPost, `/ecs/v2\.1/[^/]+/servers`, s.createServer)
+	s.handle(http.MethodGet, `/ecs/v2\.1/[^/]+/servers/([^/]+)`, s.getNovaServer)
+	s.handle(http.MethodPut, `/ecs/v2\.1/[^/]+/servers/([^/]+)`, s.updateServer)
+	s.handle(http.MethodDelete, `/ecs/v2\.1/[^/]+/servers/([^/]+)`, s.deleteServer)
+	s.handle(http.MethodPost, `/ecs/v2\.1/[^/]+/servers/([^/]+)/action`, s.serverAction)
+	s.handle(http.MethodPost, `/ecs/v2\.1/[^/]+/servers/([^/]+)/metadata`, s.updateServerMetadata)
+	s.handle(http.MethodPut, `/ecs/v2\.1/[^/]+/servers/([^/]+)/metadata`, s.updateServerMetadata)
+	s.handle(http.MethodDelete, `/ecs/v2\.1/[^/]+/servers/([^/]+)/metadata/([^/]+)`, s.deleteServerMetadatum)
+	s.handle(http.MethodGet, `/ecs/v2\.1/[^/]+/flavors/detail`, s.listFlavors)
+	s.handle(http.MethodGet, `/ecs/v2\.1/[^/]+/flavors/([^/]+)`, s.getFlavor)
+
+	// ECS native APIs
+	s.handle(http.MethodGet, `/ecs/v1/[^/]+/cloudservers/([^/]+)`, s.getCloudServer)
+	s.handle(http.MethodGet, `/ecs/v1/[^/]+/cloudservers/([^/]+)/autorecovery`, s.getAutoRecovery)
+	s.handle(http.MethodPut, `/ecs/v1/[^/]+/cloudservers/([^/]+)/autorecovery`, s.updateAutoRecovery)
+
+	// IMS
+	s.handle(http.MethodGet, `/ims/v2/images`, s.listImages)
+	s.handle(http.MethodGet, `/ims/v2/images/([^/]+)`, s.getImage)
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request, _ []string) {
+	opts := readBody(r, "server")
+	id := newID()
+
+	secgroups := []interface{}{}
+	if raw, ok := opts["security_groups"].([]interface{}); ok {
+		for _, sg := range raw {
+			secgroups = append(secgroups, stringValue(sg.(map[string]interface{}), "name", ""))
+		}
+	}
+	if len(secgroups) == 0 {
+		secgroups = append(secgroups, "default")
+	}
+
+	nics := []interface{}{}
+	if raw, ok := opts["networks"].([]interface{}); ok {
+		for _, n := range raw {
+			network := n.(map[string]interface{})
+			var port map[string]interface{}
+			if portID := stringValue(network, "port", ""); portID != "" {
+				port = s.collection("ports")[portID]
+			}
+			if port == nil {
+				port = s.createPort(stringValue(network, "uuid", ""), stringValue(network, "fixed_ip", ""), id)
+			}
+			port["device_id"] = id
+			nics = append(nics, port["id"])
+		}
+	}
+
+	metadata := map[string]interface{}{}
+	if raw, ok := opts["metadata"].(map[string]interface{}); ok {
+		metadata = raw
+	}
+
+	server := map[string]interface{}{
+		"id":                id,
+		"name":              stringValue(opts, "name", ""),
+		"status":            "ACTIVE",
+		"flavor_id":         stringValue(opts, "flavorRef", FlavorID),
+		"image_id":          stringValue(opts, "imageRef", ""),
+		"availability_zone": stringValue(opts, "availability_zone", AvailabilityZone),
+		"key_name":          stringValue(opts, "key_name", ""),
+		"security_groups":   secgroups,
+		"ports":             nics,
+		"metadata":          metadata,
+		"auto_recovery":     "false",
+		"created":           now(),
+	}
+	s.collection("servers")[id] = server
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id":        id,
+			"adminPass": "MockPassw0rd",
+			"links":     []interface{}{},
+		},
+	})
+}
+
+func (s *Server) getNovaServer(w http.ResponseWriter, _ *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	secgroups := []interface{}{}
+	for _, name := range server["security_groups"].([]interface{}) {
+		secgroups = append(secgroups, map[string]interface{}{"name": name})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id":                                   server["id"],
+			"name":                                 server["name"],
+			"status":                               server["status"],
+			"tenant_id":                            ProjectID,
+			"flavor":                               map[string]interface{}{"id": server["flavor_id"]},
+			"image":                                map[string]interface{}{"id": server["image_id"]},
+			"metadata":                             server["metadata"],
+			"key_name":                             server["key_name"],
+			"security_groups":                      secgroups,
+			"addresses":                            s.serverAddresses(server),
+			"created":                              server["created"].(string) + "Z",
+			"updated":                              server["created"].(string) + "Z",
+			"OS-EXT-AZ:availability_zone":          server["availability_zone"],
+			"os-extended-volumes:volumes_attached": []interface{}{},
+		},
+	})
+}
+
+func (s *Server) getCloudServer(w http.ResponseWriter, _ *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	secgroups := []interface{}{}
+	for _, name := range server["security_groups"].([]interface{}) {
+		secgroups = append(secgroups, map[string]interface{}{"name": name})
+	}
+
+	metadata := map[string]interface{}{}
+	for k, v := range server["metadata"].(map[string]interface{}) {
+		metadata[k] = v
+	}
+	metadata["image_name"] = ImageName
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id":        server["id"],
+			"name":      server["name"],
+			"status":    server["status"],
+			"tenant_id": ProjectID,
+			"flavor": map[string]interface{}{
+				"id":    server["flavor_id"],
+				"name":  server["flavor_id"],
+				"vcpus": "2",
+				"ram":   "4096",
+				"disk":  "0",
+			},
+			"image":                                map[string]interface{}{"id": server["image_id"]},
+			"metadata":                             metadata,
+			"key_name":                             server["key_name"],
+			"security_groups":                      secgroups,
+			"addresses":                            s.serverAddresses(server),
+			"enterprise_project_id":                "0",
+			"OS-EXT-AZ:availability_zone":          server["availability_zone"],
+			"os-extended-volumes:volumes_attached": []interface{}{},
+		},
+	})
+}
+
+// serverAddresses builds the "addresses" field, which is keyed by the VPC ID on
+// FlexibleEngine and by the network name in Nova. Both fall back to the network ID.
+func (s *Server) serverAddresses(server map[string]interface{}) map[string]interface{} {
+	addresses := map[string]interface{}{}
+	for _, portID := range server["ports"].([]interface{}) {
+		port, ok := s.collection("ports")[portID.(string)]
+		if !ok {
+			continue
+		}
+
+		networkID := port["network_id"].(string)
+		fixedIPs := port["fixed_ips"].([]interface{})
+		ip := fixedIPs[0].(map[string]interface{})["ip_address"]
+
+		list, _ := addresses[networkID].([]interface{})
+		addresses[networkID] = append(list, map[string]interface{}{
+			"version":                 "4",
+			"addr":                    ip,
+			"OS-EXT-IPS:type":         "fixed",
+			"OS-EXT-IPS:port_id":      port["id"],
+			"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+		})
+	}
+	return addresses
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	opts := readBody(r, "server")
+	if name, ok := opts["name"]; ok {
+		server["name"] = name
+	}
+	s.getNovaServer(w, r, params)
+}
+
+func (s *Server) deleteServer(w http.ResponseWriter, _ *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	for _, portID := range server["ports"].([]interface{}) {
+		delete(s.collection("ports"), portID.(string))
+	}
+	delete(s.collection("servers"), params[0])
+	delete(s.tags, params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serverAction(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	body := readBody(r, "")
+	for action, raw := range body {
+		opts, _ := raw.(map[string]interface{})
+		switch action {
+		case "addSecurityGroup":
+			server["security_groups"] = append(server["security_groups"].([]interface{}), stringValue(opts, "name", ""))
+		case "removeSecurityGroup":
+			name := stringValue(opts, "name", "")
+			secgroups := []interface{}{}
+			for _, sg := range server["security_groups"].([]interface{}) {
+				if sg != name {
+					secgroups = append(secgroups, sg)
+				}
+			}
+			server["security_groups"] = secgroups
+		case "resize":
+			server["flavor_id"] = stringValue(opts, "flavorRef", server["flavor_id"].(string))
+			server["status"] = "VERIFY_RESIZE"
+		case "confirmResize", "os-start":
+			server["status"] = "ACTIVE"
+		case "os-stop":
+			server["status"] = "SHUTOFF"
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) updateServerMetadata(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	metadata := server["metadata"].(map[string]interface{})
+	for k, v := range readBody(r, "metadata") {
+		metadata[k] = v
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"metadata": metadata})
+}
+
+func (s *Server) deleteServerMetadatum(w http.ResponseWriter, _ *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	delete(server["metadata"].(map[string]interface{}), params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getAutoRecovery(w http.ResponseWriter, _ *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"support_auto_recovery": server["auto_recovery"],
+	})
+}
+
+func (s *Server) updateAutoRecovery(w http.ResponseWriter, r *http.Request, params []string) {
+	server, ok := s.collection("servers")[params[0]]
+	if !ok {
+		notFound(w, "Server", params[0])
+		return
+	}
+
+	server["auto_recovery"] = stringValue(readBody(r, ""), "support_auto_recovery", "false")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listFlavors(w http.ResponseWriter, _ *http.Request, _ []string) {
+	flavors := []interface{}{}
+	for _, flavor := range s.collection("flavors") {
+		flavors = append(flavors, flavor)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"flavors": flavors})
+}
+
+func (s *Server) getFlavor(w http.ResponseWriter, _ *http.Request, params []string) {
+	flavor, ok := s.collection("flavors")[params[0]]
+	if !ok {
+		notFound(w, "Flavor", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"flavor": flavor})
+}
+
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request, _ []string) {
+	query := r.URL.Query()
+	images := []interface{}{}
+	for _, image := range s.collection("images") {
+		if id := query.Get("id"); id != "" && id != image["id"] {
+			continue
+		}
+		if name := query.Get("name"); name != "" && name != image["name"] {
+			continue
+		}
+		images = append(images, image)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"images": images})
+}
+
+func (s *Server) getImage(w http.ResponseWriter, _ *http.Request, params []string) {
+	image, ok := s.collection("images")[params[0]]
+	if !ok {
+		notFound(w, "Image", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, image)
+}
+
+// seed creates the fixtures which are referenced but not managed by the tests.
+func (s *Server) seed() {
+	s.collection("networks")[NetworkID] = map[string]interface{}{
+		"id":             NetworkID,
+		"name":           NetworkName,
+		"status":         "ACTIVE",
+		"admin_state_up": true,
+		"tenant_id":      ProjectID,
+		"subnets":        []interface{}{},
+	}
+
+	s.collection("images")[ImageID] = map[string]interface{}{
+		"id":               ImageID,
+		"name":             ImageName,
+		"status":           "active",
+		"visibility":       "public",
+		"container_format": "bare",
+		"disk_format":      "zvhd2",
+		"min_disk":         40,
+		"min_ram":          0,
+		"size":             0,
+		"tags":             []interface{}{},
+		"created_at":       "2023-01-01T00:00:00Z",
+		"updated_at":       "2023-01-01T00:00:00Z",
+	}
+
+	s.collection("flavors")[FlavorID] = map[string]interface{}{
+		"id":    FlavorID,
+		"name":  FlavorID,
+		"vcpus": 2,
+		"ram":   4096,
+		"disk":  0,
+	}
+}
@@ -0,0 +1,113 @@
+package mockcloud
+
+import (
+	"net/http"
+)
+
+func (s *Server) registerEVS() {
+	s.handle(http.MethodPost, `/evs/v2/[^/]+/volumes`, s.createVolume)
+	s.handle(http.MethodGet, `/evs/v2/[^/]+/volumes/detail`, s.listVolumes)
+	s.handle(http.MethodGet, `/evs/v2/[^/]+/volumes/([^/]+)`, s.getVolume)
+	s.handle(http.MethodPut, `/evs/v2/[^/]+/volumes/([^/]+)`, s.updateVolume)
+	s.handle(http.MethodDelete, `/evs/v2/[^/]+/volumes/([^/]+)`, s.deleteVolume)
+	s.handle(http.MethodPost, `/evs/v2/[^/]+/volumes/([^/]+)/action`, s.volumeAction)
+	s.handle(http.MethodGet, `/evs/v2/[^/]+/cloudvolumes/([^/]+)`, s.getVolume)
+}
+
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request, _ []string) {
+	opts := readBody(r, "volume")
+
+	size := 10
+	if v, ok := opts["size"].(float64); ok {
+		size = int(v)
+	}
+	metadata := map[string]interface{}{}
+	if raw, ok := opts["metadata"].(map[string]interface{}); ok {
+		metadata = raw
+	}
+	multiattach, _ := opts["multiattach"].(bool)
+
+	volume := map[string]interface{}{
+		"id":                newID(),
+		"name":              stringValue(opts, "name", ""),
+		"description":       stringValue(opts, "description", ""),
+		"size":              size,
+		"status":            "available",
+		"availability_zone": stringValue(opts, "availability_zone", AvailabilityZone),
+		"volume_type":       stringValue(opts, "volume_type", "SATA"),
+		"snapshot_id":       stringValue(opts, "snapshot_id", ""),
+		"source_volid":      stringValue(opts, "source_volid", ""),
+		"metadata":          metadata,
+		"multiattach":       multiattach,
+		"bootable":          "false",
+		"attachments":       []interface{}{},
+		"created_at":        now(),
+	}
+	s.collection("volumes")[volume["id"].(string)] = volume
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"volume": volume})
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request, _ []string) {
+	query := r.URL.Query()
+	volumes := []interface{}{}
+	for _, volume := range s.collection("volumes") {
+		if name := query.Get("name"); name != "" && name != volume["name"] {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volumes": volumes})
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, _ *http.Request, params []string) {
+	volume, ok := s.collection("volumes")[params[0]]
+	if !ok {
+		notFound(w, "Volume", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": volume})
+}
+
+func (s *Server) updateVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume, ok := s.collection("volumes")[params[0]]
+	if !ok {
+		notFound(w, "Volume", params[0])
+		return
+	}
+
+	opts := readBody(r, "volume")
+	for _, key := range []string{"name", "description", "metadata"} {
+		if v, ok := opts[key]; ok {
+			volume[key] = v
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": volume})
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, _ *http.Request, params []string) {
+	if _, ok := s.collection("volumes")[params[0]]; !ok {
+		notFound(w, "Volume", params[0])
+		return
+	}
+
+	delete(s.collection("volumes"), params[0])
+	delete(s.tags, params[0])
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) volumeAction(w http.ResponseWriter, r *http.Request, params []string) {
+	volume, ok := s.collection("volumes")[params[0]]
+	if !ok {
+		notFound(w, "Volume", params[0])
+		return
+	}
+
+	body := readBody(r, "")
+	if opts, ok := body["os-extend"].(map[string]interface{}); ok {
+		if size, ok := opts["new_size"].(float64); ok {
+			volume["size"] = int(size)
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
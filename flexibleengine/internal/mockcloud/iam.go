@@ -0,0 +1,77 @@
+package mockcloud
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) registerIAM() {
+	s.handle(http.MethodGet, `/iam/v3/projects`, s.listProjects)
+	s.handle(http.MethodGet, `/iam/v3/auth/projects`, s.listProjects)
+	s.handle(http.MethodGet, `/iam/v3/auth/catalog`, s.listCatalog)
+	s.handle(http.MethodGet, `/iam/v3/auth/domains`, s.listDomains)
+	s.handle(http.MethodPost, `/iam/v3\.0/OS-CREDENTIAL/securitytokens`, s.createSecurityToken)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ []string) {
+	projects := []interface{}{}
+	if name := r.URL.Query().Get("name"); name == "" || name == Region {
+		projects = append(projects, map[string]interface{}{
+			"id":        ProjectID,
+			"name":      Region,
+			"domain_id": DomainID,
+			"enabled":   true,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projects": projects,
+		"links":    map[string]interface{}{"next": nil},
+	})
+}
+
+// listCatalog returns an empty catalog: every service is reached through the
+// custom endpoints configured in the provider block.
+func (s *Server) listCatalog(w http.ResponseWriter, _ *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"catalog": []interface{}{},
+	})
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, _ *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains": []interface{}{
+			map[string]interface{}{
+				"id":      DomainID,
+				"name":    DomainName,
+				"enabled": true,
+			},
+		},
+		"links": map[string]interface{}{"next": nil},
+	})
+}
+
+// createSecurityToken returns a new temporary AK/SK and security token for every assume_role request.
+func (s *Server) createSecurityToken(w http.ResponseWriter, r *http.Request, _ []string) {
+	identity, _ := readBody(r, "auth")["identity"].(map[string]interface{})
+	assumeRole, _ := identity["assume_role"].(map[string]interface{})
+	if stringValue(assumeRole, "agency_name", "") == "" || stringValue(assumeRole, "domain_name", "") == "" {
+		writeError(w, http.StatusBadRequest, "agency_name and domain_name are required")
+		return
+	}
+
+	duration := 900
+	if v, ok := assumeRole["duration_seconds"].(float64); ok {
+		duration = int(v)
+	}
+	id := strings.ToUpper(strings.ReplaceAll(newID(), "-", ""))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"credential": map[string]interface{}{
+			"access":        "TEMP" + id[:16],
+			"secret":        id,
+			"securitytoken": "token-" + id,
+			"expires_at":    time.Now().UTC().Add(time.Duration(duration) * time.Second).Format(time.RFC3339),
+		},
+	})
+}
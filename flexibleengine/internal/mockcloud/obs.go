@@ -0,0 +1,206 @@
+package mockcloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// bucketSubResources are the OBS sub-resources whose configuration is stored as
+// the raw XML document sent by the client and returned unchanged on GET.
+var bucketSubResources = map[string]string{
+	"encryption": "NoSuchEncryptionConfiguration",
+	"lifecycle":  "NoSuchLifecycleConfiguration",
+	"website":    "NoSuchWebsiteConfiguration",
+	"cors":       "NoSuchCORSConfiguration",
+	"tagging":    "NoSuchTagSet",
+	"logging":    "",
+	"policy":     "NoSuchBucketPolicy",
+}
+
+var (
+	storageClassPattern = regexp.MustCompile(`<(?:DefaultStorageClass|StorageClass)>([^<]+)</`)
+	versioningPattern   = regexp.MustCompile(`<Status>([^<]+)</Status>`)
+)
+
+type bucket struct {
+	name          string
+	acl           string
+	storageClass  string
+	versioning    string
+	azRedundancy  string
+	fsInterface   string
+	created       time.Time
+	configuration map[string][]byte
+}
+
+func (s *Server) serveListBuckets(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	body := "<ListAllMyBucketsResult><Owner><ID>" + DomainID + "</ID></Owner><Buckets>"
+	for _, name := range names {
+		body += fmt.Sprintf("<Bucket><Name>%s</Name><CreationDate>%s</CreationDate><Location>%s</Location></Bucket>",
+			name, s.buckets[name].created.Format(time.RFC3339), Region)
+	}
+	body += "</Buckets></ListAllMyBucketsResult>"
+	writeXML(w, http.StatusOK, body)
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, exists := s.buckets[name]
+	if r.Method == http.MethodPut && r.URL.RawQuery == "" {
+		if exists {
+			writeObsError(w, http.StatusConflict, "BucketAlreadyOwnedByYou", name)
+			return
+		}
+		s.buckets[name] = &bucket{
+			name:          name,
+			acl:           obsHeader(r, "acl", "private"),
+			storageClass:  obsHeader(r, "storage-class", obsHeader(r, "default-storage-class", "STANDARD")),
+			azRedundancy:  obsHeader(r, "az-redundancy", ""),
+			fsInterface:   obsHeader(r, "fs-file-interface", ""),
+			created:       time.Now().UTC(),
+			configuration: make(map[string][]byte),
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !exists {
+		writeObsError(w, http.StatusNotFound, "NoSuchBucket", name)
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodHead:
+		w.Header().Set("x-obs-bucket-location", Region)
+		w.Header().Set("x-obs-storage-class", b.storageClass)
+		if b.azRedundancy != "" {
+			w.Header().Set("x-obs-az-redundancy", b.azRedundancy)
+		}
+		if b.fsInterface != "" {
+			w.Header().Set("x-obs-fs-file-interface", b.fsInterface)
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodDelete && r.URL.RawQuery == "":
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+
+	case query.Has("storagePolicy") || query.Has("storageClass"):
+		if r.Method == http.MethodPut {
+			if m := storageClassPattern.FindSubmatch(readAll(r)); m != nil {
+				b.storageClass = string(m[1])
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if query.Has("storageClass") {
+			writeXML(w, http.StatusOK, fmt.Sprintf("<StorageClass>%s</StorageClass>", b.storageClass))
+			return
+		}
+		writeXML(w, http.StatusOK,
+			fmt.Sprintf("<StoragePolicy><DefaultStorageClass>%s</DefaultStorageClass></StoragePolicy>", b.storageClass))
+
+	case query.Has("versioning"):
+		if r.Method == http.MethodPut {
+			if m := versioningPattern.FindSubmatch(readAll(r)); m != nil {
+				b.versioning = string(m[1])
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		body := "<VersioningConfiguration>"
+		if b.versioning != "" {
+			body += fmt.Sprintf("<Status>%s</Status>", b.versioning)
+		}
+		writeXML(w, http.StatusOK, body+"</VersioningConfiguration>")
+
+	case query.Has("acl"):
+		if r.Method == http.MethodPut {
+			b.acl = obsHeader(r, "acl", b.acl)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeXML(w, http.StatusOK, fmt.Sprintf(
+			"<AccessControlPolicy><Owner><ID>%s</ID></Owner><AccessControlList></AccessControlList></AccessControlPolicy>", DomainID))
+
+	default:
+		for sub, missing := range bucketSubResources {
+			if !query.Has(sub) {
+				continue
+			}
+
+			switch r.Method {
+			case http.MethodPut:
+				b.configuration[sub] = readAll(r)
+				w.WriteHeader(http.StatusOK)
+			case http.MethodDelete:
+				delete(b.configuration, sub)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				if raw, ok := b.configuration[sub]; ok {
+					writeXML(w, http.StatusOK, string(raw))
+				} else if missing == "" {
+					writeXML(w, http.StatusOK, "<BucketLoggingStatus></BucketLoggingStatus>")
+				} else {
+					writeObsError(w, http.StatusNotFound, missing, name)
+				}
+			}
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			writeXML(w, http.StatusOK, fmt.Sprintf(
+				"<ListBucketResult><Name>%s</Name><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated></ListBucketResult>", name))
+			return
+		}
+		writeObsError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", name)
+	}
+}
+
+// obsHeader returns the value of an OBS request header, which is sent with either
+// the "x-obs-" or the "x-amz-" prefix depending on the signature of the client.
+func obsHeader(r *http.Request, name, defaultValue string) string {
+	for _, prefix := range []string{"x-obs-", "x-amz-"} {
+		if v := r.Header.Get(prefix + name); v != "" {
+			return v
+		}
+	}
+	return defaultValue
+}
+
+func readAll(r *http.Request) []byte {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+func writeXML(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header+body)
+}
+
+func writeObsError(w http.ResponseWriter, status int, code, bucketName string) {
+	w.Header().Set("x-obs-error-code", code)
+	writeXML(w, status, fmt.Sprintf("<Error><Code>%s</Code><Message>%s</Message><BucketName>%s</BucketName></Error>",
+		code, code, bucketName))
+}
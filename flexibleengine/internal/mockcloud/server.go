@@ -0,0 +1,331 @@
+/*
+Package mockcloud provides an in-process fake of the FlexibleEngine IAM, VPC, ECS,
+IMS, EVS and OBS APIs. The provider can be pointed at it through the `auth_url`
+and `endpoints` arguments, which allows resource.UnitTest to run complete
+create/read/update/delete/import cycles without credentials or network access.
+*/
+package mockcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	// Region is the only region served by the mock cloud.
+	Region = "eu-west-0"
+	// AvailabilityZone is the availability zone reported for servers and volumes.
+	AvailabilityZone = "eu-west-0a"
+	// AccessKey and SecretKey are the credentials written into ProviderConfig.
+	AccessKey = "MOCKACCESSKEY"
+	SecretKey = "MOCKSECRETKEY"
+
+	// ProjectID and DomainID are returned by the fake IAM service.
+	ProjectID  = "0c7d8fd3d6e04ae5a4c2b01fa31d5e41"
+	DomainID   = "a1f2e3d4c5b6a7980a1b2c3d4e5f6a7b"
+	DomainName = "mock-domain"
+
+	// NetworkID, ImageID and FlavorID are pre-seeded fixtures that can be
+	// referenced by the configurations of compute resources.
+	NetworkID   = "8f4e6a2c-3b1d-4c5e-9f7a-1b2c3d4e5f60"
+	NetworkName = "mock-network"
+	ImageID     = "2b1f7e0c-8a3d-4b6e-9c5f-0d1e2f3a4b5c"
+	ImageName   = "OBS Ubuntu 20.04"
+	FlavorID    = "s3.large.2"
+
+	timeFormat = "2006-01-02T15:04:05.000000"
+)
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params []string)
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handler handlerFunc
+}
+
+// Server is an in-memory fake of the FlexibleEngine APIs.
+type Server struct {
+	*httptest.Server
+
+	// Endpoint is the base URL of the server, using a host name instead of
+	// an IP address so that OBS virtual-hosted-style requests can be routed.
+	Endpoint string
+
+	mu        sync.Mutex
+	routes    []route
+	resources map[string]map[string]map[string]interface{}
+	tags      map[string]map[string]string
+	buckets   map[string]*bucket
+	ipCounter int
+
+	lastHeader http.Header
+}
+
+// NewServer starts a mock cloud which is closed when the test finishes.
+func NewServer(t *testing.T) *Server {
+	s := &Server{
+		resources: make(map[string]map[string]map[string]interface{}),
+		tags:      make(map[string]map[string]string),
+		buckets:   make(map[string]*bucket),
+	}
+
+	s.registerIAM()
+	s.registerVPC()
+	s.registerECS()
+	s.registerEVS()
+
+	s.Server = httptest.NewServer(s)
+	_, port, _ := net.SplitHostPort(s.Listener.Addr().String())
+	s.Endpoint = fmt.Sprintf("http://localhost:%s", port)
+
+	s.seed()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// PreCheck skips the test when there is no Terraform CLI to run resource.UnitTest with.
+// The test fails instead in CI, where the CLI is always provisioned.
+func PreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		msg := "Terraform CLI must be installed or TF_ACC_TERRAFORM_PATH must be set for mock cloud unit tests"
+		if os.Getenv("CI") != "" {
+			t.Fatal(msg)
+		}
+		t.Skip(msg)
+	}
+}
+
+// ProviderConfig returns a provider block which points the provider at the mock cloud.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "flexibleengine" {
+  region      = "%[1]s"
+  auth_url    = "%[2]s/iam/v3"
+  access_key  = "%[3]s"
+  secret_key  = "%[4]s"
+  max_retries = 1
+
+  endpoints = {
+    iam = "%[2]s/iam/"
+    vpc = "%[2]s/vpc/"
+    ecs = "%[2]s/ecs/"
+    ims = "%[2]s/ims/"
+    evs = "%[2]s/evs/"
+    obs = "%[2]s/"
+  }
+}
+`, Region, s.Endpoint, AccessKey, SecretKey)
+}
+
+// ProviderFactories returns the provider factories used by resource.UnitTest.
+// The returned provider resolves the OBS virtual-hosted-style bucket domains,
+// such as "bucket.localhost", to the mock cloud.
+func (s *Server) ProviderFactories(newProvider func() *schema.Provider) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"flexibleengine": func() (*schema.Provider, error) {
+			p := newProvider()
+			configure := p.ConfigureContextFunc
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				meta, diags := configure(ctx, d)
+				if diags.HasError() {
+					return meta, diags
+				}
+				if conf, ok := meta.(*config.Config); ok {
+					s.wrapTransport(conf)
+				}
+				return meta, diags
+			}
+			return p, nil
+		},
+	}
+}
+
+func (s *Server) wrapTransport(conf *config.Config) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	dial := func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, s.Listener.Addr().String())
+	}
+
+	for _, client := range []*http.Client{&conf.HwClient.HTTPClient, &conf.DomainClient.HTTPClient} {
+		rt := client.Transport
+		for {
+			if lrt, ok := rt.(*config.LogRoundTripper); ok {
+				rt = lrt.Rt
+			} else if wrapper, ok := rt.(interface{ Unwrap() http.RoundTripper }); ok {
+				rt = wrapper.Unwrap()
+			} else {
+				break
+			}
+		}
+		if transport, ok := rt.(*http.Transport); ok {
+			transport.DialContext = dial
+		}
+	}
+}
+
+// Exists reports whether a resource of the kind, e.g. "vpcs", "servers",
+// "volumes" or "buckets", still exists in the mock cloud.
+func (s *Server) Exists(kind, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if kind == "buckets" {
+		_, ok := s.buckets[id]
+		return ok
+	}
+	_, ok := s.resources[kind][id]
+	return ok
+}
+
+// LastRequestHeader returns the headers of the last request served by the registered routes.
+func (s *Server) LastRequestHeader() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastHeader
+}
+
+// CheckDestroy returns a resource.TestCheckFunc which verifies that all resources
+// of the resource type in the state have been removed from the mock cloud.
+func (s *Server) CheckDestroy(resourceType, kind string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if s.Exists(kind, rs.Primary.ID) {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// ServeHTTP dispatches the request to the OBS handler or to the registered routes.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Printf("[DEBUG] mockcloud request: %s %s%s", r.Method, r.Host, r.URL.RequestURI())
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if strings.HasSuffix(host, ".localhost") {
+		s.serveBucket(w, r, strings.TrimSuffix(host, ".localhost"))
+		return
+	}
+	if r.URL.Path == "/" {
+		s.serveListBuckets(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastHeader = r.Header.Clone()
+	for _, rt := range s.routes {
+		if rt.method != r.Method {
+			continue
+		}
+		if m := rt.pattern.FindStringSubmatch(r.URL.Path); m != nil {
+			rt.handler(w, r, m[1:])
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("mockcloud: no handler for %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) handle(method, pattern string, h handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: regexp.MustCompile("^" + pattern + "$"),
+		handler: h,
+	})
+}
+
+func (s *Server) collection(kind string) map[string]map[string]interface{} {
+	if _, ok := s.resources[kind]; !ok {
+		s.resources[kind] = make(map[string]map[string]interface{})
+	}
+	return s.resources[kind]
+}
+
+func (s *Server) nextIP() string {
+	s.ipCounter++
+	return fmt.Sprintf("192.168.0.%d", 10+s.ipCounter)
+}
+
+func newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func now() string {
+	return time.Now().UTC().Format(timeFormat)
+}
+
+func readBody(r *http.Request, key string) map[string]interface{} {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return map[string]interface{}{}
+	}
+	if key == "" {
+		return body
+	}
+	if v, ok := body[key].(map[string]interface{}); ok {
+		return v
+	}
+	return map[string]interface{}{}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error_code": fmt.Sprintf("MOCK.%d", status),
+		"error_msg":  message,
+	})
+}
+
+func notFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s could not be found", kind, id))
+}
+
+func stringValue(m map[string]interface{}, key, defaultValue string) string {
+	if v, ok := m[key].(string); ok && v != "" {
+		return v
+	}
+	return defaultValue
+}
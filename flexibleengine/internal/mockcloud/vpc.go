@@ -0,0 +1,269 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) registerVPC() {
+	s.handle(http.MethodPost, `/vpc/v1/[^/]+/vpcs`, s.createVpc)
+	s.handle(http.MethodGet, `/vpc/v1/[^/]+/vpcs`, s.listVpcs)
+	s.handle(http.MethodGet, `/vpc/v1/[^/]+/vpcs/([^/]+)`, s.getVpc)
+	s.handle(http.MethodPut, `/vpc/v1/[^/]+/vpcs/([^/]+)`, s.updateVpc)
+	s.handle(http.MethodDelete, `/vpc/v1/[^/]+/vpcs/([^/]+)`, s.deleteVpc)
+
+	s.handle(http.MethodGet, `/vpc/v2\.0/networks`, s.listNetworks)
+	s.handle(http.MethodGet, `/vpc/v2\.0/networks/([^/]+)`, s.getNetwork)
+	s.handle(http.MethodGet, `/vpc/v2\.0/ports`, s.listPorts)
+	s.handle(http.MethodGet, `/vpc/v2\.0/ports/([^/]+)`, s.getPort)
+
+	// the security group rules are shared by the v2 and v3 APIs
+	s.handle(http.MethodGet, `/vpc/v2\.0/security-group-rules/([^/]+)`, s.getSecGroupRuleV2)
+	s.handle(http.MethodDelete, `/vpc/v2\.0/security-group-rules/([^/]+)`, s.deleteSecGroupRule)
+	s.handle(http.MethodPost, `/vpc/v3/[^/]+/vpc/security-group-rules`, s.createSecGroupRule)
+	s.handle(http.MethodGet, `/vpc/v3/[^/]+/vpc/security-group-rules/([^/]+)`, s.getSecGroupRule)
+	s.handle(http.MethodPut, `/vpc/v3/[^/]+/vpc/security-group-rules/([^/]+)`, s.updateSecGroupRule)
+	s.handle(http.MethodDelete, `/vpc/v3/[^/]+/vpc/security-group-rules/([^/]+)`, s.deleteSecGroupRule)
+
+	// the tag APIs of VPC, ECS and EVS share the same layout:
+	// /{service}/{version}/{project_id}/{resource_type}/{resource_id}/tags
+	s.handle(http.MethodGet, `/[a-z]+/v[0-9.]+/[^/]+/[^/]+/([^/]+)/tags`, s.getTags)
+	s.handle(http.MethodPost, `/[a-z]+/v[0-9.]+/[^/]+/[^/]+/([^/]+)/tags/action`, s.batchTags)
+	s.handle(http.MethodDelete, `/[a-z]+/v[0-9.]+/[^/]+/[^/]+/([^/]+)/tags/([^/]+)`, s.deleteTag)
+}
+
+func (s *Server) createVpc(w http.ResponseWriter, r *http.Request, _ []string) {
+	opts := readBody(r, "vpc")
+	vpc := map[string]interface{}{
+		"id":                    newID(),
+		"name":                  stringValue(opts, "name", ""),
+		"cidr":                  stringValue(opts, "cidr", "192.168.0.0/16"),
+		"description":           stringValue(opts, "description", ""),
+		"enterprise_project_id": stringValue(opts, "enterprise_project_id", "0"),
+		"status":                "OK",
+		"routes":                []interface{}{},
+	}
+	s.collection("vpcs")[vpc["id"].(string)] = vpc
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+}
+
+func (s *Server) listVpcs(w http.ResponseWriter, _ *http.Request, _ []string) {
+	vpcs := []interface{}{}
+	for _, vpc := range s.collection("vpcs") {
+		vpcs = append(vpcs, vpc)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpcs": vpcs})
+}
+
+func (s *Server) getVpc(w http.ResponseWriter, _ *http.Request, params []string) {
+	vpc, ok := s.collection("vpcs")[params[0]]
+	if !ok {
+		notFound(w, "VPC", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+}
+
+func (s *Server) updateVpc(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc, ok := s.collection("vpcs")[params[0]]
+	if !ok {
+		notFound(w, "VPC", params[0])
+		return
+	}
+
+	opts := readBody(r, "vpc")
+	for _, key := range []string{"name", "cidr", "description"} {
+		if v, ok := opts[key]; ok {
+			vpc[key] = v
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+}
+
+func (s *Server) deleteVpc(w http.ResponseWriter, _ *http.Request, params []string) {
+	if _, ok := s.collection("vpcs")[params[0]]; !ok {
+		notFound(w, "VPC", params[0])
+		return
+	}
+
+	delete(s.collection("vpcs"), params[0])
+	delete(s.tags, params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request, _ []string) {
+	query := r.URL.Query()
+	networks := []interface{}{}
+	for _, network := range s.collection("networks") {
+		if id := query.Get("id"); id != "" && id != network["id"] {
+			continue
+		}
+		if name := query.Get("name"); name != "" && name != network["name"] {
+			continue
+		}
+		networks = append(networks, network)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"networks": networks})
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, _ *http.Request, params []string) {
+	network, ok := s.collection("networks")[params[0]]
+	if !ok {
+		notFound(w, "Network", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"network": network})
+}
+
+func (s *Server) listPorts(w http.ResponseWriter, r *http.Request, _ []string) {
+	query := r.URL.Query()
+	ports := []interface{}{}
+	for _, port := range s.collection("ports") {
+		if deviceID := query.Get("device_id"); deviceID != "" && deviceID != port["device_id"] {
+			continue
+		}
+		if networkID := query.Get("network_id"); networkID != "" && networkID != port["network_id"] {
+			continue
+		}
+		ports = append(ports, port)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"ports": ports})
+}
+
+func (s *Server) getPort(w http.ResponseWriter, _ *http.Request, params []string) {
+	port, ok := s.collection("ports")[params[0]]
+	if !ok {
+		notFound(w, "Port", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"port": port})
+}
+
+func (s *Server) createSecGroupRule(w http.ResponseWriter, r *http.Request, _ []string) {
+	opts := readBody(r, "security_group_rule")
+	rule := map[string]interface{}{
+		"id":                      newID(),
+		"security_group_id":       stringValue(opts, "security_group_id", ""),
+		"direction":               stringValue(opts, "direction", "ingress"),
+		"ethertype":               stringValue(opts, "ethertype", "IPv4"),
+		"protocol":                stringValue(opts, "protocol", ""),
+		"multiport":               stringValue(opts, "multiport", ""),
+		"remote_ip_prefix":        stringValue(opts, "remote_ip_prefix", ""),
+		"remote_group_id":         stringValue(opts, "remote_group_id", ""),
+		"remote_address_group_id": stringValue(opts, "remote_address_group_id", ""),
+		"action":                  stringValue(opts, "action", "allow"),
+		"priority":                1,
+		"description":             stringValue(opts, "description", ""),
+	}
+	if priority, ok := opts["priority"]; ok {
+		rule["priority"] = priority
+	}
+	s.collection("security_group_rules")[rule["id"].(string)] = rule
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"security_group_rule": rule})
+}
+
+func (s *Server) getSecGroupRule(w http.ResponseWriter, _ *http.Request, params []string) {
+	rule, ok := s.collection("security_group_rules")[params[0]]
+	if !ok {
+		notFound(w, "Security group rule", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group_rule": rule})
+}
+
+// getSecGroupRuleV2 returns the rule in the layout of the v2 API, which has no v3 parameters
+func (s *Server) getSecGroupRuleV2(w http.ResponseWriter, _ *http.Request, params []string) {
+	rule, ok := s.collection("security_group_rules")[params[0]]
+	if !ok {
+		notFound(w, "Security group rule", params[0])
+		return
+	}
+
+	ruleV2 := make(map[string]interface{})
+	for _, key := range []string{"id", "security_group_id", "direction", "ethertype", "protocol",
+		"remote_ip_prefix", "remote_group_id", "description"} {
+		ruleV2[key] = rule[key]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group_rule": ruleV2})
+}
+
+func (s *Server) updateSecGroupRule(w http.ResponseWriter, r *http.Request, params []string) {
+	rule, ok := s.collection("security_group_rules")[params[0]]
+	if !ok {
+		notFound(w, "Security group rule", params[0])
+		return
+	}
+
+	opts := readBody(r, "security_group_rule")
+	for _, key := range []string{"description", "action", "priority"} {
+		if v, ok := opts[key]; ok {
+			rule[key] = v
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group_rule": rule})
+}
+
+func (s *Server) deleteSecGroupRule(w http.ResponseWriter, _ *http.Request, params []string) {
+	if _, ok := s.collection("security_group_rules")[params[0]]; !ok {
+		notFound(w, "Security group rule", params[0])
+		return
+	}
+
+	delete(s.collection("security_group_rules"), params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createPort allocates a port with a fixed IP address in the network for a server.
+func (s *Server) createPort(networkID, fixedIP, deviceID string) map[string]interface{} {
+	if fixedIP == "" {
+		fixedIP = s.nextIP()
+	}
+
+	port := map[string]interface{}{
+		"id":           newID(),
+		"network_id":   networkID,
+		"device_id":    deviceID,
+		"device_owner": "compute:" + AvailabilityZone,
+		"mac_address":  fmt.Sprintf("fa:16:3e:00:00:%02x", s.ipCounter),
+		"status":       "ACTIVE",
+		"fixed_ips": []interface{}{
+			map[string]interface{}{"ip_address": fixedIP},
+		},
+	}
+	s.collection("ports")[port["id"].(string)] = port
+	return port
+}
+
+func (s *Server) getTags(w http.ResponseWriter, _ *http.Request, params []string) {
+	tags := []interface{}{}
+	for k, v := range s.tags[params[0]] {
+		tags = append(tags, map[string]interface{}{"key": k, "value": v})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tags": tags})
+}
+
+func (s *Server) batchTags(w http.ResponseWriter, r *http.Request, params []string) {
+	body := readBody(r, "")
+	if _, ok := s.tags[params[0]]; !ok {
+		s.tags[params[0]] = make(map[string]string)
+	}
+
+	tags, _ := body["tags"].([]interface{})
+	for _, raw := range tags {
+		tag, _ := raw.(map[string]interface{})
+		key := stringValue(tag, "key", "")
+		if body["action"] == "delete" {
+			delete(s.tags[params[0]], key)
+		} else {
+			s.tags[params[0]][key] = stringValue(tag, "value", "")
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteTag(w http.ResponseWriter, _ *http.Request, params []string) {
+	delete(s.tags[params[0]], params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
@@ -19,6 +20,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/as"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cbr"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cce"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ces"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cse"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/css"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dcs"
@@ -39,6 +41,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/iam"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ims"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/lb"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/lts"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/modelarts"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/nat"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/obs"
@@ -73,7 +76,7 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Description: descriptions["region"],
 				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", nil),
 			},
@@ -175,6 +178,50 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("OS_SECURITY_TOKEN", nil),
 			},
 
+			"shared_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["shared_config_file"],
+				DefaultFunc: schema.EnvDefaultFunc("OS_SHARED_CONFIG_FILE", nil),
+			},
+
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["profile"],
+				DefaultFunc: schema.EnvDefaultFunc("OS_PROFILE", nil),
+			},
+
+			"assume_role": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["assume_role"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"agency_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["assume_role_agency_name"],
+							DefaultFunc: schema.EnvDefaultFunc("OS_ASSUME_ROLE_AGENCY_NAME", nil),
+						},
+						"domain_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["assume_role_domain_name"],
+							DefaultFunc: schema.EnvDefaultFunc("OS_ASSUME_ROLE_DOMAIN_NAME", nil),
+						},
+						"duration": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  descriptions["assume_role_duration"],
+							DefaultFunc:  schema.EnvDefaultFunc("OS_ASSUME_ROLE_DURATION", defaultAssumeRoleDuration),
+							ValidateFunc: validation.IntBetween(900, 86400),
+						},
+					},
+				},
+			},
+
 			"auth_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -230,12 +277,53 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("OS_KEY", ""),
 				Description: descriptions["key"],
 			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["default_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "The tags applied to all resources which support tags.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["ignore_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The tag keys ignored by all resources.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The tag key prefixes ignored by all resources.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"flexibleengine_availability_zones": dataSourceAvailabilityZones(),
 
 			"flexibleengine_blockstorage_volume_v2": dataSourceBlockStorageVolumeV2(),
+			"flexibleengine_evs_snapshots":          dataSourceEvsSnapshots(),
 
 			"flexibleengine_compute_instance_v2": dataSourceComputeInstance(),
 			"flexibleengine_compute_instances":   dataSourceComputeInstances(),
@@ -408,6 +496,8 @@ func Provider() *schema.Provider {
 
 		ResourcesMap: map[string]*schema.Resource{
 			"flexibleengine_blockstorage_volume_v2": resourceBlockStorageVolumeV2(),
+			"flexibleengine_evs_snapshot":           resourceEvsSnapshot(),
+			"flexibleengine_evs_snapshot_rollback":  resourceEvsSnapshotRollback(),
 
 			"flexibleengine_compute_instance_v2":             resourceComputeInstanceV2(),
 			"flexibleengine_compute_interface_attach_v2":     resourceComputeInterfaceAttachV2(),
@@ -449,6 +539,7 @@ func Provider() *schema.Provider {
 			"flexibleengine_network_acl":                 resourceNetworkACL(),
 			"flexibleengine_network_acl_rule":            resourceNetworkACLRule(),
 			"flexibleengine_networking_port_v2":          resourceNetworkingPortV2(),
+			"flexibleengine_networking_secgroup":         resourceNetworkingSecGroup(),
 			"flexibleengine_networking_secgroup_v2":      resourceNetworkingSecGroupV2(),
 			"flexibleengine_networking_secgroup_rule_v2": resourceNetworkingSecGroupRuleV2(),
 
@@ -461,8 +552,9 @@ func Provider() *schema.Provider {
 			"flexibleengine_identity_provider":            resourceIdentityProvider(),
 			"flexibleengine_identity_provider_conversion": resourceIAMProviderConversion(),
 
-			"flexibleengine_lts_group": resourceLTSGroupV2(),
-			"flexibleengine_lts_topic": resourceLTSTopicV2(),
+			"flexibleengine_lts_group":               resourceLTSGroupV2(),
+			"flexibleengine_lts_topic":               resourceLTSTopicV2(),
+			"flexibleengine_lts_index_configuration": resourceLTSIndexConfiguration(),
 
 			"flexibleengine_s3_bucket":                resourceS3Bucket(),
 			"flexibleengine_s3_bucket_policy":         resourceS3BucketPolicy(),
@@ -583,6 +675,8 @@ func Provider() *schema.Provider {
 			"flexibleengine_cbr_vault":                 cbr.ResourceVault(),
 			"flexibleengine_cce_namespace":             cce.ResourceCCENamespaceV1(),
 			"flexibleengine_cce_pvc":                   cce.ResourceCcePersistentVolumeClaimsV1(),
+			"flexibleengine_ces_alarm_template":        ces.ResourceCesAlarmTemplate(),
+			"flexibleengine_ces_resource_group":        ces.ResourceResourceGroup(),
 			"flexibleengine_cse_microservice":          cse.ResourceMicroservice(),
 			"flexibleengine_cse_microservice_engine":   cse.ResourceMicroserviceEngine(),
 			"flexibleengine_cse_microservice_instance": cse.ResourceMicroserviceInstance(),
@@ -659,6 +753,7 @@ func Provider() *schema.Provider {
 
 			"flexibleengine_tms_tags": tms.ResourceTmsTag(),
 
+			"flexibleengine_vpc_address_group": vpc.ResourceVpcAddressGroup(),
 			"flexibleengine_vpc_eip_associate": eip.ResourceEIPAssociate(),
 			"flexibleengine_vpc_route_table":   vpc.ResourceVPCRouteTable(),
 			"flexibleengine_vpc_route":         vpc.ResourceVPCRouteTableRoute(),
@@ -675,6 +770,9 @@ func Provider() *schema.Provider {
 			"flexibleengine_elb_security_policy": elb.ResourceSecurityPolicy(),
 			"flexibleengine_elb_logtank":         elb.ResourceLogTank(),
 
+			"flexibleengine_lts_structuring_configuration": lts.ResourceStructConfig(),
+			"flexibleengine_lts_transfer":                  lts.ResourceLtsTransfer(),
+
 			"flexibleengine_modelarts_dataset":         modelarts.ResourceDataset(),
 			"flexibleengine_modelarts_dataset_version": modelarts.ResourceDatasetVersion(),
 			"flexibleengine_smn_message_template":      smn.ResourceSmnMessageTemplate(),
@@ -725,6 +823,18 @@ func Provider() *schema.Provider {
 		ConfigureContextFunc: configureProvider,
 	}
 
+	// the provider default_tags take effect on the resources which manage the tags by themselves
+	for _, r := range provider.ResourcesMap {
+		resourceWithProviderTags(r)
+	}
+	// the provider ignore_tags take effect on all resources and data sources, including the imported ones
+	for _, r := range provider.ResourcesMap {
+		resourceWithIgnoredTags(r)
+	}
+	for _, r := range provider.DataSourcesMap {
+		resourceWithIgnoredTags(r)
+	}
+
 	return provider
 }
 
@@ -756,6 +866,18 @@ func init() {
 
 		"security_token": "The security token to authenticate with a temporary security credential.",
 
+		"shared_config_file": "The path to the shared config file, either a clouds.yaml or an INI file.",
+
+		"profile": "The profile name in the shared config file, defaults to `default`.",
+
+		"assume_role": "The agency to assume with the credentials configured in the provider.",
+
+		"assume_role_agency_name": "The name of the agency to assume.",
+
+		"assume_role_domain_name": "The name of the domain which created the agency.",
+
+		"assume_role_duration": "The duration in seconds of the temporary credentials of the agency.",
+
 		"domain_id": "The ID of the Domain to scope to (Identity v3).",
 
 		"domain_name": "The name of the Domain to scope to (Identity v3).",
@@ -771,29 +893,21 @@ func init() {
 		"key": "A client private key to authenticate with.",
 
 		"cloud": "The endpoint of cloud provider, defaults to prod-cloud-ocb.orange-business.com",
+
+		"default_tags": "The default tags merged into the tags of all resources which support tags.",
+
+		"ignore_tags": "The tags which are managed outside of Terraform and ignored by all resources.",
 	}
 }
 
 func configureProvider(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{}
 
-	region := d.Get("region").(string)
 	cloud := d.Get("cloud").(string)
-	config.Region = region
-
+	config.Region = d.Get("region").(string)
 	config.TenantID = d.Get("tenant_id").(string)
 	config.TenantName = d.Get("tenant_name").(string)
-	// set tenant_name to region when neither `tenant_name` nor `tenant_id` was specified
-	if config.TenantID == "" && config.TenantName == "" {
-		config.TenantName = region
-	}
-
-	if v, ok := d.GetOk("auth_url"); ok {
-		config.IdentityEndpoint = v.(string)
-	} else {
-		config.IdentityEndpoint = fmt.Sprintf("https://iam.%s.%s/v3", mainRegion, cloud)
-	}
-
+	config.IdentityEndpoint = d.Get("auth_url").(string)
 	config.DomainID = d.Get("domain_id").(string)
 	config.DomainName = d.Get("domain_name").(string)
 	config.UserID = d.Get("user_id").(string)
@@ -802,8 +916,31 @@ func configureProvider(_ context.Context, d *schema.ResourceData) (interface{},
 	config.AccessKey = d.Get("access_key").(string)
 	config.SecretKey = d.Get("secret_key").(string)
 	config.SecurityToken = d.Get("security_token").(string)
+	config.AssumeRoleAgency = d.Get("assume_role.0.agency_name").(string)
+	config.AssumeRoleDomain = d.Get("assume_role.0.domain_name").(string)
 	config.Token = d.Get("token").(string)
 
+	// fill the settings which are not specified with the profile in the shared config file
+	config.SharedConfigFile = d.Get("shared_config_file").(string)
+	config.Profile = d.Get("profile").(string)
+	if config.SharedConfigFile != "" {
+		if err := loadSharedConfig(&config); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	region := config.Region
+	if region == "" {
+		return nil, diag.Errorf("region must be specified either in the provider or in the profile of shared config file")
+	}
+	// set tenant_name to region when neither `tenant_name` nor `tenant_id` was specified
+	if config.TenantID == "" && config.TenantName == "" {
+		config.TenantName = region
+	}
+	if config.IdentityEndpoint == "" {
+		config.IdentityEndpoint = fmt.Sprintf("https://iam.%s.%s/v3", mainRegion, cloud)
+	}
+
 	config.MaxRetries = d.Get("max_retries").(int)
 	config.Insecure = d.Get("insecure").(bool)
 	config.CACertFile = d.Get("cacert_file").(string)
@@ -845,12 +982,27 @@ func configureProvider(_ context.Context, d *schema.ResourceData) (interface{},
 	}
 
 	config.Endpoints = endpoints
+	config.Metadata = &providerMetadata{
+		DefaultTags:          flattenProviderDefaultTags(d),
+		IgnoreTagKeys:        utils.ExpandToStringListBySet(d.Get("ignore_tags.0.keys").(*schema.Set)),
+		IgnoreTagKeyPrefixes: utils.ExpandToStringListBySet(d.Get("ignore_tags.0.key_prefixes").(*schema.Set)),
+		AssumeRoleDuration:   d.Get("assume_role.0.duration").(int),
+	}
+
 	if err := LoadAndValidate(&config); err != nil {
 		return nil, diag.FromErr(err)
 	}
 	return &config, nil
 }
 
+func flattenProviderDefaultTags(d *schema.ResourceData) map[string]string {
+	tagmap := make(map[string]string)
+	for k, v := range d.Get("default_tags.0.tags").(map[string]interface{}) {
+		tagmap[k] = v.(string)
+	}
+	return tagmap
+}
+
 func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error) {
 	endpoints := d.Get("endpoints").(map[string]interface{})
 	epMap := make(map[string]string)
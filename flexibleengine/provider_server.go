@@ -0,0 +1,60 @@
+package flexibleengine
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type planWarningsKey struct{}
+
+// planWarnings collects the warnings raised by CustomizeDiff, which can only return an error.
+type planWarnings struct {
+	diags []*tfprotov5.Diagnostic
+}
+
+// addPlanWarning shows a warning in the plan of the resource, the warning is only logged
+// if the diff is not computed by the provider server (e.g. in unit tests).
+func addPlanWarning(ctx context.Context, summary, detail string) {
+	warnings, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		log.Printf("[WARN] %s: %s", summary, detail)
+		return
+	}
+
+	warnings.diags = append(warnings.diags, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// providerServer appends the warnings raised during the plan to the response. The SDK only returns
+// the diagnostics of the plan as errors, so the server is wrapped to show the warnings of CustomizeDiff,
+// e.g. the nodes to be replaced by a rolling update of CCE node pool.
+type providerServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *providerServer) PlanResourceChange(ctx context.Context,
+	req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	warnings := &planWarnings{}
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, warnings), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, warnings.diags...)
+	}
+	return resp, err
+}
+
+// ProviderServer returns the gRPC server of the provider.
+func ProviderServer() tfprotov5.ProviderServer {
+	return newProviderServer(Provider())
+}
+
+func newProviderServer(p *schema.Provider) tfprotov5.ProviderServer {
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(p),
+	}
+}
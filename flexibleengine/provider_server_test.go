@@ -0,0 +1,67 @@
+package flexibleengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderServer_planWarnings(t *testing.T) {
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"flexibleengine_test": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+				CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+					addPlanWarning(ctx, "test warning", "name is "+d.Get("name").(string))
+					return nil
+				},
+			},
+		},
+	}
+
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.String,
+		"name": tftypes.String,
+	}}
+	newValue := func(id interface{}) *tfprotov5.DynamicValue {
+		value, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"name": tftypes.NewValue(tftypes.String, "demo"),
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return &value
+	}
+	priorState, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := newProviderServer(p).PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "flexibleengine_test",
+		PriorState:       &priorState,
+		ProposedNewState: newValue(tftypes.UnknownValue),
+		Config:           newValue(nil),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %#v", len(resp.Diagnostics), resp.Diagnostics)
+	}
+	diag := resp.Diagnostics[0]
+	if diag.Severity != tfprotov5.DiagnosticSeverityWarning || diag.Summary != "test warning" ||
+		diag.Detail != "name is demo" {
+		t.Fatalf("unexpected diagnostic: %#v", diag)
+	}
+}
@@ -37,6 +37,8 @@ var (
 	OS_OBS_URN_SMN            = os.Getenv("OS_OBS_URN_SMN")
 	OS_WAF_ENABLE_FLAG        = os.Getenv("OS_WAF_ENABLE_FLAG")
 	OS_TENANT_NAME            = getTenantName()
+
+	OS_ENTERPRISE_PROJECT_ID_TEST = os.Getenv("OS_ENTERPRISE_PROJECT_ID_TEST")
 )
 
 // testAccProviders is a static map containing only the main provider instance.
@@ -126,6 +128,14 @@ func testAccPreCheckSdrs(t *testing.T) {
 	}
 }
 
+func testAccPreCheckEpsID(t *testing.T) {
+	testAccPreCheckRequiredEnvVars(t)
+
+	if OS_ENTERPRISE_PROJECT_ID_TEST == "" {
+		t.Skip("OS_ENTERPRISE_PROJECT_ID_TEST must be set for enterprise project tests")
+	}
+}
+
 func testAccPreCheckAdminOnly(t *testing.T) {
 	v := os.Getenv("OS_ADMIN")
 	if v != "admin" {
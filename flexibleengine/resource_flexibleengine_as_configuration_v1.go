@@ -2,6 +2,7 @@ package flexibleengine
 
 import (
 	"crypto/sha1"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"log"
@@ -19,6 +20,9 @@ func resourceASConfiguration() *schema.Resource {
 		Read:   resourceASConfigurationRead,
 		Update: nil,
 		Delete: resourceASConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -313,9 +317,90 @@ func resourceASConfigurationRead(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Retrieved ASConfiguration %q: %+v", d.Id(), asConfig)
 
+	// the instance_id is not returned when the configuration is created from an instance
+	if v, ok := d.GetOk("instance_config.0.instance_id"); ok && asConfig.InstanceConfig.InstanceID == "" {
+		asConfig.InstanceConfig.InstanceID = v.(string)
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("scaling_configuration_name", asConfig.Name)
+	if err := d.Set("instance_config", flattenASInstanceConfig(asConfig.InstanceConfig)); err != nil {
+		return fmt.Errorf("Error setting instance_config of AS configuration %q: %s", d.Id(), err)
+	}
+
 	return nil
 }
 
+func flattenASInstanceConfig(instanceConfig configurations.InstanceConfig) []map[string]interface{} {
+	disks := make([]map[string]interface{}, len(instanceConfig.Disk))
+	for i, disk := range instanceConfig.Disk {
+		disks[i] = map[string]interface{}{
+			"size":        disk.Size,
+			"volume_type": disk.VolumeType,
+			"disk_type":   disk.DiskType,
+		}
+	}
+
+	personalities := make([]map[string]interface{}, len(instanceConfig.Personality))
+	for i, personality := range instanceConfig.Personality {
+		personalities[i] = map[string]interface{}{
+			"path":    personality.Path,
+			"content": personality.Content,
+		}
+	}
+
+	publicIps := []map[string]interface{}{}
+	if eip := instanceConfig.PublicIp.Eip; eip.Type != "" {
+		bandWidth := map[string]interface{}{
+			"size":          eip.Bandwidth.Size,
+			"share_type":    eip.Bandwidth.ShareType,
+			"charging_mode": eip.Bandwidth.ChargingMode,
+		}
+		publicIps = append(publicIps, map[string]interface{}{
+			"eip": []map[string]interface{}{
+				{
+					"ip_type":   eip.Type,
+					"bandwidth": []map[string]interface{}{bandWidth},
+				},
+			},
+		})
+	}
+
+	metadata := make(map[string]interface{})
+	for key, val := range instanceConfig.Metadata {
+		if v, ok := val.(string); ok {
+			metadata[key] = v
+		}
+	}
+
+	return []map[string]interface{}{
+		{
+			"instance_id": instanceConfig.InstanceID,
+			"flavor":      instanceConfig.FlavorRef,
+			"image":       instanceConfig.ImageRef,
+			"key_name":    instanceConfig.SSHKey,
+			"user_data":   hashASUserData(instanceConfig.UserData),
+			"disk":        disks,
+			"personality": personalities,
+			"public_ip":   publicIps,
+			"metadata":    metadata,
+		},
+	}
+}
+
+// hashASUserData returns the same hash as the StateFunc of user_data, the API returns
+// the user data encoded with base64.
+func hashASUserData(userData string) string {
+	if userData == "" {
+		return ""
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(userData); err == nil {
+		userData = string(decoded)
+	}
+	hash := sha1.Sum([]byte(userData))
+	return hex.EncodeToString(hash[:])
+}
+
 func resourceASConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	asClient, err := config.AutoscalingV1Client(GetRegion(d, config))
@@ -12,6 +12,7 @@ import (
 
 func TestAccASV1Configuration_basic(t *testing.T) {
 	var asConfig configurations.Configuration
+	resourceName := "flexibleengine_as_configuration_v1.hth_as_config"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -21,9 +22,14 @@ func TestAccASV1Configuration_basic(t *testing.T) {
 			{
 				Config: testASV1Configuration_basic,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASV1ConfigurationExists("flexibleengine_as_configuration_v1.hth_as_config", &asConfig),
+					testAccCheckASV1ConfigurationExists(resourceName, &asConfig),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -21,12 +21,17 @@ func resourceASGroup() *schema.Resource {
 		Read:   resourceASGroupRead,
 		Update: resourceASGroupUpdate,
 		Delete: resourceASGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -199,7 +204,8 @@ func resourceASGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -493,7 +499,7 @@ func resourceASGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(asgId)
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		tagList := expandResourceTags(tagRaw)
 		err := tags.Create(asClient, "scaling_group_tag", asgId, tagList).ExtractErr()
@@ -554,6 +560,25 @@ func resourceASGroupRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("instance_terminate_policy", asg.InstanceTerminatePolicy)
 	d.Set("scaling_configuration_id", asg.ConfigurationID)
 	d.Set("delete_publicip", asg.DeletePublicip)
+	d.Set("vpc_id", asg.VpcID)
+	d.Set("available_zones", asg.AvailableZones)
+
+	networks := make([]map[string]interface{}, len(asg.Networks))
+	for i, network := range asg.Networks {
+		networks[i] = map[string]interface{}{
+			"id": network.ID,
+		}
+	}
+	d.Set("networks", networks)
+
+	secGroups := make([]map[string]interface{}, len(asg.SecurityGroups))
+	for i, group := range asg.SecurityGroups {
+		secGroups[i] = map[string]interface{}{
+			"id": group.ID,
+		}
+	}
+	d.Set("security_groups", secGroups)
+
 	if len(asg.Notifications) >= 1 {
 		d.Set("notifications", asg.Notifications)
 	}
@@ -578,8 +603,9 @@ func resourceASGroupRead(d *schema.ResourceData, meta interface{}) error {
 
 	resourceTags, err := tags.Get(asClient, "scaling_group_tag", d.Id()).Extract()
 	if err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] fetching AS group %s tags failed: %s", d.Id(), err)
 	}
@@ -637,8 +663,8 @@ func resourceASGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error updating ASGroup %q: %s", asgID, err)
 	}
 
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(asClient, d, "scaling_group_tag", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(asClient, d, config, "scaling_group_tag", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of AS group:%s, err:%s", d.Id(), tagErr)
 		}
@@ -35,6 +35,15 @@ func TestAccASV1Group_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.key", "value"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"delete_instances",
+					"force_delete",
+				},
+			},
 		},
 	})
 }
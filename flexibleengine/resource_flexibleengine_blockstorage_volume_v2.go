@@ -2,8 +2,10 @@ package flexibleengine
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
@@ -11,10 +13,24 @@ import (
 	"github.com/chnsz/golangsdk/openstack/blockstorage/v2/volumes"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/volumeattach"
+	"github.com/chnsz/golangsdk/openstack/evs/v1/jobs"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 )
 
+// the following fields can only be used with the native Openstack API to create a volume
+var volumeV2OpenstackOnlyFields = []string{"source_vol_id", "source_replica", "consistency_group_id"}
+
+// the system metadata set by the service or by the provider, they are hidden from the metadata attribute
+var volumeV2SystemMetadata = []string{
+	"billing", "orderID", "resourceType", "resourceSpecCode", "create_for_volume_id",
+	"__system__encrypted", "__system__cmkid", "hw:passthrough",
+}
+
 func resourceBlockStorageVolumeV2() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBlockStorageVolumeV2Create,
@@ -27,9 +43,12 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -61,7 +80,8 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"snapshot_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -78,11 +98,45 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 				ForceNew: true,
 			},
 			"volume_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"iops": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: volumeV2OpenstackOnlyFields,
+			},
+			"throughput": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: volumeV2OpenstackOnlyFields,
+			},
+			"kms_id": {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
 				Computed: true,
 			},
+			"device_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"VBD", "SCSI"}, false),
+			},
+			"enterprise_project_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: volumeV2OpenstackOnlyFields,
+			},
+			"charging_mode": common.SchemaChargingMode(volumeV2OpenstackOnlyFields),
+			"period_unit":   common.SchemaPeriodUnit(volumeV2OpenstackOnlyFields),
+			"period":        common.SchemaPeriod(volumeV2OpenstackOnlyFields),
+			"auto_renew":    common.SchemaAutoRenewUpdatable(volumeV2OpenstackOnlyFields),
 			"consistency_group_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -136,6 +190,31 @@ func resourceContainerMetadataV2(d *schema.ResourceData) map[string]string {
 	return m
 }
 
+// resourceVolumeV2SystemMetadata returns the system metadata of the volume to create
+func resourceVolumeV2SystemMetadata(d *schema.ResourceData) map[string]string {
+	m := make(map[string]string)
+	if v, ok := d.GetOk("kms_id"); ok {
+		m["__system__encrypted"] = "1"
+		m["__system__cmkid"] = v.(string)
+	}
+	if d.Get("device_type").(string) == "SCSI" {
+		m["hw:passthrough"] = "true"
+	}
+	return m
+}
+
+// useCloudVolumesAPI returns whether the volume must be created with the EVS v2.1 API:
+// the native Openstack API does not support the IOPS, throughput, enterprise project and charging mode.
+func useCloudVolumesAPI(d *schema.ResourceData, config *Config) bool {
+	for _, field := range volumeV2OpenstackOnlyFields {
+		if _, ok := d.GetOk(field); ok {
+			return false
+		}
+	}
+	return d.Get("iops").(int) > 0 || d.Get("throughput").(int) > 0 ||
+		d.Get("charging_mode").(string) == "prePaid" || config.GetEnterpriseProjectID(d) != ""
+}
+
 func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
@@ -143,37 +222,50 @@ func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
 	}
 
-	createOpts := &volumes.CreateOpts{
-		AvailabilityZone:   d.Get("availability_zone").(string),
-		ConsistencyGroupID: d.Get("consistency_group_id").(string),
-		Description:        d.Get("description").(string),
-		ImageID:            d.Get("image_id").(string),
-		Metadata:           resourceContainerMetadataV2(d),
-		Name:               d.Get("name").(string),
-		Size:               d.Get("size").(int),
-		SnapshotID:         d.Get("snapshot_id").(string),
-		SourceReplica:      d.Get("source_replica").(string),
-		SourceVolID:        d.Get("source_vol_id").(string),
-		VolumeType:         d.Get("volume_type").(string),
-		Multiattach:        d.Get("multiattach").(bool),
+	metadata := resourceContainerMetadataV2(d)
+	for k, v := range resourceVolumeV2SystemMetadata(d) {
+		metadata[k] = v
 	}
 
-	log.Printf("[DEBUG] Create Options: %#v", createOpts)
-	v, err := volumes.Create(blockStorageClient, createOpts).Extract()
-	if err != nil {
-		return fmt.Errorf("Error creating FlexibleEngine volume: %s", err)
+	if useCloudVolumesAPI(d, config) {
+		if err := resourceBlockStorageVolumeV2CreateByEVS(d, config, metadata); err != nil {
+			return err
+		}
+	} else {
+		createOpts := &volumes.CreateOpts{
+			AvailabilityZone:   d.Get("availability_zone").(string),
+			ConsistencyGroupID: d.Get("consistency_group_id").(string),
+			Description:        d.Get("description").(string),
+			ImageID:            d.Get("image_id").(string),
+			Metadata:           metadata,
+			Name:               d.Get("name").(string),
+			Size:               d.Get("size").(int),
+			SnapshotID:         d.Get("snapshot_id").(string),
+			SourceReplica:      d.Get("source_replica").(string),
+			SourceVolID:        d.Get("source_vol_id").(string),
+			VolumeType:         d.Get("volume_type").(string),
+			Multiattach:        d.Get("multiattach").(bool),
+		}
+
+		log.Printf("[DEBUG] Create Options: %#v", createOpts)
+		v, err := volumes.Create(blockStorageClient, createOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine volume: %s", err)
+		}
+		log.Printf("[INFO] Volume ID: %s", v.ID)
+		// Store the ID now
+		d.SetId(v.ID)
 	}
-	log.Printf("[INFO] Volume ID: %s", v.ID)
 
 	// Wait for the volume to become available.
 	log.Printf(
 		"[DEBUG] Waiting for volume (%s) to become available",
-		v.ID)
+		d.Id())
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"downloading", "creating"},
 		Target:     []string{"available"},
-		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, v.ID),
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
@@ -183,24 +275,138 @@ func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for volume (%s) to become ready: %s",
-			v.ID, err)
+			d.Id(), err)
 	}
 
-	// Store the ID now
-	d.SetId(v.ID)
-
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
-		if tagErr := tags.Create(blockStorageClient, "os-vendor-volumes", v.ID, taglist).ExtractErr(); tagErr != nil {
-			return fmt.Errorf("Error setting tags of volume %s: %s", v.ID, tagErr)
+		if tagErr := tags.Create(blockStorageClient, "os-vendor-volumes", d.Id(), taglist).ExtractErr(); tagErr != nil {
+			return fmt.Errorf("Error setting tags of volume %s: %s", d.Id(), tagErr)
 		}
 	}
 
 	return resourceBlockStorageVolumeV2Read(d, meta)
 }
 
+func resourceBlockStorageVolumeV2CreateByEVS(d *schema.ResourceData, config *Config, metadata map[string]string) error {
+	evsV21Client, err := config.BlockStorageV21Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage v2.1 client: %s", err)
+	}
+
+	createOpts := cloudvolumes.CreateOpts{
+		Volume: cloudvolumes.VolumeOpts{
+			AvailabilityZone:    d.Get("availability_zone").(string),
+			VolumeType:          d.Get("volume_type").(string),
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Size:                d.Get("size").(int),
+			SnapshotID:          d.Get("snapshot_id").(string),
+			ImageID:             d.Get("image_id").(string),
+			Multiattach:         d.Get("multiattach").(bool),
+			Metadata:            metadata,
+			IOPS:                d.Get("iops").(int),
+			Throughput:          d.Get("throughput").(int),
+			EnterpriseProjectID: config.GetEnterpriseProjectID(d),
+		},
+	}
+	if d.Get("charging_mode").(string) == "prePaid" {
+		if err := common.ValidatePrePaidChargeInfo(d); err != nil {
+			return err
+		}
+		createOpts.ChargeInfo = &cloudvolumes.BssParam{
+			ChargingMode: "prePaid",
+			PeriodType:   d.Get("period_unit").(string),
+			PeriodNum:    d.Get("period").(int),
+			IsAutoRenew:  d.Get("auto_renew").(string),
+			IsAutoPay:    "true",
+		}
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	job, err := cloudvolumes.Create(evsV21Client, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine volume: %s", err)
+	}
+	if len(job.VolumeIDs) < 1 {
+		// the volume ID of a prepaid volume is returned by the order
+		if job.OrderID == "" {
+			return fmt.Errorf("Error creating FlexibleEngine volume: the volume ID is not found in the response")
+		}
+		bssClient, err := config.BssV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine BSS v2 client: %s", err)
+		}
+		ctx := context.Background()
+		if err := common.WaitOrderComplete(ctx, bssClient, job.OrderID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+		volumeID, err := common.WaitOrderResourceComplete(ctx, bssClient, job.OrderID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+		d.SetId(volumeID)
+		return nil
+	}
+
+	log.Printf("[INFO] Volume ID: %s", job.VolumeIDs[0])
+	d.SetId(job.VolumeIDs[0])
+	return waitForVolumeV2Job(d, config, job, d.Timeout(schema.TimeoutCreate))
+}
+
+// waitForVolumeV2Job waits for the order or the job returned by the EVS v2.1 API to complete
+func waitForVolumeV2Job(d *schema.ResourceData, config *Config, job *cloudvolumes.JobResponse, timeout time.Duration) error {
+	region := GetRegion(d, config)
+	if job.OrderID != "" {
+		bssClient, err := config.BssV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine BSS v2 client: %s", err)
+		}
+		if err := common.WaitOrderComplete(context.Background(), bssClient, job.OrderID, timeout); err != nil {
+			return err
+		}
+	}
+
+	if job.JobID != "" {
+		evsV1Client, err := config.BlockStorageV1Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine block storage v1 client: %s", err)
+		}
+		stateConf := &resource.StateChangeConf{
+			Pending:      []string{"PENDING"},
+			Target:       []string{"SUCCESS"},
+			Refresh:      volumeV2JobRefreshFunc(evsV1Client, job.JobID),
+			Timeout:      timeout,
+			Delay:        5 * time.Second,
+			PollInterval: 10 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for the job (%s) of volume %s to succeed: %s", job.JobID, d.Id(), err)
+		}
+	}
+	return nil
+}
+
+func volumeV2JobRefreshFunc(client *golangsdk.ServiceClient, jobID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		job, err := jobs.GetJobDetails(client, jobID).ExtractJob()
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch job.Status {
+		case "SUCCESS":
+			return job, job.Status, nil
+		case "FAIL":
+			return job, job.Status, fmt.Errorf("the job failed: %s", job.FailReason)
+		default:
+			return job, "PENDING", nil
+		}
+	}
+}
+
 func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
@@ -222,16 +428,38 @@ func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{})
 	d.Set("snapshot_id", v.SnapshotID)
 	d.Set("source_vol_id", v.SourceVolID)
 	d.Set("volume_type", v.VolumeType)
-	//flexibleengine will add metadata 'billing=1' additionally, so remove the metadata 'billing' from response
+	// flexibleengine will add some system metadata additionally, such as 'billing=1', so remove them from response
+	// unless they are specified in the metadata argument
+	configured := d.Get("metadata").(map[string]interface{})
 	m := make(map[string]string)
 	for key, val := range v.Metadata {
-		if key == "billing" {
+		if _, ok := configured[key]; !ok && isStrContainsSliceElement(key, volumeV2SystemMetadata, false, true) {
 			continue
 		}
 		m[key] = val
 	}
 	d.Set("metadata", m)
 
+	// the IOPS, throughput, enterprise project and charging mode are only returned by the EVS API
+	cloudVolume, err := cloudvolumes.Get(blockStorageClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving FlexibleEngine volume %s: %s", d.Id(), err)
+	}
+	d.Set("iops", cloudVolume.IOPS.TotalVal)
+	d.Set("throughput", cloudVolume.Throughput.TotalVal)
+	d.Set("enterprise_project_id", cloudVolume.EnterpriseProjectID)
+	d.Set("kms_id", cloudVolume.Metadata.SystemCmkID)
+	if cloudVolume.Metadata.HwPassthrough == "true" {
+		d.Set("device_type", "SCSI")
+	} else {
+		d.Set("device_type", "VBD")
+	}
+	if cloudVolume.Metadata.OrderID != "" {
+		d.Set("charging_mode", "prePaid")
+	} else {
+		d.Set("charging_mode", "postPaid")
+	}
+
 	d.Set("region", GetRegion(d, config))
 
 	attachments := make([]map[string]interface{}, len(v.Attachments))
@@ -247,8 +475,9 @@ func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{})
 
 	// fetch tags
 	if resourceTags, err := tags.Get(blockStorageClient, "os-vendor-volumes", d.Id()).Extract(); err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] fetching tags of volume failed: %s", err)
 	}
@@ -279,40 +508,77 @@ func resourceBlockStorageVolumeV2Update(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("size") {
-		extendOpts := volumeactions.ExtendSizeOpts{
-			NewSize: d.Get("size").(int),
+	if d.HasChange("volume_type") {
+		if err := retypeBlockStorageVolumeV2(d, config, blockStorageClient); err != nil {
+			return err
+		}
+	} else if d.HasChanges("iops", "throughput") {
+		if err := updateBlockStorageVolumeV2QoS(d, config, blockStorageClient); err != nil {
+			return err
 		}
+	}
 
-		err = volumeactions.ExtendSize(blockStorageClient, d.Id(), extendOpts).ExtractErr()
-		if err != nil {
-			return fmt.Errorf("Error extending flexibleengine_blockstorage_volume_v2 %s size: %s", d.Id(), err)
+	if d.HasChange("size") {
+		if d.Get("charging_mode").(string) == "prePaid" {
+			evsV21Client, err := config.BlockStorageV21Client(GetRegion(d, config))
+			if err != nil {
+				return fmt.Errorf("Error creating FlexibleEngine block storage v2.1 client: %s", err)
+			}
+			extendOpts := cloudvolumes.ExtendOpts{
+				SizeOpts: cloudvolumes.ExtendSizeOpts{
+					NewSize: d.Get("size").(int),
+				},
+				ChargeInfo: &cloudvolumes.ExtendChargeOpts{
+					IsAutoPay: "true",
+				},
+			}
+			job, err := cloudvolumes.ExtendSize(evsV21Client, d.Id(), extendOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error extending flexibleengine_blockstorage_volume_v2 %s size: %s", d.Id(), err)
+			}
+			if err := waitForVolumeV2Job(d, config, job, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		} else {
+			extendOpts := volumeactions.ExtendSizeOpts{
+				NewSize: d.Get("size").(int),
+			}
+
+			err = volumeactions.ExtendSize(blockStorageClient, d.Id(), extendOpts).ExtractErr()
+			if err != nil {
+				return fmt.Errorf("Error extending flexibleengine_blockstorage_volume_v2 %s size: %s", d.Id(), err)
+			}
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"extending"},
-			Target:     []string{"available", "in-use"},
-			Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+		if err := waitForBlockStorageVolumeV2Update(d, blockStorageClient); err != nil {
+			return err
 		}
+	}
 
-		_, err := stateConf.WaitForState()
+	if d.HasChange("auto_renew") {
+		bssClient, err := config.BssV2Client(GetRegion(d, config))
 		if err != nil {
-			return fmt.Errorf(
-				"Error waiting for flexibleengine_blockstorage_volume_v2 %s to become ready: %s", d.Id(), err)
+			return fmt.Errorf("Error creating FlexibleEngine BSS v2 client: %s", err)
+		}
+		if err := common.UpdateAutoRenew(bssClient, d.Get("auto_renew").(string), d.Id()); err != nil {
+			return fmt.Errorf("Error updating the auto-renew of volume %s: %s", d.Id(), err)
 		}
 	}
 
 	// update tags
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(blockStorageClient, d, "os-vendor-volumes", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(blockStorageClient, d, config, "os-vendor-volumes", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of volume:%s, err:%s", d.Id(), tagErr)
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "disk", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceBlockStorageVolumeV2Read(d, meta)
 }
 
@@ -367,7 +633,11 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 	// in a "deleting" state from when the instance was terminated.
 	// If this is true, just move on. It'll eventually delete.
 	if v.Status != "deleting" {
-		if err := volumes.Delete(blockStorageClient, d.Id(), deleteOpts).ExtractErr(); err != nil {
+		if d.Get("charging_mode").(string) == "prePaid" {
+			if err := common.UnsubscribePrePaidResource(d, config, []string{d.Id()}); err != nil {
+				return fmt.Errorf("Error unsubscribing FlexibleEngine volume %s: %s", d.Id(), err)
+			}
+		} else if err := volumes.Delete(blockStorageClient, d.Id(), deleteOpts).ExtractErr(); err != nil {
 			return CheckDeleted(d, err, "volume")
 		}
 	}
@@ -395,6 +665,104 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+func waitForBlockStorageVolumeV2Update(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"extending", "retyping", "updating"},
+		Target:     []string{"available", "in-use"},
+		Refresh:    VolumeV2StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for flexibleengine_blockstorage_volume_v2 %s to become ready: %s", d.Id(), err)
+	}
+	return nil
+}
+
+type volumeV2RetypeOpts struct {
+	Retype     volumeV2RetypeSpec             `json:"os-retype"`
+	ChargeInfo *cloudvolumes.ExtendChargeOpts `json:"bssParam,omitempty"`
+}
+
+type volumeV2RetypeSpec struct {
+	NewType    string `json:"new_type"`
+	IOPS       int    `json:"iops,omitempty"`
+	Throughput int    `json:"throughput,omitempty"`
+}
+
+// retypeBlockStorageVolumeV2 changes the volume type, the IOPS and throughput are provisioned
+// together with the new type.
+func retypeBlockStorageVolumeV2(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient) error {
+	opts := volumeV2RetypeOpts{
+		Retype: volumeV2RetypeSpec{
+			NewType:    d.Get("volume_type").(string),
+			IOPS:       getConfiguredInt(d, "iops"),
+			Throughput: getConfiguredInt(d, "throughput"),
+		},
+	}
+	if d.Get("charging_mode").(string) == "prePaid" {
+		opts.ChargeInfo = &cloudvolumes.ExtendChargeOpts{
+			IsAutoPay: "true",
+		}
+	}
+
+	log.Printf("[DEBUG] Retype volume %s with options: %#v", d.Id(), opts)
+	var r golangsdk.Result
+	_, r.Err = client.Post(client.ServiceURL("volumes", d.Id(), "retype"), opts, &r.Body,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 202}})
+	job, err := cloudvolumes.JobResult{Result: r}.Extract()
+	if err != nil {
+		return fmt.Errorf("Error changing the type of flexibleengine_blockstorage_volume_v2 %s: %s", d.Id(), err)
+	}
+
+	if err := waitForVolumeV2Job(d, config, job, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+	return waitForBlockStorageVolumeV2Update(d, client)
+}
+
+type volumeV2QoSOpts struct {
+	QoS volumeV2QoSSpec `json:"qos_modify"`
+}
+
+type volumeV2QoSSpec struct {
+	IOPS       int `json:"iops"`
+	Throughput int `json:"throughput,omitempty"`
+}
+
+// updateBlockStorageVolumeV2QoS modifies the provisioned IOPS and throughput of a GPSSD2 or ESSD2 volume
+func updateBlockStorageVolumeV2QoS(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient) error {
+	opts := volumeV2QoSOpts{
+		QoS: volumeV2QoSSpec{
+			IOPS:       d.Get("iops").(int),
+			Throughput: d.Get("throughput").(int),
+		},
+	}
+
+	// the version of the QoS API is v5
+	v5Client := *client
+	v5Client.ResourceBase = strings.Replace(client.ResourceBaseURL(), "/v2/", "/v5/", 1)
+
+	log.Printf("[DEBUG] Modify the QoS of volume %s with options: %#v", d.Id(), opts)
+	var r golangsdk.Result
+	_, r.Err = v5Client.Put(v5Client.ServiceURL("cloudvolumes", d.Id(), "qos"), opts, &r.Body,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 202}})
+	job, err := cloudvolumes.JobResult{Result: r}.Extract()
+	if err != nil {
+		return fmt.Errorf("Error modifying the IOPS and throughput of flexibleengine_blockstorage_volume_v2 %s: %s",
+			d.Id(), err)
+	}
+
+	if err := waitForVolumeV2Job(d, config, job, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+	return waitForBlockStorageVolumeV2Update(d, client)
+}
+
 func resourceVolumeMetadataV2(d *schema.ResourceData) map[string]string {
 	m := make(map[string]string)
 	for key, val := range d.Get("metadata").(map[string]interface{}) {
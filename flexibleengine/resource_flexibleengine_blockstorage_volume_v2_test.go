@@ -1,6 +1,7 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -9,6 +10,8 @@ import (
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/blockstorage/v2/volumes"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 func TestAccBlockStorageV2Volume_basic(t *testing.T) {
@@ -54,6 +57,136 @@ func TestAccBlockStorageV2Volume_basic(t *testing.T) {
 	})
 }
 
+func TestUnitBlockStorageV2Volume_basic(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	resourceName := "flexibleengine_blockstorage_volume_v2.volume_1"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockcloud.PreCheck(t) },
+		ProviderFactories: srv.ProviderFactories(Provider),
+		CheckDestroy:      srv.CheckDestroy("flexibleengine_blockstorage_volume_v2", "volumes"),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccBlockStorageV2Volume_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "volume_1"),
+					resource.TestCheckResourceAttr(resourceName, "size", "10"),
+					resource.TestCheckResourceAttr(resourceName, "description", "first test volume"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccBlockStorageV2Volume_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "volume_1-updated"),
+					resource.TestCheckResourceAttr(resourceName, "size", "20"),
+					resource.TestCheckResourceAttr(resourceName, "description", "first test volume updated"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"cascade",
+				},
+			},
+		},
+	})
+}
+
+func TestBlockStorageV2VolumeDeviceTypeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "volume",
+		Attributes: map[string]string{
+			"id":                      "volume",
+			"name":                    "volume",
+			"size":                    "10",
+			"device_type":             "SCSI",
+			"metadata.%":              "1",
+			"metadata.hw:passthrough": "true",
+			"cascade":                 "false",
+			"availability_zone":       "eu-west-0a",
+		},
+	}
+
+	cases := []struct {
+		name        string
+		raw         map[string]interface{}
+		requiresNew bool
+	}{
+		{"SCSI volume without device_type", map[string]interface{}{}, false},
+		{"SCSI volume by metadata", map[string]interface{}{
+			"metadata": map[string]interface{}{"hw:passthrough": "true"},
+		}, false},
+		{"change to VBD", map[string]interface{}{"device_type": "VBD"}, true},
+	}
+
+	for _, tc := range cases {
+		tc.raw["name"] = "volume"
+		tc.raw["size"] = 10
+		tc.raw["availability_zone"] = "eu-west-0a"
+		diff, err := resourceBlockStorageVolumeV2().Diff(context.Background(), state,
+			terraform.NewResourceConfigRaw(tc.raw), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		if diff.RequiresNew() != tc.requiresNew {
+			t.Fatalf("%s: expected RequiresNew to be %t", tc.name, tc.requiresNew)
+		}
+	}
+}
+
+func TestAccBlockStorageV2Volume_provisioned(t *testing.T) {
+	var volume volumes.Volume
+	resourceName := "flexibleengine_blockstorage_volume_v2.volume_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV2VolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2Volume_provisioned(3000, 125),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists(resourceName, &volume),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "GPSSD2"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "3000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "125"),
+					resource.TestCheckResourceAttr(resourceName, "device_type", "SCSI"),
+					resource.TestCheckResourceAttr(resourceName, "charging_mode", "postPaid"),
+				),
+			},
+			{
+				Config: testAccBlockStorageV2Volume_provisioned(4000, 150),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists(resourceName, &volume),
+					resource.TestCheckResourceAttr(resourceName, "iops", "4000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "150"),
+				),
+			},
+			{
+				Config: testAccBlockStorageV2Volume_retype,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists(resourceName, &volume),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "SSD"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"cascade",
+				},
+			},
+		},
+	})
+}
+
 func TestAccBlockStorageV2Volume_online_resize(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -197,6 +330,30 @@ resource "flexibleengine_blockstorage_volume_v2" "volume_1" {
 }
 `
 
+func testAccBlockStorageV2Volume_provisioned(iops, throughput int) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_blockstorage_volume_v2" "volume_1" {
+  name              = "volume_1"
+  availability_zone = "%s"
+  volume_type       = "GPSSD2"
+  size              = 20
+  iops              = %d
+  throughput        = %d
+  device_type       = "SCSI"
+}
+`, OS_AVAILABILITY_ZONE, iops, throughput)
+}
+
+var testAccBlockStorageV2Volume_retype = fmt.Sprintf(`
+resource "flexibleengine_blockstorage_volume_v2" "volume_1" {
+  name              = "volume_1"
+  availability_zone = "%s"
+  volume_type       = "SSD"
+  size              = 20
+  device_type       = "SCSI"
+}
+`, OS_AVAILABILITY_ZONE)
+
 var testAccBlockStorageV2Volume_online_resize = fmt.Sprintf(`
 resource "flexibleengine_compute_instance_v2" "basic" {
   name            = "instance_1"
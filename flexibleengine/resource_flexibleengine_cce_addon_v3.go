@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/cce/v3/addons"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/templates"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,6 +20,7 @@ func resourceCCEAddon() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCCEAddonCreate,
 		Read:   resourceCCEAddonRead,
+		Update: resourceCCEAddonUpdate,
 		Delete: resourceCCEAddonDelete,
 
 		Importer: &schema.ResourceImporter{
@@ -26,6 +29,7 @@ func resourceCCEAddon() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
 
@@ -44,7 +48,6 @@ func resourceCCEAddon() *schema.Resource {
 			"version": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"template_name": {
 				Type:     schema.TypeString,
@@ -54,26 +57,22 @@ func resourceCCEAddon() *schema.Resource {
 			"values": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"basic": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringIsJSON,
 						},
 						"custom": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringIsJSON,
 						},
 						"flavor": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringIsJSON,
 						},
 					},
@@ -140,6 +139,11 @@ func resourceCCEAddonCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error getting values for CCE addon: %s", err)
 	}
+	err = checkCCEAddonValues(cceClient, clusterID, d.Get("template_name").(string), d.Get("version").(string),
+		basic, custom)
+	if err != nil {
+		return err
+	}
 
 	createOpts := addons.CreateOpts{
 		Kind:       "Addon",
@@ -209,6 +213,123 @@ func resourceCCEAddonRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func resourceCCEAddonUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine CCE client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	templateName := d.Get("template_name").(string)
+	version := d.Get("version").(string)
+
+	basic, custom, flavor, err := getValuesValues(d)
+	if err != nil {
+		return fmt.Errorf("error getting values for CCE addon: %s", err)
+	}
+	if err := checkCCEAddonValues(cceClient, clusterID, templateName, version, basic, custom); err != nil {
+		return err
+	}
+
+	updateOpts := addons.UpdateOpts{
+		Kind:       "Addon",
+		ApiVersion: "v3",
+		Metadata: addons.UpdateMetadata{
+			Anno: addons.UpdateAnnotations{
+				AddonUpgradeType: "upgrade",
+			},
+		},
+		Spec: addons.RequestSpec{
+			Version:           version,
+			ClusterID:         clusterID,
+			AddonTemplateName: templateName,
+			Values: addons.Values{
+				Basic:  basic,
+				Custom: custom,
+				Flavor: flavor,
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] Updating FlexibleEngine CCEAddon %s: %#v", d.Id(), updateOpts)
+	_, err = addons.Update(cceClient, updateOpts, d.Id(), clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error updating FlexibleEngine CCEAddon %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{"installing", "upgrading"},
+		Target:                    []string{"running", "available"},
+		Refresh:                   waitForCCEAddonUpdated(cceClient, d.Id(), clusterID),
+		Timeout:                   d.Timeout(schema.TimeoutUpdate),
+		Delay:                     10 * time.Second,
+		PollInterval:              10 * time.Second,
+		ContinuousTargetOccurence: 3,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for FlexibleEngine CCEAddon %s to be updated: %s", d.Id(), err)
+	}
+
+	return resourceCCEAddonRead(d, meta)
+}
+
+// checkCCEAddonValues checks that the version is provided by the addon template and that the basic and
+// custom values only contain the parameters defined by the template
+func checkCCEAddonValues(client *golangsdk.ServiceClient, clusterID, templateName, version string,
+	basic, custom map[string]interface{}) error {
+	templateList, err := templates.List(client, clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve template list: %s", err)
+	}
+
+	template, err := getTemplateByNameAndVersion(templateList, templateName, version)
+	if err != nil {
+		return fmt.Errorf("Unable to find specifies template by name (%s) and version (%s): %s",
+			templateName, version, err)
+	}
+
+	var input struct {
+		Basic      map[string]interface{} `json:"basic"`
+		Parameters struct {
+			Custom map[string]interface{} `json:"custom"`
+		} `json:"parameters"`
+	}
+	if err := json.Unmarshal([]byte(template.Spec), &input); err != nil {
+		log.Printf("[WARN] Unable to parse the spec of addon template %s: %s", templateName, err)
+		return nil
+	}
+
+	if unknown := unknownCCEAddonParameters(basic, input.Basic); len(unknown) > 0 {
+		return fmt.Errorf("the basic values of %s (%s) contain the parameters not defined by the template: %s",
+			templateName, version, strings.Join(unknown, ", "))
+	}
+	if unknown := unknownCCEAddonParameters(custom, input.Parameters.Custom); len(unknown) > 0 {
+		return fmt.Errorf("the custom values of %s (%s) contain the parameters not defined by the template: %s",
+			templateName, version, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// unknownCCEAddonParameters returns the sorted keys of values which are not defined in the template,
+// an empty template definition means that there is nothing to check
+func unknownCCEAddonParameters(values, defined map[string]interface{}) []string {
+	if len(defined) == 0 {
+		return nil
+	}
+
+	unknown := make([]string, 0)
+	for key := range values {
+		if _, ok := defined[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
 func resourceCCEAddonDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	cceClient, err := config.CceAddonV3Client(GetRegion(d, config))
@@ -252,6 +373,22 @@ func waitForCCEAddonActive(cceAddonV3Client *golangsdk.ServiceClient, id, cluste
 	}
 }
 
+// waitForCCEAddonUpdated fails with the status reason once the addon becomes abnormal
+func waitForCCEAddonUpdated(cceClient *golangsdk.ServiceClient, id, clusterID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		n, err := addons.Get(cceClient, id, clusterID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if n.Status.Status == "abnormal" {
+			return n, n.Status.Status, fmt.Errorf("the addon is abnormal, reason: %s, message: %s",
+				n.Status.Reason, n.Status.Message)
+		}
+		return n, n.Status.Status, nil
+	}
+}
+
 func waitForCCEAddonDelete(cceClient *golangsdk.ServiceClient, id, clusterID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		log.Printf("[DEBUG] Attempting to delete FlexibleEngine CCE Addon %s", id)
@@ -2,6 +2,7 @@ package flexibleengine
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -24,12 +25,21 @@ func TestAccCCEAddon_basic(t *testing.T) {
 		CheckDestroy: testAccCheckCCEAddonDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCCEAddon_basic(rName),
+				Config: testAccCCEAddon_basic(rName, "1.0.6"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckCCEAddonExists(resourceName, clusterName, &addon),
+					resource.TestCheckResourceAttr(resourceName, "version", "1.0.6"),
 					resource.TestCheckResourceAttrSet(resourceName, "status"),
 				),
 			},
+			{
+				Config: testAccCCEAddon_basic(rName, "1.1.2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEAddonExists(resourceName, clusterName, &addon),
+					resource.TestCheckResourceAttr(resourceName, "version", "1.1.2"),
+					resource.TestCheckResourceAttr(resourceName, "status", "running"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
@@ -40,6 +50,30 @@ func TestAccCCEAddon_basic(t *testing.T) {
 	})
 }
 
+func TestUnknownCCEAddonParameters(t *testing.T) {
+	defined := map[string]interface{}{
+		"swr_addr": "",
+		"swr_user": "",
+	}
+
+	cases := []struct {
+		values   map[string]interface{}
+		defined  map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{"swr_addr": "addr"}, defined, []string{}},
+		{map[string]interface{}{"swr_addr": "addr", "rbac": true, "euleros": ""}, defined, []string{"euleros", "rbac"}},
+		{map[string]interface{}{"rbac": true}, nil, nil},
+	}
+
+	for _, tc := range cases {
+		unknown := unknownCCEAddonParameters(tc.values, tc.defined)
+		if !reflect.DeepEqual(unknown, tc.expected) {
+			t.Fatalf("expected %v for %v, got %v", tc.expected, tc.values, unknown)
+		}
+	}
+}
+
 func testAccCheckCCEAddonDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	cceClient, err := config.CceAddonV3Client(OS_REGION_NAME)
@@ -129,7 +163,7 @@ func testAccCCEAddonImportStateIdFunc() resource.ImportStateIdFunc {
 	}
 }
 
-func testAccCCEAddon_basic(rName string) string {
+func testAccCCEAddon_basic(rName, version string) string {
 	return fmt.Sprintf(`
 resource "flexibleengine_cce_cluster_v3" "cluster_1" {
   name         = "%s"
@@ -159,9 +193,9 @@ resource "flexibleengine_cce_node_v3" "node_1" {
 
 resource "flexibleengine_cce_addon_v3" "test" {
   cluster_id    = flexibleengine_cce_cluster_v3.cluster_1.id
-  version       = "1.0.6"
+  version       = "%s"
   template_name = "metrics-server"
   depends_on    = [flexibleengine_cce_node_v3.node_1]
 }
-`, rName, OS_VPC_ID, OS_NETWORK_ID, OS_AVAILABILITY_ZONE, OS_KEYPAIR_NAME)
+`, rName, OS_VPC_ID, OS_NETWORK_ID, OS_AVAILABILITY_ZONE, OS_KEYPAIR_NAME, version)
 }
@@ -1,8 +1,12 @@
 package flexibleengine
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,8 +15,14 @@ import (
 	"github.com/chnsz/golangsdk/openstack/networking/v1/eips"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// cceMasterFlavorSizes lists the sizes of master flavors in ascending order, e.g. cce.s2.large
+var cceMasterFlavorSizes = []string{"small", "medium", "large", "xlarge", "2xlarge", "4xlarge"}
+
+var cceVersionRegexp = regexp.MustCompile(`\d+`)
+
 func resourceCCEClusterV3() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCCEClusterV3Create,
@@ -25,9 +35,12 @@ func resourceCCEClusterV3() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: resourceCCEClusterV3CustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -53,13 +66,11 @@ func resourceCCEClusterV3() *schema.Resource {
 			"flavor_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"cluster_version": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"cluster_type": {
 				Type:     schema.TypeString,
@@ -98,6 +109,7 @@ func resourceCCEClusterV3() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 			"container_network_type": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -159,6 +171,24 @@ func resourceCCEClusterV3() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"component_configurations": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"configurations": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: suppressEquivalentJsonDiffs,
+						},
+					},
+				},
+			},
 			"hibernate": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -252,6 +282,9 @@ func resourceClusterExtendParamV3(d *schema.ResourceData) map[string]interface{}
 	if eip, ok := d.GetOk("eip"); ok {
 		m["clusterExternalIP"] = eip.(string)
 	}
+	if epsID, ok := d.GetOk("enterprise_project_id"); ok {
+		m["enterpriseProjectId"] = epsID.(string)
+	}
 	return m
 }
 
@@ -280,6 +313,90 @@ func resourceClusterMastersV3(d *schema.ResourceData) ([]clusters.MasterSpec, er
 	return nil, nil
 }
 
+// compareCCEClusterVersion compares two cluster versions like v1.19.10-r0,
+// it returns a negative number if v1 is lower than v2, 0 if they are equal, and a positive number otherwise.
+func compareCCEClusterVersion(v1, v2 string) int {
+	parts1 := cceVersionRegexp.FindAllString(v1, -1)
+	parts2 := cceVersionRegexp.FindAllString(v2, -1)
+
+	for i := 0; i < len(parts1) && i < len(parts2); i++ {
+		n1, _ := strconv.Atoi(parts1[i])
+		n2, _ := strconv.Atoi(parts2[i])
+		if n1 != n2 {
+			return n1 - n2
+		}
+	}
+	return len(parts1) - len(parts2)
+}
+
+// checkCCEMasterFlavorChange checks whether the master flavor can be changed from oldFlavor to newFlavor:
+// the number of masters (s1 or s2) can not be changed and the size can only be scaled up.
+func checkCCEMasterFlavorChange(oldFlavor, newFlavor string) error {
+	oldIndex := strings.LastIndex(oldFlavor, ".")
+	newIndex := strings.LastIndex(newFlavor, ".")
+	if oldIndex < 0 || newIndex < 0 {
+		// unknown format, let the API check it
+		return nil
+	}
+
+	if oldFlavor[:oldIndex] != newFlavor[:newIndex] {
+		return fmt.Errorf("the master mode of flavor_id can not be changed from %s to %s", oldFlavor, newFlavor)
+	}
+
+	oldSize := indexOfString(cceMasterFlavorSizes, oldFlavor[oldIndex+1:])
+	newSize := indexOfString(cceMasterFlavorSizes, newFlavor[newIndex+1:])
+	if oldSize >= 0 && newSize >= 0 && newSize < oldSize {
+		return fmt.Errorf("flavor_id can only be scaled up, the current value is %s", oldFlavor)
+	}
+	return nil
+}
+
+func indexOfString(list []string, value string) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func resourceCCEClusterV3CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("cluster_version") {
+		o, n := d.GetChange("cluster_version")
+		if n.(string) != "" && compareCCEClusterVersion(n.(string), o.(string)) < 0 {
+			return fmt.Errorf("cluster_version can only be upgraded, the current value is %s", o)
+		}
+	}
+
+	if d.HasChange("flavor_id") {
+		o, n := d.GetChange("flavor_id")
+		if err := checkCCEMasterFlavorChange(o.(string), n.(string)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceClusterConfigurationsV3(d *schema.ResourceData) ([]clusters.PackageConfiguration, error) {
+	rawList := d.Get("component_configurations").([]interface{})
+	packages := make([]clusters.PackageConfiguration, len(rawList))
+	for i, v := range rawList {
+		raw := v.(map[string]interface{})
+		packages[i] = clusters.PackageConfiguration{
+			Name: raw["name"].(string),
+		}
+		if err := json.Unmarshal([]byte(raw["configurations"].(string)), &packages[i].Configurations); err != nil {
+			return nil, fmt.Errorf("error parsing the configurations of %s: %s", packages[i].Name, err)
+		}
+	}
+	return packages, nil
+}
+
 func resourceCustomSans(d *schema.ResourceData) []string {
 	rawCustomSans := d.Get("custom_san").([]interface{})
 	customSans := make([]string, len(rawCustomSans))
@@ -331,6 +448,12 @@ func resourceCCEClusterV3Create(d *schema.ResourceData, meta interface{}) error
 	}
 	spec.Masters = masters
 
+	configurations, err := resourceClusterConfigurationsV3(d)
+	if err != nil {
+		return err
+	}
+	spec.ConfigurationsOverride = configurations
+
 	createOpts := clusters.CreateOpts{
 		Kind:       "Cluster",
 		ApiVersion: "v3",
@@ -408,6 +531,9 @@ func resourceCCEClusterV3Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("authentication_mode", n.Spec.Authentication.Mode)
 	d.Set("security_group_id", n.Spec.HostNetwork.SecurityGroup)
 	d.Set("custom_san", n.Spec.CustomSan)
+	if epsID, ok := n.Spec.ExtendParam["enterpriseProjectId"].(string); ok {
+		d.Set("enterprise_project_id", epsID)
+	}
 
 	cert, err := clusters.GetCert(cceClient, d.Id()).Extract()
 	if err != nil {
@@ -510,6 +636,27 @@ func resourceCCEClusterV3Update(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("flavor_id") {
+		err = resourceClusterResize(d, cceClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("component_configurations") {
+		err = resourceClusterUpdateConfigurations(d, cceClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("cluster_version") {
+		err = resourceClusterUpgrade(d, cceClient)
+		if err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("hibernate") {
 		if d.Get("hibernate").(bool) {
 			err = resourceClusterHibernate(d, cceClient)
@@ -524,6 +671,12 @@ func resourceCCEClusterV3Update(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "cce", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceCCEClusterV3Read(d, meta)
 }
 
@@ -652,6 +805,141 @@ func resourceClusterAwake(d *schema.ResourceData, cceClient *golangsdk.ServiceCl
 	return nil
 }
 
+func resourceClusterResize(d *schema.ResourceData, cceClient *golangsdk.ServiceClient) error {
+	clusterID := d.Id()
+	resizeOpts := map[string]interface{}{
+		"flavorResize": d.Get("flavor_id").(string),
+	}
+
+	log.Printf("[DEBUG] Resizing the master nodes of CCE cluster (%s): %#v", clusterID, resizeOpts)
+	_, err := cceClient.Post(cceClient.ServiceURL("clusters", clusterID, "operation", "resize"), resizeOpts, nil,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 201, 202}})
+	if err != nil {
+		return fmt.Errorf("error resizing the master nodes of CCE cluster: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		// The statuses of pending phase include "Resizing" and "Upgrading".
+		Pending:      []string{"PENDING"},
+		Target:       []string{"COMPLETED"},
+		Refresh:      clusterStateRefreshFunc(cceClient, clusterID, []string{"Available"}),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        60 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for the master nodes of CCE cluster to be resized: %s", err)
+	}
+	return nil
+}
+
+func resourceClusterUpdateConfigurations(d *schema.ResourceData, cceClient *golangsdk.ServiceClient) error {
+	packages, err := resourceClusterConfigurationsV3(d)
+	if err != nil {
+		return err
+	}
+
+	updateOpts := map[string]interface{}{
+		"apiVersion": "v3",
+		"kind":       "Configuration",
+		"metadata": map[string]interface{}{
+			"name": "configuration",
+		},
+		"spec": map[string]interface{}{
+			"packages": packages,
+		},
+	}
+
+	log.Printf("[DEBUG] Updating the component configurations of CCE cluster (%s): %#v", d.Id(), updateOpts)
+	url := cceClient.ServiceURL("clusters", d.Id(), "nodepools", "master", "configuration")
+	_, err = cceClient.Put(url, updateOpts, nil, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		return fmt.Errorf("error updating the component configurations of CCE cluster: %s", err)
+	}
+	return nil
+}
+
+// resourceClusterUpgrade upgrades the cluster in place by an upgrade task, the phase and progress of the task
+// are reported if it does not succeed, as the components and nodes may have been partially upgraded.
+func resourceClusterUpgrade(d *schema.ResourceData, cceClient *golangsdk.ServiceClient) error {
+	clusterID := d.Id()
+	oldVersion, newVersion := d.GetChange("cluster_version")
+	upgradeOpts := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"apiVersion": "v3",
+			"kind":       "UpgradeTask",
+		},
+		"spec": map[string]interface{}{
+			"clusterUpgradeAction": map[string]interface{}{
+				"targetVersion": newVersion.(string),
+				"strategy": map[string]interface{}{
+					"type": "inPlaceRollingUpdate",
+				},
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] Upgrading CCE cluster (%s) from %s to %s", clusterID, oldVersion, newVersion)
+	var result struct {
+		Metadata struct {
+			UID string `json:"uid"`
+		} `json:"metadata"`
+	}
+	_, err := cceClient.Post(cceClient.ServiceURL("clusters", clusterID, "operation", "upgrade"), upgradeOpts, &result,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 201, 202}})
+	if err != nil {
+		return fmt.Errorf("error upgrading CCE cluster from %s to %s: %s", oldVersion, newVersion, err)
+	}
+
+	taskID := result.Metadata.UID
+	var task clusterUpgradeTask
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"Init", "Queuing", "Running"},
+		Target:       []string{"Success"},
+		Refresh:      clusterUpgradeTaskRefreshFunc(cceClient, clusterID, taskID, &task),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        60 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error upgrading CCE cluster from %s to %s (task ID: %s, phase: %s, progress: %s), "+
+			"please check the task in the console: %s",
+			oldVersion, newVersion, taskID, task.Status.Phase, task.Status.Progress, err)
+	}
+	return nil
+}
+
+type clusterUpgradeTask struct {
+	Status struct {
+		Phase    string `json:"phase"`
+		Progress string `json:"progress"`
+	} `json:"status"`
+}
+
+// clusterUpgradeTaskRefreshFunc keeps the latest status of the upgrade task in task.
+func clusterUpgradeTaskRefreshFunc(cceClient *golangsdk.ServiceClient, clusterID, taskID string,
+	task *clusterUpgradeTask) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		url := cceClient.ServiceURL("clusters", clusterID, "operation", "upgrade", "tasks", taskID)
+		_, err := cceClient.Get(url, task, nil)
+		if err != nil {
+			return nil, "ERROR", err
+		}
+
+		phase := task.Status.Phase
+		log.Printf("[DEBUG] The upgrade task (%s) of CCE cluster (%s) is %s, progress: %s",
+			taskID, clusterID, phase, task.Status.Progress)
+		if phase == "Failed" || phase == "Pause" {
+			return task, phase, fmt.Errorf("the upgrade task is %s", phase)
+		}
+		return task, phase, nil
+	}
+}
+
 func clusterStateRefreshFunc(cceClient *golangsdk.ServiceClient, clusterId string,
 	targets []string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
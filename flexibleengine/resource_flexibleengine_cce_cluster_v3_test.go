@@ -54,6 +54,78 @@ func TestAccCCEClusterV3_basic(t *testing.T) {
 	})
 }
 
+func TestAccCCEClusterV3_upgrade(t *testing.T) {
+	var cluster clusters.Clusters
+	var cceName = fmt.Sprintf("terra-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_cce_cluster_v3.cluster_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEClusterV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterV3_upgrade(cceName, "v1.19.10-r0", "cce.s1.small", 200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEClusterV3Exists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", "cce.s1.small"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.19.10-r0"),
+					resource.TestCheckResourceAttr(resourceName, "component_configurations.#", "1"),
+				),
+			},
+			{
+				Config: testAccCCEClusterV3_upgrade(cceName, "v1.21.7-r0", "cce.s1.medium", 300),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEClusterV3Exists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "status", "Available"),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", "cce.s1.medium"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.21.7-r0"),
+				),
+			},
+		},
+	})
+}
+
+func TestCompareCCEClusterVersion(t *testing.T) {
+	cases := []struct {
+		v1, v2   string
+		expected int
+	}{
+		{"v1.17.9-r0", "v1.17.9-r0", 0},
+		{"v1.19.10-r0", "v1.17.9-r0", 1},
+		{"v1.17.9-r0", "v1.19", -1},
+		{"v1.9.10-r0", "v1.11.3-r1", -1},
+		{"v1.21.7-r1", "v1.21.7-r0", 1},
+	}
+
+	for _, tc := range cases {
+		result := compareCCEClusterVersion(tc.v1, tc.v2)
+		if (result > 0) != (tc.expected > 0) || (result < 0) != (tc.expected < 0) {
+			t.Fatalf("comparing %s with %s: expected %d, got %d", tc.v1, tc.v2, tc.expected, result)
+		}
+	}
+}
+
+func TestCheckCCEMasterFlavorChange(t *testing.T) {
+	cases := []struct {
+		oldFlavor, newFlavor string
+		valid                bool
+	}{
+		{"cce.s1.small", "cce.s1.medium", true},
+		{"cce.s2.medium", "cce.s2.xlarge", true},
+		{"cce.s2.large", "cce.s2.medium", false},
+		{"cce.s1.small", "cce.s2.small", false},
+	}
+
+	for _, tc := range cases {
+		err := checkCCEMasterFlavorChange(tc.oldFlavor, tc.newFlavor)
+		if (err == nil) != tc.valid {
+			t.Fatalf("changing flavor from %s to %s: expected valid %t, got error %v",
+				tc.oldFlavor, tc.newFlavor, tc.valid, err)
+		}
+	}
+}
+
 func testAccCheckCCEClusterV3Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	cceClient, err := config.CceV3Client(OS_REGION_NAME)
@@ -194,6 +266,31 @@ resource "flexibleengine_cce_cluster_v3" "cluster_1" {
 }`, testAccCCEClusterV3_Base(cceName), cceName)
 }
 
+func testAccCCEClusterV3_upgrade(cceName, version, flavor string, maxRequests int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_cce_cluster_v3" "cluster_1" {
+  name                   = "%s"
+  cluster_type           = "VirtualMachine"
+  cluster_version        = "%s"
+  flavor_id              = "%s"
+  vpc_id                 = flexibleengine_vpc_v1.test.id
+  subnet_id              = flexibleengine_vpc_subnet_v1.test.id
+  container_network_type = "overlay_l2"
+
+  component_configurations {
+    name           = "kube-apiserver"
+    configurations = jsonencode([
+      {
+        name  = "max-requests-inflight"
+        value = %d
+      }
+    ])
+  }
+}`, testAccCCEClusterV3_Base(cceName), cceName, version, flavor, maxRequests)
+}
+
 func TestAccCluster_hibernate(t *testing.T) {
 	var cluster clusters.Clusters
 
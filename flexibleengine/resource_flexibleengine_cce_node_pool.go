@@ -1,12 +1,14 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,6 +19,11 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
+const cceNodePoolAnnotation = "kubernetes.io/node-pool.id"
+
+// cceNodePoolRollingKeys are the arguments which require the nodes of the pool to be replaced
+var cceNodePoolRollingKeys = []string{"os", "flavor_id", "root_volume", "runtime"}
+
 func resourceCCENodePool() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCCENodePoolCreate,
@@ -40,8 +47,14 @@ func resourceCCENodePool() *schema.Resource {
 			},
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceCCENodePoolCustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
@@ -68,7 +81,6 @@ func resourceCCENodePool() *schema.Resource {
 			"flavor_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -78,29 +90,24 @@ func resourceCCENodePool() *schema.Resource {
 			"root_volume": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"size": {
 							Type:     schema.TypeInt,
 							Required: true,
-							ForceNew: true,
 						},
 						"volumetype": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"kms_key_id": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"extend_params": {
 							Type:     schema.TypeMap,
 							Optional: true,
-							ForceNew: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
 					}},
@@ -143,7 +150,6 @@ func resourceCCENodePool() *schema.Resource {
 			"os": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"key_pair": {
@@ -183,7 +189,8 @@ func resourceCCENodePool() *schema.Resource {
 						},
 					}},
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"max_pods": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -229,7 +236,6 @@ func resourceCCENodePool() *schema.Resource {
 			"runtime": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					"docker", "containerd",
@@ -268,6 +274,27 @@ func resourceCCENodePool() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"rolling_update": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_surge": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
 			"billing_mode": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -351,7 +378,7 @@ func resourceCCENodePoolCreate(d *schema.ResourceData, meta interface{}) error {
 				},
 				ExtendParam: resourceCCEExtendParam(d),
 				Taints:      resourceCCETaint(d),
-				UserTags:    resourceCCENodeUserTags(d),
+				UserTags:    resourceCCENodeUserTags(d, config),
 			},
 			Autoscaling: nodepools.AutoscalingSpec{
 				Enable:                d.Get("scale_enable").(bool) || d.Get("scall_enable").(bool),
@@ -483,20 +510,14 @@ func resourceCCENodePoolRead(d *schema.ResourceData, meta interface{}) error {
 	tagmap := tagsToMap(s.Spec.NodeTemplate.UserTags)
 	// ignore "CCE-Dynamic-Provisioning-Node"
 	delete(tagmap, "CCE-Dynamic-Provisioning-Node")
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := setResourceTags(d, config, tagmap); err != nil {
 		return fmt.Errorf("Error saving tags to state for CCE Node Pool(%s): %s", d.Id(), err)
 	}
 
 	return nil
 }
 
-func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
-	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
-	if err != nil {
-		return fmt.Errorf("Error creating Flexibleengine CCE client: %s", err)
-	}
-
+func buildCCENodePoolUpdateOpts(d *schema.ResourceData, config *Config, nodeCount int) nodepools.UpdateOpts {
 	updateOpts := nodepools.UpdateOpts{
 		Kind:       "NodePool",
 		ApiVersion: "v3",
@@ -504,7 +525,7 @@ func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 			Name: d.Get("name").(string),
 		},
 		Spec: nodepools.UpdateSpec{
-			InitialNodeCount: utils.Int(d.Get("initial_node_count").(int)),
+			InitialNodeCount: utils.Int(nodeCount),
 			Autoscaling: nodepools.AutoscalingSpec{
 				Enable:                d.Get("scale_enable").(bool) || d.Get("scall_enable").(bool),
 				MinNodeCount:          d.Get("min_node_count").(int),
@@ -514,14 +535,50 @@ func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 			},
 			NodeTemplate: nodepools.UpdateNodeTemplate{
 				K8sTags:     resourceCCENodeK8sTags(d),
-				UserTags:    resourceCCENodeUserTags(d),
+				UserTags:    resourceCCENodeUserTags(d, config),
 				Taints:      resourceCCETaint(d),
 				ExtendParam: resourceCCEExtendParam(d),
 			},
 		},
 	}
 
+	// the template of the new nodes created by the rolling update
+	if d.HasChanges(cceNodePoolRollingKeys...) {
+		rootVolume := resourceCCERootVolume(d)
+		updateOpts.Spec.NodeTemplate.Flavor = d.Get("flavor_id").(string)
+		updateOpts.Spec.NodeTemplate.Os = d.Get("os").(string)
+		updateOpts.Spec.NodeTemplate.RootVolume = &rootVolume
+		if v, ok := d.GetOk("runtime"); ok {
+			updateOpts.Spec.NodeTemplate.RunTime = &nodes.RunTimeSpec{
+				Name: v.(string),
+			}
+		}
+	}
+	return updateOpts
+}
+
+func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Flexibleengine CCE client: %s", err)
+	}
+
 	clusterid := d.Get("cluster_id").(string)
+
+	// the nodes existing before the template changes are the ones to be replaced
+	var oldNodeIDs []string
+	if d.HasChanges(cceNodePoolRollingKeys...) {
+		poolNodes, err := listCCENodePoolNodes(nodePoolClient, clusterid, d.Id())
+		if err != nil {
+			return err
+		}
+		for _, node := range poolNodes {
+			oldNodeIDs = append(oldNodeIDs, node.Metadata.Id)
+		}
+	}
+
+	updateOpts := buildCCENodePoolUpdateOpts(d, config, d.Get("initial_node_count").(int))
 	_, err = nodepools.Update(nodePoolClient, clusterid, d.Id(), updateOpts).Extract()
 	if err != nil {
 		return fmt.Errorf("Error updating Flexibleengine Node Node Pool: %s", err)
@@ -540,9 +597,185 @@ func resourceCCENodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error creating Flexibleengine CCE Node Pool: %s", err)
 	}
 
+	if len(oldNodeIDs) > 0 {
+		if err := rollingUpdateCCENodePool(nodePoolClient, d, config, clusterid, oldNodeIDs); err != nil {
+			return err
+		}
+	}
+
 	return resourceCCENodePoolRead(d, meta)
 }
 
+func resourceCCENodePoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChanges(cceNodePoolRollingKeys...) {
+		return nil
+	}
+
+	rollingRaw := d.Get("rolling_update").([]interface{})
+	if len(rollingRaw) == 0 || rollingRaw[0] == nil {
+		// without the rolling update, the node pool has to be recreated, the nested arguments of the root
+		// volume are flagged one by one as flagging the list does not force new on its elements
+		keys := []string{"os", "flavor_id", "runtime", "root_volume.0.size", "root_volume.0.volumetype",
+			"root_volume.0.kms_key_id", "root_volume.0.extend_params"}
+		for _, key := range keys {
+			if d.HasChange(key) {
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	rolling := rollingRaw[0].(map[string]interface{})
+	if rolling["max_surge"].(int)+rolling["max_unavailable"].(int) == 0 {
+		return fmt.Errorf("max_surge and max_unavailable of rolling_update can not be both 0")
+	}
+	// the rolling update scales the pool by itself, the autoscaler would change the node count meanwhile
+	if d.Get("scale_enable").(bool) || d.Get("scall_enable").(bool) {
+		return fmt.Errorf("the rolling update can not be performed while scale_enable is true, " +
+			"please disable the autoscaling of the node pool first")
+	}
+
+	// all the nodes of the pool will be replaced by the ones created with the new template
+	addPlanWarning(ctx, fmt.Sprintf("%d nodes of CCE node pool %s will be replaced",
+		d.Get("current_node_count").(int), d.Get("name").(string)),
+		"The nodes are replaced by the rolling update as flavor_id, os, runtime or root_volume is changed.")
+	return nil
+}
+
+// rollingUpdateCCENodePool replaces the old nodes batch by batch: each batch scales out the pool by max_surge
+// nodes created with the new template, waits for them to become Active and then removes max_surge+max_unavailable
+// old nodes, the pool is scaled back to the expected count at the end of each batch
+func rollingUpdateCCENodePool(client *golangsdk.ServiceClient, d *schema.ResourceData, config *Config, clusterID string,
+	oldNodeIDs []string) error {
+	rolling := d.Get("rolling_update").([]interface{})[0].(map[string]interface{})
+	maxSurge := rolling["max_surge"].(int)
+	maxUnavailable := rolling["max_unavailable"].(int)
+	nodeCount := d.Get("initial_node_count").(int)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	remaining := oldNodeIDs
+	for len(remaining) > 0 {
+		surge := maxSurge
+		if surge > len(remaining) {
+			surge = len(remaining)
+		}
+		removal := surge + maxUnavailable
+		if removal > len(remaining) {
+			removal = len(remaining)
+		}
+
+		if surge > 0 {
+			log.Printf("[DEBUG] Adding %d nodes to CCE node pool %s", surge, d.Id())
+			if err := scaleCCENodePool(client, d, config, clusterID, nodeCount+surge, timeout); err != nil {
+				return err
+			}
+		}
+
+		batch := remaining[:removal]
+		remaining = remaining[removal:]
+		log.Printf("[DEBUG] Removing the old nodes %v from CCE node pool %s", batch, d.Id())
+		for _, nodeID := range batch {
+			err := nodes.Delete(client, clusterID, nodeID).ExtractErr()
+			if err != nil {
+				if _, ok := err.(golangsdk.ErrDefault404); !ok {
+					return fmt.Errorf("Error deleting the old node %s of CCE node pool %s: %s", nodeID, d.Id(), err)
+				}
+			}
+		}
+		for _, nodeID := range batch {
+			stateConf := &resource.StateChangeConf{
+				Pending:      []string{"Deleting", "Active", "Abnormal"},
+				Target:       []string{"Deleted"},
+				Refresh:      waitForCceNodeDelete(client, clusterID, nodeID),
+				Timeout:      timeout,
+				Delay:        30 * time.Second,
+				PollInterval: 20 * time.Second,
+			}
+			if _, err := stateConf.WaitForState(); err != nil {
+				return fmt.Errorf("Error waiting for the old node %s of CCE node pool %s to be deleted: %s",
+					nodeID, d.Id(), err)
+			}
+		}
+
+		if err := scaleCCENodePool(client, d, config, clusterID, nodeCount, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaleCCENodePool sets the node count of the pool if necessary and waits for all the nodes to become Active
+func scaleCCENodePool(client *golangsdk.ServiceClient, d *schema.ResourceData, config *Config, clusterID string,
+	nodeCount int, timeout time.Duration) error {
+	pool, err := nodepools.Get(client, clusterID, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Flexibleengine Node Pool: %s", err)
+	}
+
+	if pool.Spec.InitialNodeCount != nodeCount {
+		_, err = nodepools.Update(client, clusterID, d.Id(), buildCCENodePoolUpdateOpts(d, config, nodeCount)).Extract()
+		if err != nil {
+			return fmt.Errorf("Error scaling CCE node pool %s to %d nodes: %s", d.Id(), nodeCount, err)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"Active"},
+		Refresh:      waitForCCENodePoolNodesActive(client, clusterID, d.Id(), nodeCount),
+		Timeout:      timeout,
+		Delay:        60 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for the nodes of CCE node pool %s to become Active: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func listCCENodePoolNodes(client *golangsdk.ServiceClient, clusterID, nodePoolID string) ([]nodes.Nodes, error) {
+	allNodes, err := nodes.List(client, clusterID, nodes.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the nodes of CCE cluster %s: %s", clusterID, err)
+	}
+
+	poolNodes := make([]nodes.Nodes, 0)
+	for _, node := range allNodes {
+		if node.Metadata.Annotations[cceNodePoolAnnotation] == nodePoolID {
+			poolNodes = append(poolNodes, node)
+		}
+	}
+	return poolNodes, nil
+}
+
+// waitForCCENodePoolNodesActive returns Active when the pool has the expected count of nodes and all of them
+// are Active
+func waitForCCENodePoolNodesActive(client *golangsdk.ServiceClient, clusterID, nodePoolID string,
+	nodeCount int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		poolNodes, err := listCCENodePoolNodes(client, clusterID, nodePoolID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(poolNodes) != nodeCount {
+			return poolNodes, "PENDING", nil
+		}
+		for _, node := range poolNodes {
+			switch node.Status.Phase {
+			case "Active":
+			case "Error", "Abnormal":
+				return poolNodes, node.Status.Phase, fmt.Errorf("the node %s is %s", node.Metadata.Id, node.Status.Phase)
+			default:
+				return poolNodes, "PENDING", nil
+			}
+		}
+		return poolNodes, "Active", nil
+	}
+}
+
 func resourceCCENodePoolDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	nodePoolClient, err := config.CceV3Client(GetRegion(d, config))
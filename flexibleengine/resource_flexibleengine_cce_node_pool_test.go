@@ -1,6 +1,7 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,6 +12,101 @@ import (
 	"github.com/chnsz/golangsdk/openstack/cce/v3/nodepools"
 )
 
+func TestCCENodePoolRollingUpdateDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "pool-id",
+		Attributes: map[string]string{
+			"id":                        "pool-id",
+			"region":                    "eu-west-0",
+			"name":                      "pool",
+			"cluster_id":                "cluster-id",
+			"initial_node_count":        "3",
+			"current_node_count":        "3",
+			"flavor_id":                 "s3.large.2",
+			"os":                        "EulerOS 2.5",
+			"runtime":                   "docker",
+			"availability_zone":         "random",
+			"key_pair":                  "key",
+			"security_groups.#":         "1",
+			"security_groups.0":         "secgroup-id",
+			"root_volume.#":             "1",
+			"root_volume.0.size":        "40",
+			"root_volume.0.volumetype":  "SSD",
+			"data_volumes.#":            "1",
+			"data_volumes.0.size":       "100",
+			"data_volumes.0.volumetype": "SSD",
+		},
+	}
+
+	buildConfig := func(flavor string, rootSize int, rolling, scaling bool) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"name":               "pool",
+			"cluster_id":         "cluster-id",
+			"initial_node_count": 3,
+			"flavor_id":          flavor,
+			"os":                 "EulerOS 2.5",
+			"runtime":            "docker",
+			"key_pair":           "key",
+			"root_volume": []interface{}{
+				map[string]interface{}{"size": rootSize, "volumetype": "SSD"},
+			},
+			"data_volumes": []interface{}{
+				map[string]interface{}{"size": 100, "volumetype": "SSD"},
+			},
+		}
+		if scaling {
+			raw["scale_enable"] = true
+		}
+		if rolling {
+			raw["rolling_update"] = []interface{}{
+				map[string]interface{}{"max_surge": 1, "max_unavailable": 0},
+			}
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	cases := []struct {
+		name        string
+		config      *terraform.ResourceConfig
+		requiresNew bool
+		warning     string
+		expectErr   bool
+	}{
+		{"flavor without rolling update", buildConfig("s3.xlarge.2", 40, false, false), true, "", false},
+		{"root volume without rolling update", buildConfig("s3.large.2", 50, false, false), true, "", false},
+		{"flavor with rolling update", buildConfig("s3.xlarge.2", 40, true, false), false,
+			"3 nodes of CCE node pool pool will be replaced", false},
+		{"root volume with rolling update", buildConfig("s3.large.2", 50, true, false), false,
+			"3 nodes of CCE node pool pool will be replaced", false},
+		{"rolling update with autoscaling", buildConfig("s3.xlarge.2", 40, true, true), false, "", true},
+	}
+
+	for _, tc := range cases {
+		warnings := &planWarnings{}
+		ctx := context.WithValue(context.Background(), planWarningsKey{}, warnings)
+		diff, err := resourceCCENodePool().Diff(ctx, state, tc.config, nil)
+		if tc.expectErr {
+			if err == nil {
+				t.Fatalf("%s: expected an error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		if diff.RequiresNew() != tc.requiresNew {
+			t.Fatalf("%s: expected RequiresNew to be %t", tc.name, tc.requiresNew)
+		}
+		if tc.warning == "" {
+			if len(warnings.diags) != 0 {
+				t.Fatalf("%s: expected no warning, got %s", tc.name, warnings.diags[0].Summary)
+			}
+		} else if len(warnings.diags) != 1 || warnings.diags[0].Summary != tc.warning {
+			t.Fatalf("%s: expected the warning %q, got %v", tc.name, tc.warning, warnings.diags)
+		}
+	}
+}
+
 func TestAccCCENodePool_basic(t *testing.T) {
 	var nodePool nodepools.NodePool
 
@@ -1,6 +1,7 @@
 package flexibleengine
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/hex"
@@ -11,10 +12,12 @@ import (
 	"time"
 
 	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/blockstorage/v2/volumes"
 	"github.com/chnsz/golangsdk/openstack/cce/v3/clusters"
 	"github.com/chnsz/golangsdk/openstack/cce/v3/nodes"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -30,8 +33,14 @@ func resourceCCENodeV3() *schema.Resource {
 			State: resourceCCENodeV3Import,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceCCENodeV3CustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
@@ -55,14 +64,14 @@ func resourceCCENodeV3() *schema.Resource {
 			"labels": { //(k8s_tags)
 				Type:     schema.TypeMap,
 				Optional: true,
-				ForceNew: true,
 			},
 			"annotations": {
 				Type:     schema.TypeMap,
 				Optional: true,
 				ForceNew: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"flavor_id": {
 				Type:     schema.TypeString,
@@ -85,92 +94,41 @@ func resourceCCENodeV3() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			// the replacement of volumes is decided in resourceCCENodeV3CustomizeDiff
+			// as only the size can be increased in place and data volumes can be appended
 			"root_volume": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"size": {
-							Type:     schema.TypeInt,
-							Required: true,
-							ForceNew: true,
-						},
-						"volumetype": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
-						},
-						"kms_key_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
-						},
-						"extend_params": {
-							Type:     schema.TypeMap,
-							Optional: true,
-							ForceNew: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-					}},
+				Elem:     cceNodeVolumeSchema(),
 			},
 			"data_volumes": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"size": {
-							Type:     schema.TypeInt,
-							Required: true,
-							ForceNew: true,
-						},
-						"volumetype": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
-						},
-						"kms_key_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
-						},
-						"extend_params": {
-							Type:     schema.TypeMap,
-							Optional: true,
-							ForceNew: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-					}},
+				Elem:     cceNodeVolumeSchema(),
 			},
 			"taints": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"key": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"value": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"effect": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 					}},
 			},
 			"eip_ids": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 				Computed: true,
@@ -322,6 +280,30 @@ func resourceCCENodeV3() *schema.Resource {
 	}
 }
 
+func cceNodeVolumeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"size": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"volumetype": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"extend_params": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
 func resourceCCENodeAnnotationsV2(d *schema.ResourceData) map[string]string {
 	m := make(map[string]string)
 	for key, val := range d.Get("annotations").(map[string]interface{}) {
@@ -445,8 +427,8 @@ func resourceCCENodeK8sTags(d *schema.ResourceData) map[string]string {
 	return m
 }
 
-func resourceCCENodeUserTags(d *schema.ResourceData) []tags.ResourceTag {
-	tagRaw := d.Get("tags").(map[string]interface{})
+func resourceCCENodeUserTags(d *schema.ResourceData, config *Config) []tags.ResourceTag {
+	tagRaw := getResourceTags(d, config)
 	return expandResourceTags(tagRaw)
 }
 
@@ -472,7 +454,7 @@ func resourceCCENodeV3Create(d *schema.ResourceData, meta interface{}) error {
 			RootVolume:  resourceCCERootVolume(d),
 			DataVolumes: resourceCCEDataVolume(d),
 			ExtendParam: resourceCCEExtendParam(d),
-			UserTags:    resourceCCENodeUserTags(d),
+			UserTags:    resourceCCENodeUserTags(d, config),
 			K8sTags:     resourceCCENodeK8sTags(d),
 			Taints:      resourceCCETaint(d),
 			PublicIP: nodes.PublicIPSpec{
@@ -612,29 +594,44 @@ func resourceCCENodeV3Read(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	rootVolume := expandResourceCCERootVolume(s.Spec)
-	d.Set("root_volume", rootVolume)
+	volumes := expandResourceCCEDataVolumes(s.Spec)
+	// the volumes expanded or appended through EVS are not reflected in the node spec
+	if err := refreshCCENodeV3Volumes(d, config, s.Status.ServerID, rootVolume, &volumes); err != nil {
+		log.Printf("[WARN] Unable to fetch the volumes of CCE node %s: %s", d.Id(), err)
+	}
+
 	if err := d.Set("root_volume", rootVolume); err != nil {
 		return fmt.Errorf("Error saving root volume of cce node %s: %s", d.Id(), err)
 	}
-
-	volumes := expandResourceCCEDataVolumes(s.Spec)
 	if err := d.Set("data_volumes", volumes); err != nil {
 		return fmt.Errorf("Error saving data volumes of cce node %s: %s", d.Id(), err)
 	}
 
 	nodeTaints := expandResourceCCETaints(s.Spec)
+	labels := expandResourceCCEK8sTags(s.Spec)
+	// the labels and taints updated through the Kubernetes API are not reflected in the node spec
+	if k8sClient, err := config.CceV1Client(GetRegion(d, config)); err == nil && s.Status.PrivateIP != "" {
+		k8sNode, err := getCCEKubernetesNode(k8sClient, clusterid, s.Status.PrivateIP)
+		if err == nil {
+			labels, nodeTaints = flattenCCEKubernetesNodeMetadata(k8sNode, labels,
+				d.Get("labels").(map[string]interface{}))
+		} else {
+			log.Printf("[WARN] Unable to fetch the Kubernetes node of CCE node %s: %s", d.Id(), err)
+		}
+	}
+
 	if err := d.Set("taints", nodeTaints); err != nil {
 		return fmt.Errorf("Error saving taints of cce node %s: %s", d.Id(), err)
 	}
-
-	labels := expandResourceCCEK8sTags(s.Spec)
 	if err := d.Set("labels", labels); err != nil {
 		return fmt.Errorf("Error saving labels/k8stags of cce node %s: %s", d.Id(), err)
 	}
 
 	// fetch tags from ECS instance as Spec.UserTags is empty
 	if tagmap, err := expandResourceCCETagsByServer(computeClient, s.Status.ServerID); err == nil {
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagmap); err != nil {
+			return err
+		}
 	} else {
 		return err
 	}
@@ -661,15 +658,41 @@ func resourceCCENodeV3Update(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChanges("labels", "taints") {
+		k8sClient, err := config.CceV1Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating flexibleengine CCE Kubernetes client: %s", err)
+		}
+		if err := updateCCENodeKubernetesMetadata(d, k8sClient); err != nil {
+			return fmt.Errorf("Error updating labels and taints of cce node %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("eip_ids") {
+		networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+		}
+		if err := updateCCENodeEips(d, networkingClient); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("root_volume", "data_volumes") {
+		if err := updateCCENodeVolumes(d, config); err != nil {
+			return fmt.Errorf("Error updating volumes of cce node %s: %s", d.Id(), err)
+		}
+	}
+
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		computeClient, err := config.ComputeV1Client(GetRegion(d, config))
 		if err != nil {
 			return fmt.Errorf("Error creating Flexibleengine compute client: %s", err)
 		}
 
 		serverId := d.Get("server_id").(string)
-		tagErr := UpdateResourceTags(computeClient, d, "servers", serverId)
+		tagErr := UpdateResourceTags(computeClient, d, config, "servers", serverId)
 		if tagErr != nil {
 			return fmt.Errorf("Error updateing tags of cce node %s: %s", d.Id(), tagErr)
 		}
@@ -683,6 +706,27 @@ func resourceCCENodeV3Delete(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error creating flexibleengine CCE client: %s", err)
 	}
+	region := GetRegion(d, config)
+	blockStorageClient, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+	computeClient, err := config.ComputeV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine compute client: %s", err)
+	}
+
+	// the data volumes appended after creation have to be removed once the node is deleted
+	var appended []volumes.Volume
+	if serverID := d.Get("server_id").(string); serverID != "" {
+		serverVolumes, err := getCCENodeServerVolumes(computeClient, blockStorageClient, serverID, d.Id())
+		if err != nil {
+			log.Printf("[WARN] Unable to fetch the volumes of CCE node %s: %s", d.Id(), err)
+		} else {
+			appended = serverVolumes.Appended
+		}
+	}
+
 	clusterid := d.Get("cluster_id").(string)
 	err = nodes.Delete(nodeClient, clusterid, d.Id()).ExtractErr()
 	if err != nil {
@@ -702,10 +746,57 @@ func resourceCCENodeV3Delete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error deleting flexibleengine CCE Node: %s", err)
 	}
 
+	if err := deleteCCENodeAppendedVolumes(blockStorageClient, appended, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
 	d.SetId("")
 	return nil
 }
 
+// resourceCCENodeV3CustomizeDiff only allows the volume size to be increased and
+// data volumes to be appended in place, other volume changes will create a new node.
+func resourceCCENodeV3CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("root_volume") {
+		if err := forceNewCCENodeVolumeChanges(d, "root_volume.0."); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("data_volumes") {
+		o, n := d.GetChange("data_volumes")
+		oldVolumes, newVolumes := o.([]interface{}), n.([]interface{})
+		if len(newVolumes) < len(oldVolumes) {
+			return d.ForceNew("data_volumes")
+		}
+		for i := range oldVolumes {
+			if err := forceNewCCENodeVolumeChanges(d, fmt.Sprintf("data_volumes.%d.", i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func forceNewCCENodeVolumeChanges(d *schema.ResourceDiff, prefix string) error {
+	for _, key := range []string{"volumetype", "kms_key_id", "extend_params"} {
+		if d.HasChange(prefix + key) {
+			if err := d.ForceNew(prefix + key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o, n := d.GetChange(prefix + "size"); n.(int) < o.(int) {
+		return d.ForceNew(prefix + "size")
+	}
+	return nil
+}
+
 func waitForCceNodeActive(cceClient *golangsdk.ServiceClient, clusterId, nodeId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		n, err := nodes.Get(cceClient, clusterId, nodeId).Extract()
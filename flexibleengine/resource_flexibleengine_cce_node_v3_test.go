@@ -1,6 +1,7 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,6 +12,122 @@ import (
 	"github.com/chnsz/golangsdk/openstack/cce/v3/nodes"
 )
 
+func TestCCENodeV3VolumesDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "node-id",
+		Attributes: map[string]string{
+			"id":                        "node-id",
+			"region":                    "eu-west-0",
+			"name":                      "node",
+			"cluster_id":                "cluster-id",
+			"flavor_id":                 "s3.large.2",
+			"availability_zone":         "eu-west-0a",
+			"os":                        "EulerOS 2.5",
+			"key_pair":                  "key",
+			"runtime":                   "docker",
+			"eip_ids.#":                 "0",
+			"eip_count":                 "0",
+			"iptype":                    "",
+			"bandwidth_charge_mode":     "",
+			"sharetype":                 "",
+			"bandwidth_size":            "0",
+			"ecs_performance_type":      "",
+			"ecs_group_id":              "",
+			"subnet_id":                 "subnet-id",
+			"product_id":                "",
+			"max_pods":                  "0",
+			"public_key":                "",
+			"root_volume.#":             "1",
+			"root_volume.0.size":        "40",
+			"root_volume.0.volumetype":  "SSD",
+			"data_volumes.#":            "1",
+			"data_volumes.0.size":       "100",
+			"data_volumes.0.volumetype": "SSD",
+		},
+	}
+
+	buildConfig := func(rootSize int, dataVolumes ...map[string]interface{}) *terraform.ResourceConfig {
+		volumes := make([]interface{}, len(dataVolumes))
+		for i, v := range dataVolumes {
+			volumes[i] = v
+		}
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "node",
+			"cluster_id":        "cluster-id",
+			"flavor_id":         "s3.large.2",
+			"availability_zone": "eu-west-0a",
+			"key_pair":          "key",
+			"root_volume": []interface{}{
+				map[string]interface{}{"size": rootSize, "volumetype": "SSD"},
+			},
+			"data_volumes": volumes,
+		})
+	}
+	dataVolume := func(size int, volumeType string) map[string]interface{} {
+		return map[string]interface{}{"size": size, "volumetype": volumeType}
+	}
+
+	cases := []struct {
+		name        string
+		config      *terraform.ResourceConfig
+		requiresNew bool
+	}{
+		{"expand root volume", buildConfig(50, dataVolume(100, "SSD")), false},
+		{"shrink root volume", buildConfig(30, dataVolume(100, "SSD")), true},
+		{"expand data volume", buildConfig(40, dataVolume(200, "SSD")), false},
+		{"change data volume type", buildConfig(40, dataVolume(100, "SAS")), true},
+		{"append data volume", buildConfig(40, dataVolume(100, "SSD"), dataVolume(50, "SAS")), false},
+		{"remove data volume", buildConfig(40), true},
+	}
+
+	for _, tc := range cases {
+		diff, err := resourceCCENodeV3().Diff(context.Background(), state, tc.config, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		if diff.RequiresNew() != tc.requiresNew {
+			t.Fatalf("%s: expected RequiresNew to be %t", tc.name, tc.requiresNew)
+		}
+	}
+}
+
+func TestBuildCCEKubernetesNodePatch(t *testing.T) {
+	current := &cceKubernetesNode{}
+	current.Spec.Taints = []cceKubernetesTaint{
+		{Key: "node.kubernetes.io/unreachable", Effect: "NoExecute"},
+		{Key: "dedicated", Value: "db", Effect: "NoSchedule"},
+	}
+
+	patch := buildCCEKubernetesNodePatch(current,
+		map[string]interface{}{"app": "web", "tier": "front"},
+		map[string]interface{}{"app": "api"},
+		[]interface{}{map[string]interface{}{"key": "dedicated", "value": "db", "effect": "NoSchedule"}},
+		[]interface{}{map[string]interface{}{"key": "gpu", "value": "true", "effect": "NoSchedule"}},
+	)
+
+	labels := patch["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	if v, ok := labels["tier"]; !ok || v != nil {
+		t.Fatalf("expected the label tier to be removed, got %#v", labels)
+	}
+	if labels["app"] != "api" {
+		t.Fatalf("expected the label app to be api, got %#v", labels)
+	}
+
+	taints := patch["spec"].(map[string]interface{})["taints"].([]cceKubernetesTaint)
+	expected := []cceKubernetesTaint{
+		{Key: "node.kubernetes.io/unreachable", Effect: "NoExecute"},
+		{Key: "gpu", Value: "true", Effect: "NoSchedule"},
+	}
+	if len(taints) != len(expected) {
+		t.Fatalf("expected taints %#v, got %#v", expected, taints)
+	}
+	for i := range expected {
+		if taints[i] != expected[i] {
+			t.Fatalf("expected taints %#v, got %#v", expected, taints)
+		}
+	}
+}
+
 func TestAccCCENodeV3_basic(t *testing.T) {
 	var node nodes.Nodes
 
@@ -41,6 +158,12 @@ func TestAccCCENodeV3_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", cceName+"-update"),
 					resource.TestCheckResourceAttr(resourceName, "tags.key", "value1"),
 					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform"),
+					resource.TestCheckResourceAttr(resourceName, "root_volume.0.size", "50"),
+					resource.TestCheckResourceAttr(resourceName, "data_volumes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "data_volumes.0.size", "120"),
+					resource.TestCheckResourceAttr(resourceName, "data_volumes.1.volumetype", "SSD"),
+					resource.TestCheckResourceAttr(resourceName, "labels.app", "web"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.key", "dedicated"),
 				),
 			},
 			{
@@ -256,13 +379,27 @@ resource "flexibleengine_cce_node_v3" "node_1" {
   runtime = "containerd"
 
   root_volume {
-    size       = 40
+    size       = 50
     volumetype = "SATA"
   }
   data_volumes {
-    size       = 100
+    size       = 120
     volumetype = "SATA"
   }
+  data_volumes {
+    size       = 50
+    volumetype = "SSD"
+  }
+
+  labels = {
+    app = "web"
+  }
+  taints {
+    key    = "dedicated"
+    value  = "web"
+    effect = "NoSchedule"
+  }
+
   tags = {
     key   = "value1"
     owner = "terraform"
@@ -1,11 +1,15 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"time"
 
+	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/cloudeyeservice/v1/alarmrule"
+	alarmrulev2 "github.com/chnsz/golangsdk/openstack/cloudeyeservice/v2/alarmrule"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -44,8 +48,9 @@ func resourceAlarmRule() *schema.Resource {
 		Update: resourceAlarmRuleUpdate,
 		Delete: resourceAlarmRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAlarmRuleImport,
 		},
+		CustomizeDiff: resourceAlarmRuleCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -70,6 +75,16 @@ func resourceAlarmRule() *schema.Resource {
 				Optional: true,
 			},
 
+			"alarm_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"MULTI_INSTANCE", "ALL_INSTANCE", "EVENT.SYS", "EVENT.CUSTOM",
+				}, false),
+			},
+
 			"metric": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -79,41 +94,69 @@ func resourceAlarmRule() *schema.Resource {
 						"namespace": {
 							Type:     schema.TypeString,
 							Required: true,
+							ForceNew: true,
 						},
 
 						"metric_name": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+							Computed: true,
 						},
 
+						"dimensions": {
+							Type:          schema.TypeList,
+							Optional:      true,
+							Computed:      true,
+							MaxItems:      3,
+							ConflictsWith: []string{"resources", "resource_group_id"},
+							Elem:          cesDimensionSchema(),
+						},
+					},
+				},
+			},
+
+			"resources": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"resource_group_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
 						"dimensions": {
 							Type:     schema.TypeList,
 							Required: true,
 							MaxItems: 3,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-
-									"value": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
+							Elem:     cesDimensionSchema(),
 						},
 					},
 				},
 			},
 
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"alarm_template_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
 			"condition": {
 				Type:     schema.TypeList,
 				Required: true,
-				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"metric_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
 						"period": {
 							Type:         schema.TypeInt,
 							Required:     true,
@@ -153,7 +196,17 @@ func resourceAlarmRule() *schema.Resource {
 						},
 						"suppress_duration": {
 							Type:     schema.TypeInt,
+							Optional: true,
 							Computed: true,
+							ValidateFunc: validation.IntInSlice([]int{
+								0, 300, 600, 900, 1800, 3600, 10800, 21600, 43200, 86400,
+							}),
+						},
+						"alarm_level": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(1, 4),
 						},
 					},
 				},
@@ -172,7 +225,6 @@ func resourceAlarmRule() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      2,
-				ForceNew:     true,
 				ValidateFunc: validation.IntBetween(1, 4),
 			},
 
@@ -183,13 +235,15 @@ func resourceAlarmRule() *schema.Resource {
 			},
 
 			"alarm_state": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:       schema.TypeString,
+				Computed:   true,
+				Deprecated: "alarm_state is not returned by the v2 API of Cloud Eye",
 			},
 
 			"update_time": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:       schema.TypeInt,
+				Computed:   true,
+				Deprecated: "update_time is not returned by the v2 API of Cloud Eye",
 			},
 
 			// deprecated
@@ -216,27 +270,59 @@ func resourceAlarmRule() *schema.Resource {
 	}
 }
 
-func getMetricOpts(d *schema.ResourceData) (alarmrule.MetricOpts, error) {
-	mos, ok := d.Get("metric").([]interface{})
-	if !ok {
-		return alarmrule.MetricOpts{}, fmt.Errorf("Error converting opt of metric:%v", d.Get("metric"))
+func cesDimensionSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
 	}
-	mo := mos[0].(map[string]interface{})
+}
 
-	mod := mo["dimensions"].([]interface{})
-	dopts := make([]alarmrule.DimensionOpts, len(mod))
-	for i, v := range mod {
-		v1 := v.(map[string]interface{})
-		dopts[i] = alarmrule.DimensionOpts{
-			Name:  v1["name"].(string),
-			Value: v1["value"].(string),
+func expandAlarmDimensions(rawDimensions []interface{}) []alarmrulev2.DimensionOpts {
+	dimensions := make([]alarmrulev2.DimensionOpts, len(rawDimensions))
+	for i, v := range rawDimensions {
+		dimension := v.(map[string]interface{})
+		dimensions[i] = alarmrulev2.DimensionOpts{
+			Name:  dimension["name"].(string),
+			Value: dimension["value"].(string),
+		}
+	}
+	return dimensions
+}
+
+func expandAlarmResources(rawResources []interface{}) [][]alarmrulev2.DimensionOpts {
+	resources := make([][]alarmrulev2.DimensionOpts, 0, len(rawResources))
+	for _, v := range rawResources {
+		if item, ok := v.(map[string]interface{}); ok {
+			resources = append(resources, expandAlarmDimensions(item["dimensions"].([]interface{})))
 		}
 	}
-	return alarmrule.MetricOpts{
-		Namespace:  mo["namespace"].(string),
-		MetricName: mo["metric_name"].(string),
-		Dimensions: dopts,
-	}, nil
+	return resources
+}
+
+// getAlarmResources returns the resources monitored by the alarm rule, which are specified
+// either by "resources" or, for a single resource, by "metric.0.dimensions"
+func getAlarmResources(d *schema.ResourceData) [][]alarmrulev2.DimensionOpts {
+	if _, ok := d.GetOk("resource_group_id"); ok {
+		return [][]alarmrulev2.DimensionOpts{}
+	}
+
+	if isAlarmRuleFieldConfigured(d, "resources") {
+		return expandAlarmResources(d.Get("resources").([]interface{}))
+	}
+
+	if rawDimensions := d.Get("metric.0.dimensions").([]interface{}); len(rawDimensions) > 0 {
+		return [][]alarmrulev2.DimensionOpts{expandAlarmDimensions(rawDimensions)}
+	}
+	return [][]alarmrulev2.DimensionOpts{}
 }
 
 func getAlarmAction(d *schema.ResourceData, name string) []alarmrule.ActionOpts {
@@ -262,51 +348,121 @@ func getAlarmAction(d *schema.ResourceData, name string) []alarmrule.ActionOpts
 	return opts
 }
 
-func getAlarmCondition(d *schema.ResourceData) alarmrule.ConditionOpts {
-	var opts alarmrule.ConditionOpts
+func getAlarmNotifications(d *schema.ResourceData, name string) []alarmrulev2.NotificationOpts {
+	actions := getAlarmAction(d, name)
+	if len(actions) == 0 {
+		return nil
+	}
+
+	notifications := make([]alarmrulev2.NotificationOpts, len(actions))
+	for i, action := range actions {
+		notifications[i] = alarmrulev2.NotificationOpts{
+			Type:             action.Type,
+			NotificationList: action.NotificationList,
+		}
+	}
+	return notifications
+}
 
-	rawCondition := d.Get("condition").([]interface{})
-	if len(rawCondition) == 1 {
-		condition := rawCondition[0].(map[string]interface{})
+// getAlarmPolicies builds the policies from the conditions. The metric_name and alarm_level
+// of a condition default to the values of metric.0.metric_name and alarm_level
+func getAlarmPolicies(d *schema.ResourceData) []alarmrulev2.PolicyOpts {
+	rawConditions := d.Get("condition").([]interface{})
+	policies := make([]alarmrulev2.PolicyOpts, len(rawConditions))
+
+	for i, v := range rawConditions {
+		condition := v.(map[string]interface{})
+
+		policies[i] = alarmrulev2.PolicyOpts{
+			MetricName:         d.Get("metric.0.metric_name").(string),
+			Period:             condition["period"].(int),
+			Filter:             condition["filter"].(string),
+			ComparisonOperator: condition["comparison_operator"].(string),
+			Value:              condition["value"].(float64),
+			Unit:               condition["unit"].(string),
+			Count:              condition["count"].(int),
+			SuppressDuration:   condition["suppress_duration"].(int),
+			Level:              d.Get("alarm_level").(int),
+		}
 
-		opts.Period = condition["period"].(int)
-		opts.Filter = condition["filter"].(string)
-		opts.ComparisonOperator = condition["comparison_operator"].(string)
-		opts.Value = condition["value"].(float64)
-		opts.Unit = condition["unit"].(string)
-		opts.Count = condition["count"].(int)
+		if isAlarmRuleFieldConfigured(d, "condition", i, "metric_name") {
+			policies[i].MetricName = condition["metric_name"].(string)
+		}
+		if isAlarmRuleFieldConfigured(d, "condition", i, "alarm_level") {
+			policies[i].Level = condition["alarm_level"].(int)
+		}
 	}
 
-	return opts
+	return policies
+}
+
+// isAlarmRuleFieldConfigured checks whether a field is set in the configuration rather than
+// being computed from the state, e.g. ("condition", 0, "alarm_level").
+// The path is made of attribute names and indexes of list blocks.
+func isAlarmRuleFieldConfigured(d *schema.ResourceData, path ...interface{}) bool {
+	value := d.GetRawConfig()
+	for _, step := range path {
+		if value.IsNull() || !value.IsKnown() {
+			return false
+		}
+
+		switch v := step.(type) {
+		case string:
+			value = value.GetAttr(v)
+		case int:
+			if value.LengthInt() <= v {
+				return false
+			}
+			value = value.AsValueSlice()[v]
+		}
+	}
+
+	// blocks which are not specified are empty lists
+	if value.IsNull() || (value.IsKnown() && value.CanIterateElements() && value.LengthInt() == 0) {
+		return false
+	}
+	return true
+}
+
+// resourceAlarmRuleCustomizeDiff refuses the insufficientdata_actions of a new alarm rule,
+// which can not be created by the v2 API
+func resourceAlarmRuleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" && len(d.Get("insufficientdata_actions").([]interface{})) > 0 {
+		return fmt.Errorf("insufficientdata_actions is no longer supported when creating an alarm rule, " +
+			"please remove it from the configuration")
+	}
+	return nil
 }
 
 func resourceAlarmRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
-	client, err := config.CesV1Client(GetRegion(d, config))
+	client, err := config.CesV2Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating Cloud Eye Service client: %s", err)
+		return fmt.Errorf("Error creating Cloud Eye Service v2 client: %s", err)
 	}
 
-	metric, err := getMetricOpts(d)
-	if err != nil {
-		return err
+	alarmType := d.Get("alarm_type").(string)
+	if alarmType == "" {
+		alarmType = "MULTI_INSTANCE"
 	}
 
-	createOpts := alarmrule.CreateOpts{
-		AlarmName:               d.Get("alarm_name").(string),
-		AlarmDescription:        d.Get("alarm_description").(string),
-		AlarmLevel:              d.Get("alarm_level").(int),
-		Metric:                  metric,
-		Condition:               getAlarmCondition(d),
-		AlarmActions:            getAlarmAction(d, "alarm_actions"),
-		OkActions:               getAlarmAction(d, "ok_actions"),
-		InsufficientdataActions: getAlarmAction(d, "insufficientdata_actions"),
-		AlarmEnabled:            d.Get("alarm_enabled").(bool),
-		AlarmActionEnabled:      d.Get("alarm_action_enabled").(bool),
+	createOpts := alarmrulev2.CreateOpts{
+		Name:                d.Get("alarm_name").(string),
+		Description:         d.Get("alarm_description").(string),
+		Type:                alarmType,
+		Namespace:           d.Get("metric.0.namespace").(string),
+		ResourceGroupID:     d.Get("resource_group_id").(string),
+		Resources:           getAlarmResources(d),
+		Policies:            getAlarmPolicies(d),
+		AlarmTemplateID:     d.Get("alarm_template_id").(string),
+		AlarmNotifications:  getAlarmNotifications(d, "alarm_actions"),
+		OkNotifications:     getAlarmNotifications(d, "ok_actions"),
+		Enabled:             d.Get("alarm_enabled").(bool),
+		NotificationEnabled: d.Get("alarm_action_enabled").(bool),
 	}
 	log.Printf("[DEBUG] Create %s Options: %#v", nameCESAR, createOpts)
 
-	r, err := alarmrule.Create(client, createOpts).Extract()
+	r, err := alarmrulev2.Create(client, createOpts).Extract()
 	if err != nil {
 		return fmt.Errorf("Error creating %s: %s", nameCESAR, err)
 	}
@@ -319,39 +475,53 @@ func resourceAlarmRuleCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceAlarmRuleRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
-	client, err := config.CesV1Client(GetRegion(d, config))
+	client, err := config.CesV2Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating Cloud Eye Service client: %s", err)
+		return fmt.Errorf("Error creating Cloud Eye Service v2 client: %s", err)
 	}
 
-	r, err := alarmrule.Get(client, d.Id()).Extract()
+	r, err := alarmrulev2.Get(client, d.Id()).Extract()
 	if err != nil {
 		return CheckDeleted(d, err, "alarmrule")
 	}
 	log.Printf("[DEBUG] Retrieved %s %s: %#v", nameCESAR, d.Id(), r)
 
-	m, err := convertStructToMap(r, map[string]string{"notificationList": "notification_list"})
-	if err != nil {
-		return err
+	var resourceGroupID string
+	var resources []map[string]interface{}
+	if len(r.Resources) > 0 && r.Resources[0].ResourceGroupID != "" {
+		resourceGroupID = r.Resources[0].ResourceGroupID
+	} else {
+		resources = flattenAlarmResources(r.Resources)
+	}
+
+	var dimensions interface{}
+	if len(resources) == 1 {
+		dimensions = resources[0]["dimensions"]
 	}
 
-	alarmMetric := make([]interface{}, 1)
-	alarmMetric[0] = m["metric"]
-	alarmCondition := make([]interface{}, 1)
-	alarmCondition[0] = m["condition"]
+	conditions := flattenAlarmConditions(r.Policies)
+	metricName := getAlarmDefaultMetricName(d.Get("metric.0.metric_name").(string), r.Policies)
+	alarmMetric := []map[string]interface{}{
+		{
+			"namespace":   r.Namespace,
+			"metric_name": metricName,
+			"dimensions":  dimensions,
+		},
+	}
 
 	mErr := multierror.Append(nil,
-		d.Set("alarm_name", m["alarm_name"]),
-		d.Set("alarm_description", m["alarm_description"]),
-		d.Set("alarm_level", m["alarm_level"]),
+		d.Set("alarm_name", r.Name),
+		d.Set("alarm_description", r.Description),
+		d.Set("alarm_type", r.Type),
 		d.Set("metric", alarmMetric),
-		d.Set("condition", alarmCondition),
-		d.Set("alarm_actions", m["alarm_actions"]),
-		d.Set("ok_actions", m["ok_actions"]),
-		d.Set("alarm_enabled", m["alarm_enabled"]),
-		d.Set("alarm_action_enabled", m["alarm_action_enabled"]),
-		d.Set("alarm_state", m["alarm_state"]),
-		d.Set("update_time", m["update_time"]),
+		d.Set("resources", resources),
+		d.Set("resource_group_id", resourceGroupID),
+		d.Set("alarm_template_id", r.AlarmTemplateID),
+		d.Set("condition", conditions),
+		d.Set("alarm_actions", flattenAlarmNotifications(r.AlarmNotifications)),
+		d.Set("ok_actions", flattenAlarmNotifications(r.OkNotifications)),
+		d.Set("alarm_enabled", r.Enabled),
+		d.Set("alarm_action_enabled", r.NotificationEnabled),
 	)
 	if mErr.ErrorOrNil() != nil {
 		return mErr
@@ -360,40 +530,194 @@ func resourceAlarmRuleRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func flattenAlarmNotifications(notifications []alarmrulev2.NotificationOpts) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(notifications))
+	for i, notification := range notifications {
+		result[i] = map[string]interface{}{
+			"type":              notification.Type,
+			"notification_list": notification.NotificationList,
+		}
+	}
+	return result
+}
+
+// getAlarmDefaultMetricName keeps the current metric name if it is still used by a policy,
+// otherwise the metric name of the first policy is returned
+func getAlarmDefaultMetricName(current string, policies []alarmrulev2.PolicyOpts) string {
+	if len(policies) == 0 {
+		return current
+	}
+
+	for _, policy := range policies {
+		if policy.MetricName == current {
+			return current
+		}
+	}
+	return policies[0].MetricName
+}
+
+func flattenAlarmResources(resources []alarmrulev2.ResourcesInfo) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(resources))
+	for i, resource := range resources {
+		dimensions := make([]map[string]interface{}, len(resource.Dimensions))
+		for j, dimension := range resource.Dimensions {
+			dimensions[j] = map[string]interface{}{
+				"name":  dimension.Name,
+				"value": dimension.Value,
+			}
+		}
+		result[i] = map[string]interface{}{
+			"dimensions": dimensions,
+		}
+	}
+	return result
+}
+
+func flattenAlarmConditions(policies []alarmrulev2.PolicyOpts) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(policies))
+	for i, policy := range policies {
+		result[i] = map[string]interface{}{
+			"metric_name":         policy.MetricName,
+			"period":              policy.Period,
+			"filter":              policy.Filter,
+			"comparison_operator": policy.ComparisonOperator,
+			"value":               policy.Value,
+			"unit":                policy.Unit,
+			"count":               policy.Count,
+			"suppress_duration":   policy.SuppressDuration,
+			"alarm_level":         policy.Level,
+		}
+	}
+	return result
+}
+
 func resourceAlarmRuleUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.CesV1Client(GetRegion(d, config))
 	if err != nil {
 		return fmt.Errorf("Error creating Cloud Eye Service client: %s", err)
 	}
+	clientV2, err := config.CesV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Eye Service v2 client: %s", err)
+	}
 
 	arId := d.Id()
 
-	if !d.HasChange("alarm_enabled") {
-		log.Printf("[WARN] %s Nothing will be updated", nameCESAR)
-		return nil
+	if d.HasChanges("alarm_name", "alarm_description", "alarm_action_enabled", "alarm_actions", "ok_actions") {
+		description := d.Get("alarm_description").(string)
+		updateOpts := alarmrule.UpdateOpts{
+			Name:         d.Get("alarm_name").(string),
+			Description:  &description,
+			AlarmActions: getAlarmAction(d, "alarm_actions"),
+			OkActions:    getAlarmAction(d, "ok_actions"),
+		}
+		// the API reports an error if alarm_action_enabled is sent without being changed
+		if d.HasChange("alarm_action_enabled") {
+			actionEnabled := d.Get("alarm_action_enabled").(bool)
+			updateOpts.ActionEnabled = &actionEnabled
+		}
+
+		log.Printf("[DEBUG] Updating %s %s with options: %#v", nameCESAR, arId, updateOpts)
+		if err := alarmrule.Update(client, arId, updateOpts).ExtractErr(); err != nil {
+			return fmt.Errorf("Error updating %s %s: %s", nameCESAR, arId, err)
+		}
 	}
 
-	enabled := d.Get("alarm_enabled").(bool)
-	enableOpts := alarmrule.EnableOpts{
-		AlarmEnabled: enabled,
+	if d.HasChanges("resources", "metric.0.dimensions") {
+		if err := updateAlarmRuleResources(clientV2, d); err != nil {
+			return err
+		}
 	}
-	log.Printf("[DEBUG] Updating %s %s to %#v", nameCESAR, arId, enabled)
 
-	timeout := d.Timeout(schema.TimeoutUpdate)
-	//lintignore:R006
-	err = resource.Retry(timeout, func() *resource.RetryError {
-		err := alarmrule.Enable(client, arId, enableOpts).ExtractErr()
+	if d.HasChanges("condition", "alarm_level", "metric.0.metric_name") {
+		policiesOpts := alarmrulev2.UpdatePoliciesOpts{
+			Policies: getAlarmPolicies(d),
+		}
+		log.Printf("[DEBUG] Updating policies of %s %s with options: %#v", nameCESAR, arId, policiesOpts)
+		if err := alarmrulev2.PoliciesModify(clientV2, arId, policiesOpts).ExtractErr(); err != nil {
+			return fmt.Errorf("Error updating the conditions of %s %s: %s", nameCESAR, arId, err)
+		}
+	}
+
+	if d.HasChange("alarm_enabled") {
+		enabled := d.Get("alarm_enabled").(bool)
+		enableOpts := alarmrule.EnableOpts{
+			AlarmEnabled: enabled,
+		}
+		log.Printf("[DEBUG] Updating %s %s to %#v", nameCESAR, arId, enabled)
+
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		//lintignore:R006
+		err = resource.Retry(timeout, func() *resource.RetryError {
+			err := alarmrule.Enable(client, arId, enableOpts).ExtractErr()
+			if err != nil {
+				return checkForRetryableError(err)
+			}
+			return nil
+		})
 		if err != nil {
-			return checkForRetryableError(err)
+			return fmt.Errorf("Error updating %s %s: %s", nameCESAR, arId, err)
 		}
+	}
+
+	return resourceAlarmRuleRead(d, meta)
+}
+
+// updateAlarmRuleResources replaces the resources monitored by the alarm rule
+func updateAlarmRuleResources(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+	if _, ok := d.GetOk("resource_group_id"); ok {
 		return nil
-	})
+	}
+
+	arId := d.Id()
+	oldRaw, _ := d.GetChange("resources")
+	oldResources := expandAlarmResources(oldRaw.([]interface{}))
+	newResources := getAlarmResources(d)
+	if reflect.DeepEqual(oldResources, newResources) {
+		return nil
+	}
+
+	if len(oldResources) > 0 {
+		opts := alarmrulev2.UpdateResourcesOpts{
+			Resources: oldResources,
+		}
+		if err := alarmrulev2.BatchResources(client, arId, "batch-delete", opts).ExtractErr(); err != nil {
+			return fmt.Errorf("Error removing the resources of %s %s: %s", nameCESAR, arId, err)
+		}
+	}
+
+	if len(newResources) > 0 {
+		opts := alarmrulev2.UpdateResourcesOpts{
+			Resources: newResources,
+		}
+		if err := alarmrulev2.BatchResources(client, arId, "batch-create", opts).ExtractErr(); err != nil {
+			return fmt.Errorf("Error adding the resources of %s %s: %s", nameCESAR, arId, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceAlarmRuleImport sets alarm_level, which is only used as the default level of the conditions,
+// to the level of the first condition
+func resourceAlarmRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	client, err := config.CesV2Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error updating %s %s: %s", nameCESAR, arId, err)
+		return nil, fmt.Errorf("Error creating Cloud Eye Service v2 client: %s", err)
 	}
 
-	return resourceAlarmRuleRead(d, meta)
+	r, err := alarmrulev2.Get(client, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving %s %s: %s", nameCESAR, d.Id(), err)
+	}
+
+	if len(r.Policies) > 0 && r.Policies[0].Level != 0 {
+		d.Set("alarm_level", r.Policies[0].Level)
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceAlarmRuleDelete(d *schema.ResourceData, meta interface{}) error {
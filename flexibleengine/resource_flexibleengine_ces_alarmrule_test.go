@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/chnsz/golangsdk/openstack/cloudeyeservice/v1/alarmrule"
+	alarmrulev2 "github.com/chnsz/golangsdk/openstack/cloudeyeservice/v2/alarmrule"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -35,13 +36,93 @@ func TestCESAlarmRule_basic(t *testing.T) {
 			{
 				Config: testCESAlarmRule_update,
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "alarm_name", "alarm_rule1_update"),
 					resource.TestCheckResourceAttr(resourceName, "alarm_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "alarm_level", "3"),
+					resource.TestCheckResourceAttr(resourceName, "condition.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.metric_name", "network_outgoing_bytes_rate_inband"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.alarm_level", "3"),
+					resource.TestCheckResourceAttr(resourceName, "condition.1.metric_name", "cpu_util"),
+					resource.TestCheckResourceAttr(resourceName, "condition.1.alarm_level", "1"),
 				),
 			},
 		},
 	})
 }
 
+func TestCESAlarmRule_resourceGroup(t *testing.T) {
+	var ar alarmrule.AlarmRule
+	resourceName := "flexibleengine_ces_alarmrule.alarmrule_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCESAlarmRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCESAlarmRule_resourceGroup,
+				Check: resource.ComposeTestCheckFunc(
+					testCESAlarmRuleExists(resourceName, &ar),
+					resource.TestCheckResourceAttr(resourceName, "alarm_type", "MULTI_INSTANCE"),
+					resource.TestCheckResourceAttr(resourceName, "condition.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_group_id",
+						"flexibleengine_ces_resource_group.group_1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestCESAlarmRule_event(t *testing.T) {
+	var ar alarmrule.AlarmRule
+	resourceName := "flexibleengine_ces_alarmrule.alarmrule_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCESAlarmRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCESAlarmRule_event,
+				Check: resource.ComposeTestCheckFunc(
+					testCESAlarmRuleExists(resourceName, &ar),
+					resource.TestCheckResourceAttr(resourceName, "alarm_type", "EVENT.SYS"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.metric_name", "startAutoRecovery"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetAlarmDefaultMetricName(t *testing.T) {
+	policies := []alarmrulev2.PolicyOpts{
+		{MetricName: "cpu_util"},
+		{MetricName: "mem_util"},
+	}
+
+	cases := []struct {
+		current  string
+		policies []alarmrulev2.PolicyOpts
+		expected string
+	}{
+		{"mem_util", policies, "mem_util"},
+		{"disk_util", policies, "cpu_util"},
+		{"", policies, "cpu_util"},
+		{"cpu_util", nil, "cpu_util"},
+	}
+
+	for _, c := range cases {
+		if got := getAlarmDefaultMetricName(c.current, c.policies); got != c.expected {
+			t.Errorf("getAlarmDefaultMetricName(%q) = %q, expected %q", c.current, got, c.expected)
+		}
+	}
+}
+
 func testCESAlarmRuleDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	cesClient, err := config.CesV1Client(OS_REGION_NAME)
@@ -150,9 +231,10 @@ resource "flexibleengine_smn_topic_v2" "topic_1" {
 }
 
 resource "flexibleengine_ces_alarmrule" "alarmrule_1" {
-  alarm_name           = "alarm_rule1"
+  alarm_name           = "alarm_rule1_update"
   alarm_enabled        = false
   alarm_action_enabled = true
+  alarm_level          = 3
 
   metric {
     namespace   = "SYS.ECS"
@@ -170,6 +252,16 @@ resource "flexibleengine_ces_alarmrule" "alarmrule_1" {
     unit                = "B/s"
     count               = 1
   }
+  condition  {
+    metric_name         = "cpu_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 80
+    unit                = "%%"
+    count               = 3
+    alarm_level         = 1
+  }
 
   alarm_actions {
     type = "notification"
@@ -179,3 +271,91 @@ resource "flexibleengine_ces_alarmrule" "alarmrule_1" {
   }
 }
 `, OS_NETWORK_ID)
+
+var testCESAlarmRule_resourceGroup = fmt.Sprintf(`
+resource "flexibleengine_compute_instance_v2" "vm_1" {
+  count = 2
+
+  name = "instance_${count.index}"
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "flexibleengine_ces_resource_group" "group_1" {
+  name = "group_1"
+
+  dynamic "resources" {
+    for_each = flexibleengine_compute_instance_v2.vm_1[*].id
+    content {
+      namespace = "SYS.ECS"
+      dimensions {
+        name  = "instance_id"
+        value = resources.value
+      }
+    }
+  }
+}
+
+resource "flexibleengine_ces_alarmrule" "alarmrule_1" {
+  alarm_name        = "alarm_rule_group"
+  alarm_type        = "MULTI_INSTANCE"
+  resource_group_id = flexibleengine_ces_resource_group.group_1.id
+
+  metric {
+    namespace = "SYS.ECS"
+  }
+
+  condition {
+    metric_name         = "cpu_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 80
+    unit                = "%%"
+    count               = 3
+  }
+  condition {
+    metric_name         = "mem_util"
+    period              = 300
+    filter              = "average"
+    comparison_operator = ">"
+    value               = 80
+    unit                = "%%"
+    count               = 3
+    alarm_level         = 1
+  }
+}
+`, OS_NETWORK_ID)
+
+const testCESAlarmRule_event = `
+resource "flexibleengine_smn_topic_v2" "topic_1" {
+  name         = "topic_event"
+  display_name = "The display name of topic_event"
+}
+
+resource "flexibleengine_ces_alarmrule" "alarmrule_1" {
+  alarm_name = "alarm_rule_event"
+  alarm_type = "EVENT.SYS"
+
+  metric {
+    namespace = "SYS.ECS"
+  }
+
+  condition {
+    metric_name         = "startAutoRecovery"
+    period              = 0
+    filter              = "average"
+    comparison_operator = ">="
+    value               = 1
+    count               = 1
+  }
+
+  alarm_actions {
+    type = "notification"
+    notification_list = [
+      flexibleengine_smn_topic_v2.topic_1.topic_urn
+    ]
+  }
+}
+`
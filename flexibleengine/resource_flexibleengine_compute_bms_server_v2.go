@@ -26,6 +26,9 @@ func resourceComputeBMSInstanceV2() *schema.Resource {
 		Read:   resourceComputeBMSInstanceV2Read,
 		Update: resourceComputeBMSInstanceV2Update,
 		Delete: resourceComputeBMSInstanceV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -476,6 +479,13 @@ func resourceComputeBMSInstanceV2Read(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	secGroups := make([]string, len(server.SecurityGroups))
+	for i, sg := range server.SecurityGroups {
+		secGroups[i] = sg.Name
+	}
+	d.Set("security_groups", secGroups)
+	d.Set("key_pair", server.KeyName)
+
 	d.Set("availability_zone", server.AvailabilityZone)
 	d.Set("tenant_id", server.TenantID)
 	d.Set("host_status", server.HostStatus)
@@ -33,6 +33,17 @@ func TestAccComputeV2BmsInstance_basic(t *testing.T) {
 						"flexibleengine_compute_bms_server_v2.instance_1", "name", "instance_2"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_compute_bms_server_v2.instance_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"user_data",
+					"admin_pass",
+					"block_device",
+					"stop_before_destroy",
+				},
+			},
 		},
 	})
 }
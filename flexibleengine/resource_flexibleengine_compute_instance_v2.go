@@ -18,6 +18,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/compute/v2/flavors"
 	"github.com/chnsz/golangsdk/openstack/compute/v2/servers"
 	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -34,6 +35,11 @@ func resourceComputeInstanceV2() *schema.Resource {
 			State: resourceComputeInstanceV2ImportState,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceComputeInstanceV2CustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -113,33 +119,28 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"network": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				MaxItems: 12,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"uuid": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"name": {
 							Type:       schema.TypeString,
 							Optional:   true,
-							ForceNew:   true,
 							Computed:   true,
 							Deprecated: "use uuid instead",
 						},
 						"port": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v4": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v6": {
@@ -188,38 +189,31 @@ func resourceComputeInstanceV2() *schema.Resource {
 						"source_type": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"uuid": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"volume_size": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true,
 						},
 						"destination_type": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"boot_index": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true,
 						},
 						"delete_on_termination": {
 							Type:     schema.TypeBool,
 							Optional: true,
 							Default:  false,
-							ForceNew: true,
 						},
 						"guest_format": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"disk_bus": {
 							Type:     schema.TypeString,
@@ -235,12 +229,14 @@ func resourceComputeInstanceV2() *schema.Resource {
 						"device_name": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"volume_type": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
+						},
+						"volume_id": {
+							Type:     schema.TypeString,
+							Computed: true,
 						},
 					},
 				},
@@ -332,11 +328,9 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			"tags": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-			},
+			"tags":                  tagsSchema(),
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 			"all_metadata": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -524,13 +518,12 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	if hasFilledOpt(d, "tags") {
+	if tagmap := getResourceTags(d, config); len(tagmap) > 0 {
 		ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
 		if err != nil {
 			return fmt.Errorf("Error creating FlexibleEngine compute v1 client: %s", err)
 		}
 
-		tagmap := d.Get("tags").(map[string]interface{})
 		log.Printf("[DEBUG] Setting tags(key/value): %v", tagmap)
 		err = setTagsForInstance(ecsClient, server.ID, tagmap)
 		if err != nil {
@@ -538,6 +531,13 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	// the compute v2 API can not create an instance in an enterprise project
+	if config.GetEnterpriseProjectID(d) != "" {
+		if err := migrateEnterpriseProject(d, config, "ecs", server.ID); err != nil {
+			return err
+		}
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -565,6 +565,7 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	d.Set("availability_zone", server.AvailabilityZone)
 	d.Set("name", server.Name)
 	d.Set("status", server.Status)
+	d.Set("enterprise_project_id", server.EnterpriseProjectID)
 
 	flavorInfo := server.Flavor
 	d.Set("flavor_id", flavorInfo.ID)
@@ -629,14 +630,19 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	d.Set("security_groups", secGrpNames)
 
 	// Set volume attached
+	var volumes []map[string]interface{}
 	if len(server.VolumeAttached) > 0 {
-		volumes, rootID, err := flattenInstanceVolumeAttached(d, config, server)
+		var rootID string
+		volumes, rootID, err = flattenInstanceVolumeAttached(d, config, server)
 		if err != nil {
 			return nil
 		}
 		d.Set("volume_attached", volumes)
 		d.Set("system_disk_id", rootID)
 	}
+	if blockDevices := d.Get("block_device").([]interface{}); len(blockDevices) > 0 {
+		d.Set("block_device", flattenInstanceBlockDevices(blockDevices, volumes))
+	}
 
 	// set scheduler_hints
 	osHints := server.OsSchedulerHints
@@ -693,7 +699,9 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	if err != nil && !isResourceNotFound(err) {
 		return fmt.Errorf("Error reading tags of instance:%s, err=%s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	if err := setResourceTags(d, config, tags); err != nil {
+		return fmt.Errorf("Error saving tags of instance:%s, err=%s", d.Id(), err)
+	}
 
 	return nil
 }
@@ -856,6 +864,18 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("network") {
+		if err := updateInstanceNetworks(d, meta, computeClient); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("block_device") {
+		if err := updateInstanceBlockDevices(d, meta, computeClient); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("auto_recovery") {
 		ar := d.Get("auto_recovery").(bool)
 		log.Printf("[DEBUG] Update auto recovery of instance to %t", ar)
@@ -865,10 +885,9 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	if d.HasChange("tags") {
-		oRaw, nRaw := d.GetChange("tags")
-		oMap := oRaw.(map[string]interface{})
-		nMap := nRaw.(map[string]interface{})
+	if d.HasChanges("tags", "tags_all") {
+		oMap := getOldResourceTags(d, config)
+		nMap := getResourceTags(d, config)
 
 		ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
 		if err != nil {
@@ -892,6 +911,12 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "ecs", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/chnsz/golangsdk/openstack/compute/v2/servers"
 	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/security/groups"
 	"github.com/chnsz/golangsdk/pagination"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 func TestAccComputeV2Instance_basic(t *testing.T) {
@@ -45,6 +47,46 @@ func TestAccComputeV2Instance_basic(t *testing.T) {
 	})
 }
 
+func TestUnitComputeV2Instance_basic(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	resourceName := "flexibleengine_compute_instance_v2.instance_1"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockcloud.PreCheck(t) },
+		ProviderFactories: srv.ProviderFactories(Provider),
+		CheckDestroy:      srv.CheckDestroy("flexibleengine_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testUnitComputeV2Instance_basic("instance_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "instance_1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "flavor_id", mockcloud.FlavorID),
+					resource.TestCheckResourceAttr(resourceName, "image_name", mockcloud.ImageName),
+					resource.TestCheckResourceAttr(resourceName, "availability_zone", mockcloud.AvailabilityZone),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+					resource.TestCheckResourceAttrSet(resourceName, "network.0.fixed_ip_v4"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testUnitComputeV2Instance_basic("instance_1-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "instance_1-updated"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"stop_before_destroy",
+				},
+			},
+		},
+	})
+}
+
 func TestAccComputeV2Instance_secgroupMulti(t *testing.T) {
 	var instance_1 servers.Server
 	var secgroup_1 groups.SecGroup
@@ -168,6 +210,191 @@ func TestAccComputeV2Instance_bootFromVolumeForceNew(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_hotplug(t *testing.T) {
+	var instance1_1 servers.Server
+	var instance1_2 servers.Server
+	resourceName := "flexibleengine_compute_instance_v2.instance_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_hotplug_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance1_1),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "block_device.#", "0"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_hotplug_2,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance1_2),
+					testAccCheckComputeV2InstanceInstanceIDsMatch(&instance1_1, &instance1_2),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "network.1.port"),
+					resource.TestCheckResourceAttr(resourceName, "volume_attached.#", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "block_device.1.volume_id"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_hotplug_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance1_2),
+					testAccCheckComputeV2InstanceInstanceIDsMatch(&instance1_1, &instance1_2),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "volume_attached.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDiffInstanceNetworks(t *testing.T) {
+	oldNetworks := []interface{}{
+		map[string]interface{}{"uuid": "net-1", "port": "port-1", "fixed_ip_v4": "192.168.0.10"},
+		map[string]interface{}{"uuid": "net-2", "port": "port-2", "fixed_ip_v4": "192.168.1.10"},
+		map[string]interface{}{"uuid": "net-3", "port": "port-3", "fixed_ip_v4": "192.168.2.10"},
+	}
+	desired := []InstanceNetwork{
+		{UUID: "net-1"},
+		{UUID: "net-3", FixedIP: "192.168.2.10"},
+		{Port: "port-4"},
+		{UUID: "net-2", FixedIP: "192.168.1.20"},
+	}
+
+	detachPorts, ports := diffInstanceNetworks(oldNetworks, desired)
+	if fmt.Sprint(detachPorts) != "[port-2]" {
+		t.Errorf("expected to detach [port-2], got %v", detachPorts)
+	}
+	if expected := "[port-1 port-3  ]"; fmt.Sprint(ports) != expected {
+		t.Errorf("expected the matched ports to be %s, got %v", expected, ports)
+	}
+
+	// the primary NIC is never detached even if the first network is resolved to another one
+	detachPorts, ports = diffInstanceNetworks(oldNetworks[:2], []InstanceNetwork{{UUID: "net-4"}, {UUID: "net-2"}})
+	if len(detachPorts) != 0 {
+		t.Errorf("expected no NIC to be detached, got %v", detachPorts)
+	}
+	if expected := "[port-1 port-2]"; fmt.Sprint(ports) != expected {
+		t.Errorf("expected the matched ports to be %s, got %v", expected, ports)
+	}
+}
+
+func TestDiffInstanceBlockDevices(t *testing.T) {
+	bootDisk := map[string]interface{}{
+		"source_type": "image", "uuid": "image-1", "volume_size": 40, "destination_type": "volume",
+		"boot_index": 0, "delete_on_termination": true, "guest_format": "", "device_name": "", "volume_type": "SSD",
+	}
+	dataDisk := func(uuid string) map[string]interface{} {
+		return map[string]interface{}{
+			"source_type": "volume", "uuid": uuid, "volume_size": 0, "destination_type": "volume",
+			"boot_index": 1, "delete_on_termination": false, "guest_format": "", "device_name": "", "volume_type": "",
+		}
+	}
+	resizedBootDisk := make(map[string]interface{})
+	for k, v := range bootDisk {
+		resizedBootDisk[k] = v
+	}
+	resizedBootDisk["volume_size"] = 50
+
+	changes := diffInstanceBlockDevices(
+		[]interface{}{bootDisk, dataDisk("volume-1"), dataDisk("volume-2")},
+		[]interface{}{bootDisk, dataDisk("volume-2"), dataDisk("volume-3")},
+	)
+	if changes.ForceNew {
+		t.Errorf("expected the data disks to be changed in place")
+	}
+	if len(changes.Detach) != 1 || changes.Detach[0]["uuid"] != "volume-1" {
+		t.Errorf("expected to detach volume-1, got %v", changes.Detach)
+	}
+	if len(changes.Attach) != 1 || changes.Attach[0]["uuid"] != "volume-3" {
+		t.Errorf("expected to attach volume-3, got %v", changes.Attach)
+	}
+
+	changes = diffInstanceBlockDevices(
+		[]interface{}{bootDisk, dataDisk("volume-1")},
+		[]interface{}{resizedBootDisk, dataDisk("volume-1")},
+	)
+	if !changes.ForceNew {
+		t.Errorf("expected a new instance when the boot disk is changed")
+	}
+
+	// the attached volume is not reattached when the fields which can not be changed online are changed
+	deletedDataDisk := dataDisk("volume-1")
+	deletedDataDisk["delete_on_termination"] = true
+	changes = diffInstanceBlockDevices(
+		[]interface{}{bootDisk, dataDisk("volume-1")},
+		[]interface{}{bootDisk, deletedDataDisk},
+	)
+	if !changes.ForceNew || len(changes.Detach) != 0 || len(changes.Attach) != 0 {
+		t.Errorf("expected a new instance instead of reattaching volume-1, got %+v", changes)
+	}
+
+	blankDisk := func(size int, volumeID string) map[string]interface{} {
+		return map[string]interface{}{
+			"source_type": "blank", "uuid": "", "volume_size": size, "destination_type": "volume",
+			"boot_index": 1, "delete_on_termination": true, "guest_format": "", "device_name": "",
+			"volume_type": "SAS", "volume_id": volumeID,
+		}
+	}
+	changes = diffInstanceBlockDevices(
+		[]interface{}{bootDisk, blankDisk(10, "volume-10"), blankDisk(20, "volume-20")},
+		[]interface{}{bootDisk, blankDisk(20, "volume-10"), blankDisk(30, "")},
+	)
+	if changes.ForceNew {
+		t.Errorf("expected the blank data disks to be changed in place")
+	}
+	if len(changes.Delete) != 1 || changes.Delete[0]["volume_id"] != "volume-10" {
+		t.Errorf("expected to delete volume-10, got %v", changes.Delete)
+	}
+	if len(changes.Create) != 1 || changes.Create[0]["volume_size"] != 30 {
+		t.Errorf("expected to create a 30 GB data disk, got %v", changes.Create)
+	}
+
+	// the volume of the removed data disk is unknown
+	changes = diffInstanceBlockDevices(
+		[]interface{}{bootDisk, blankDisk(10, "")},
+		[]interface{}{bootDisk},
+	)
+	if !changes.ForceNew {
+		t.Errorf("expected a new instance when the volume of the removed data disk is unknown")
+	}
+}
+
+func TestFlattenInstanceBlockDevices(t *testing.T) {
+	blankDisk := func(size int, volumeID string) map[string]interface{} {
+		return map[string]interface{}{
+			"source_type": "blank", "uuid": "", "volume_size": size, "destination_type": "volume",
+			"boot_index": 1, "delete_on_termination": true, "guest_format": "", "device_name": "",
+			"volume_type": "SAS", "volume_id": volumeID,
+		}
+	}
+	volume := func(volumeID string, size, bootIndex int) map[string]interface{} {
+		return map[string]interface{}{"uuid": volumeID, "size": size, "type": "SAS", "boot_index": bootIndex}
+	}
+
+	// the stale volume_id of the second disk is replaced by the new volume
+	devices := flattenInstanceBlockDevices(
+		[]interface{}{blankDisk(10, "volume-10"), blankDisk(30, "volume-20")},
+		[]map[string]interface{}{volume("volume-0", 10, 0), volume("volume-10", 10, 1), volume("volume-30", 30, 2)},
+	)
+	if devices[0]["volume_id"] != "volume-10" || devices[1]["volume_id"] != "volume-30" {
+		t.Errorf("expected the volumes to be volume-10 and volume-30, got %v", devices)
+	}
+
+	// the disk can not be paired with the volumes of the same size
+	devices = flattenInstanceBlockDevices(
+		[]interface{}{blankDisk(10, "")},
+		[]map[string]interface{}{volume("volume-0", 10, 0), volume("volume-1", 10, 1), volume("volume-2", 10, 2)},
+	)
+	if devices[0]["volume_id"] != "" {
+		t.Errorf("expected the volume to be unknown, got %v", devices[0]["volume_id"])
+	}
+}
+
 // TODO: verify the personality really exists on the instance.
 func TestAccComputeV2Instance_personality(t *testing.T) {
 	var instance servers.Server
@@ -443,6 +670,17 @@ func testAccCheckComputeV2InstanceBootVolumeAttachment(
 	}
 }
 
+func testAccCheckComputeV2InstanceInstanceIDsMatch(
+	instance1, instance2 *servers.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if instance1.ID != instance2.ID {
+			return fmt.Errorf("Instance was recreated: %s, %s", instance1.ID, instance2.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckComputeV2InstanceInstanceIDsDoNotMatch(
 	instance1, instance2 *servers.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -761,6 +999,56 @@ resource "flexibleengine_compute_instance_v2" "instance_1" {
 }
 `, OS_NETWORK_ID)
 
+var testAccComputeV2Instance_hotplug_1 = fmt.Sprintf(`
+resource "flexibleengine_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = 10
+  availability_zone = "%[1]s"
+}
+
+resource "flexibleengine_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%[1]s"
+  network {
+    uuid = "%[2]s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
+var testAccComputeV2Instance_hotplug_2 = fmt.Sprintf(`
+resource "flexibleengine_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = 10
+  availability_zone = "%[1]s"
+}
+
+resource "flexibleengine_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%[1]s"
+  network {
+    uuid = "%[2]s"
+  }
+  network {
+    uuid = "%[2]s"
+  }
+  block_device {
+    uuid = flexibleengine_blockstorage_volume_v2.volume_1.id
+    source_type = "volume"
+    destination_type = "volume"
+    boot_index = -1
+  }
+  block_device {
+    source_type = "blank"
+    destination_type = "volume"
+    volume_size = 10
+    boot_index = -1
+    delete_on_termination = true
+  }
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
 var testAccComputeV2Instance_stopBeforeDestroy = fmt.Sprintf(`
 resource "flexibleengine_compute_instance_v2" "instance_1" {
   name = "instance_1"
@@ -969,3 +1257,27 @@ resource "flexibleengine_compute_instance_v2" "instance_1" {
   auto_recovery = true
 }
 `, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
+func testUnitComputeV2Instance_basic(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_compute_instance_v2" "instance_1" {
+  name              = "%s"
+  image_id          = "%s"
+  flavor_id         = "%s"
+  availability_zone = "%s"
+  security_groups   = ["default"]
+
+  metadata = {
+    foo = "bar"
+  }
+
+  network {
+    uuid = "%s"
+  }
+
+  tags = {
+    key1 = "value1"
+  }
+}
+`, name, mockcloud.ImageID, mockcloud.FlavorID, mockcloud.AvailabilityZone, mockcloud.NetworkID)
+}
@@ -15,29 +15,54 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
+	"github.com/chnsz/golangsdk/openstack/css/v1/cluster"
 	"github.com/chnsz/golangsdk/openstack/css/v1/snapshots"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	cssNodeTypeEss    = "ess"
+	cssNodeTypeMaster = "ess-master"
+	cssNodeTypeClient = "ess-client"
+)
+
+// cssRoleConfigs maps the node configuration blocks of master and client nodes to their node types
+var cssRoleConfigs = map[string]string{
+	"master_node_config": cssNodeTypeMaster,
+	"client_node_config": cssNodeTypeClient,
+}
+
 func resourceCssClusterV1() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCssClusterV1Create,
 		Read:   resourceCssClusterV1Read,
 		Update: resourceCssClusterV1Update,
 		Delete: resourceCssClusterV1Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceCssClusterV1CustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -78,14 +103,12 @@ func resourceCssClusterV1() *schema.Resource {
 			"node_config": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"flavor": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"network_info": {
 							Type:     schema.TypeList,
@@ -115,13 +138,13 @@ func resourceCssClusterV1() *schema.Resource {
 						"volume": {
 							Type:     schema.TypeList,
 							Required: true,
-							ForceNew: true,
 							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"size": {
-										Type:     schema.TypeInt,
-										Required: true,
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntDivisibleBy(10),
 									},
 									"volume_type": {
 										Type:     schema.TypeString,
@@ -140,6 +163,34 @@ func resourceCssClusterV1() *schema.Resource {
 				},
 			},
 
+			"master_node_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     cssClusterV1RoleNodeSchema(3, 10),
+			},
+			"client_node_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     cssClusterV1RoleNodeSchema(1, 32),
+			},
+
+			"public_access": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"password"},
+				Elem:         cssClusterV1PublicAccessSchema(),
+			},
+			"kibana_public_access": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"password"},
+				Elem:         cssClusterV1PublicAccessSchema(),
+			},
+
 			"backup_strategy": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -166,6 +217,9 @@ func resourceCssClusterV1() *schema.Resource {
 
 			"tags": tagsSchema(),
 
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
+
 			"created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -200,7 +254,100 @@ func resourceCssClusterV1() *schema.Resource {
 	}
 }
 
-func resourceCssClusterV1UserInputParams(d *schema.ResourceData) map[string]interface{} {
+func cssClusterV1RoleNodeSchema(min, max int) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"flavor": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"instance_number": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(min, max),
+			},
+			"volume": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"size": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntDivisibleBy(10),
+						},
+						"volume_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func cssClusterV1PublicAccessSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"bandwidth": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"whitelist_enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+			"whitelist": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceCssClusterV1CustomizeDiff refuses to shrink the cluster during the plan, and recreates the
+// cluster when the master or client nodes are added or removed.
+func resourceCssClusterV1CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, key := range []string{"node_number", "node_config.0.volume.0.size",
+		"master_node_config.0.instance_number", "client_node_config.0.instance_number"} {
+		if !d.HasChange(key) {
+			continue
+		}
+		o, n := d.GetChange(key)
+		if n.(int) < o.(int) && n.(int) != 0 {
+			return fmt.Errorf("%s can only be increased, the current value is %d", key, o)
+		}
+	}
+
+	for key := range cssRoleConfigs {
+		if !d.HasChange(key) {
+			continue
+		}
+		o, n := d.GetChange(key)
+		if len(o.([]interface{})) != len(n.([]interface{})) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceCssClusterV1UserInputParams(d *schema.ResourceData, config *Config) map[string]interface{} {
 	return map[string]interface{}{
 		"terraform_resource_data": d,
 		"name":                    d.Get("name"),
@@ -208,7 +355,7 @@ func resourceCssClusterV1UserInputParams(d *schema.ResourceData) map[string]inte
 		"engine_version":          d.Get("engine_version"),
 		"node_number":             d.Get("node_number"),
 		"node_config":             d.Get("node_config"),
-		"tags":                    d.Get("tags"),
+		"tags":                    getResourceTags(d, config),
 	}
 }
 
@@ -219,7 +366,7 @@ func resourceCssClusterV1Create(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating FlexibleEngine CSS client: %s", err)
 	}
 
-	opts := resourceCssClusterV1UserInputParams(d)
+	opts := resourceCssClusterV1UserInputParams(d, config)
 	arrayIndex := map[string]int{
 		"node_config.network_info": 0,
 		"node_config.volume":       0,
@@ -230,7 +377,13 @@ func resourceCssClusterV1Create(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return fmt.Errorf("Error building the request body of api(create), err=%s", err)
 	}
-	r, err := sendCssClusterV1CreateRequest(d, params, client)
+	url := client.ServiceURL("clusters")
+	if cssClusterV1NeedV2Create(d) {
+		// master/client nodes and public access can only be specified by the v2.0 API
+		params = buildCssClusterV2CreateParameters(d, params.(map[string]interface{}))
+		url = strings.Replace(url, "/v1.0/", "/v2.0/", 1)
+	}
+	r, err := sendCssClusterV1CreateRequest(url, params, client)
 	if err != nil {
 		return fmt.Errorf("Error creating CssClusterV1, err=%s", err)
 	}
@@ -284,7 +437,7 @@ func resourceCssClusterV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	res := make(map[string]interface{})
 	res["read"] = fillCssClusterV1ReadRespBody(v)
-	if err := setCssClusterV1Properties(d, res); err != nil {
+	if err := setCssClusterV1Properties(d, config, res); err != nil {
 		return err
 	}
 
@@ -316,7 +469,7 @@ func resourceCssClusterV1Read(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	tagmap := tagsToMap(resourceTags.Tags)
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := setResourceTags(d, config, tagmap); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving tag to state for CSS cluster (%s): %s", d.Id(), err)
 	}
 
@@ -330,7 +483,12 @@ func resourceCssClusterV1Update(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating FlexibleEngine CSS client: %s", err)
 	}
 
-	opts := resourceCssClusterV1UserInputParams(d)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if err := updateCssClusterV1Flavors(d, config, client, timeout); err != nil {
+		return err
+	}
+
+	opts := resourceCssClusterV1UserInputParams(d, config)
 	arrayIndex := map[string]int{
 		"node_config.network_info": 0,
 		"node_config.volume":       0,
@@ -347,13 +505,23 @@ func resourceCssClusterV1Update(d *schema.ResourceData, meta interface{}) error
 			return err
 		}
 
-		timeout := d.Timeout(schema.TimeoutUpdate)
 		_, err = asyncWaitCssClusterV1ExtendCluster(d, config, r, client, timeout)
 		if err != nil {
 			return err
 		}
 	}
 
+	if d.HasChange("public_access") {
+		if err := updateCssClusterV1PublicAccess(d, config, client, timeout); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("kibana_public_access") {
+		if err := updateCssClusterV1KibanaPublicAccess(d, config, client, timeout); err != nil {
+			return err
+		}
+	}
+
 	// update backup strategy
 	if d.HasChange("backup_strategy") {
 		var opts = snapshots.PolicyCreateOpts{
@@ -391,13 +559,19 @@ func resourceCssClusterV1Update(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(client, d, "css-cluster", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(client, d, config, "css-cluster", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of CSS cluster:%s, err:%s", d.Id(), tagErr)
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "css-cluster", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceCssClusterV1Read(d, meta)
 }
 
@@ -490,6 +664,10 @@ func buildCssClusterV1CreateParameters(opts map[string]interface{}, arrayIndex m
 		params["adminPwd"] = adminPassword
 	}
 
+	if epsID := resourceData.Get("enterprise_project_id").(string); epsID != "" {
+		params["enterprise_project_id"] = epsID
+	}
+
 	// build tags parameter
 	tagOpts := opts["tags"].(map[string]interface{})
 	if len(tagOpts) > 0 {
@@ -640,10 +818,87 @@ func expandCssClusterV1CreateInstanceVolume(d interface{}, arrayIndex map[string
 	return req, nil
 }
 
-func sendCssClusterV1CreateRequest(d *schema.ResourceData, params interface{},
-	client *golangsdk.ServiceClient) (interface{}, error) {
-	url := client.ServiceURL("clusters")
+func cssClusterV1NeedV2Create(d *schema.ResourceData) bool {
+	for _, key := range []string{"master_node_config", "client_node_config", "public_access", "kibana_public_access"} {
+		if len(d.Get(key).([]interface{})) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCssClusterV2CreateParameters converts the v1.0 request body to the v2.0 one,
+// the specifications of all node types are described by roles in v2.0.
+func buildCssClusterV2CreateParameters(d *schema.ResourceData, params map[string]interface{}) interface{} {
+	cluster := params["cluster"].(map[string]interface{})
+	instance := cluster["instance"].(map[string]interface{})
+
+	roles := []map[string]interface{}{
+		{
+			"type":        cssNodeTypeEss,
+			"flavorRef":   instance["flavorRef"],
+			"instanceNum": d.Get("node_number"),
+			"volume":      instance["volume"],
+		},
+	}
+	for key, nodeType := range cssRoleConfigs {
+		rawList := d.Get(key).([]interface{})
+		if len(rawList) == 0 {
+			continue
+		}
+		raw := rawList[0].(map[string]interface{})
+		volume := raw["volume"].([]interface{})[0].(map[string]interface{})
+		roles = append(roles, map[string]interface{}{
+			"type":        nodeType,
+			"flavorRef":   raw["flavor"],
+			"instanceNum": raw["instance_number"],
+			"volume": map[string]interface{}{
+				"size":        volume["size"],
+				"volume_type": volume["volume_type"],
+			},
+		})
+	}
+
+	cluster["roles"] = roles
+	cluster["nics"] = instance["nics"]
+	if az, ok := instance["availability_zone"]; ok {
+		cluster["availability_zone"] = az
+	}
+	delete(cluster, "instance")
+	delete(cluster, "instanceNum")
+
+	if rawList := d.Get("public_access").([]interface{}); len(rawList) > 0 {
+		raw := rawList[0].(map[string]interface{})
+		cluster["publicIPReq"] = map[string]interface{}{
+			"publicBindType": "auto_assign",
+			"eip": map[string]interface{}{
+				"bandWidth": map[string]interface{}{
+					"size": raw["bandwidth"],
+				},
+			},
+			"elbWhiteListReq": map[string]interface{}{
+				"enableWhiteList": raw["whitelist_enabled"],
+				"whiteList":       raw["whitelist"],
+			},
+		}
+	}
 
+	if rawList := d.Get("kibana_public_access").([]interface{}); len(rawList) > 0 {
+		raw := rawList[0].(map[string]interface{})
+		cluster["publicKibanaReq"] = map[string]interface{}{
+			"eipSize": raw["bandwidth"],
+			"elbWhiteList": map[string]interface{}{
+				"enableWhiteList": raw["whitelist_enabled"],
+				"whiteList":       raw["whitelist"],
+			},
+		}
+	}
+
+	return map[string]interface{}{"cluster": cluster}
+}
+
+func sendCssClusterV1CreateRequest(url string, params interface{},
+	client *golangsdk.ServiceClient) (interface{}, error) {
 	r := golangsdk.Result{}
 	_, r.Err = client.Post(url, params, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: successHTTPCodes,
@@ -713,14 +968,39 @@ func buildCssClusterV1ExtendClusterParameters(opts map[string]interface{}, array
 	}
 	params["disksize"] = v
 
+	grows := make([]map[string]interface{}, 0)
 	// both of nodesize and disksize can not be set to 0 simultaneously
-	if params["nodesize"].(int) == 0 && params["disksize"].(int) == 0 {
-		return nil, nil
+	if params["nodesize"].(int) != 0 || params["disksize"].(int) != 0 {
+		params["type"] = cssNodeTypeEss
+		grows = append(grows, params)
+	}
+
+	rd := opts["terraform_resource_data"].(*schema.ResourceData)
+	for key, nodeType := range cssRoleConfigs {
+		numKey := key + ".0.instance_number"
+		if !rd.HasChange(numKey) {
+			continue
+		}
+		oldv, newv := rd.GetChange(numKey)
+		// the whole role block is added or removed, the cluster will be rebuilt
+		if oldv.(int) == 0 || newv.(int) == 0 {
+			continue
+		}
+		if newv.(int) < oldv.(int) {
+			return nil, fmt.Errorf("%s only supports to be extended", numKey)
+		}
+		grows = append(grows, map[string]interface{}{
+			"type":     nodeType,
+			"nodesize": newv.(int) - oldv.(int),
+			"disksize": 0,
+		})
 	}
 
-	params["type"] = "ess"
+	if len(grows) == 0 {
+		return nil, nil
+	}
 	updateOpts := map[string]interface{}{
-		"grow": []map[string]interface{}{params},
+		"grow": grows,
 	}
 
 	return updateOpts, nil
@@ -773,6 +1053,193 @@ func asyncWaitCssClusterV1ExtendCluster(d *schema.ResourceData, config *Config,
 	)
 }
 
+// getCssFlavorID returns the ID of the flavor with the given name, which is required by the flavor changing API
+func getCssFlavorID(client *golangsdk.ServiceClient, version, nodeType, name string) (string, error) {
+	resp, err := cluster.ListFlavors(client)
+	if err != nil {
+		return "", fmt.Errorf("Error fetching CSS flavors: %s", err)
+	}
+
+	for _, v := range resp.Versions {
+		if v.Version != version || v.Type != nodeType {
+			continue
+		}
+		for _, flavor := range v.Flavors {
+			if flavor.Name == name {
+				return flavor.FlavorId, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("Unable to find the CSS flavor %s of %s nodes in version %s", name, nodeType, version)
+}
+
+func updateCssClusterV1Flavors(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient,
+	timeout time.Duration) error {
+	flavorKeys := map[string]string{
+		"node_config.0.flavor":        cssNodeTypeEss,
+		"master_node_config.0.flavor": cssNodeTypeMaster,
+		"client_node_config.0.flavor": cssNodeTypeClient,
+	}
+
+	version := d.Get("engine_version").(string)
+	for key, nodeType := range flavorKeys {
+		if !d.HasChange(key) {
+			continue
+		}
+		// the whole role block is added or removed, the cluster will be rebuilt
+		if o, n := d.GetChange(key); o.(string) == "" || n.(string) == "" {
+			continue
+		}
+
+		flavorID, err := getCssFlavorID(client, version, nodeType, d.Get(key).(string))
+		if err != nil {
+			return err
+		}
+
+		url := client.ServiceURL("clusters", d.Id(), nodeType, "flavor")
+		params := map[string]interface{}{
+			"newFlavorId":      flavorID,
+			"needCheckReplica": true,
+		}
+		log.Printf("[DEBUG] Changing the flavor of CSS cluster %s %s nodes: %#v", d.Id(), nodeType, params)
+		r := golangsdk.Result{}
+		_, r.Err = client.Post(url, params, &r.Body, &golangsdk.RequestOpts{
+			OkCodes: successHTTPCodes,
+		})
+		if r.Err != nil {
+			return fmt.Errorf("Error changing the flavor of CSS cluster %s %s nodes: %s", d.Id(), nodeType, r.Err)
+		}
+
+		if _, err := asyncWaitCssClusterV1ExtendCluster(d, config, r.Body, client, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cssPublicAccessOptions returns the configuration of a public access block, or nil if it is not set
+func cssPublicAccessOptions(raw interface{}) map[string]interface{} {
+	if rawList, ok := raw.([]interface{}); ok && len(rawList) > 0 && rawList[0] != nil {
+		return rawList[0].(map[string]interface{})
+	}
+	return nil
+}
+
+func doCssClusterV1PublicAction(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient,
+	timeout time.Duration, method, path string, params interface{}) error {
+	url := client.ServiceURL("clusters", d.Id(), path)
+	opts := golangsdk.RequestOpts{
+		OkCodes:     successHTTPCodes,
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	}
+
+	log.Printf("[DEBUG] Calling %s of CSS cluster %s: %#v", path, d.Id(), params)
+	r := golangsdk.Result{}
+	if method == "PUT" {
+		_, r.Err = client.Put(url, params, &r.Body, &opts)
+	} else {
+		_, r.Err = client.Post(url, params, &r.Body, &opts)
+	}
+	if r.Err != nil {
+		return fmt.Errorf("Error running api(%s) of CSS cluster %s: %s", path, d.Id(), r.Err)
+	}
+
+	_, err := asyncWaitCssClusterV1ExtendCluster(d, config, r.Body, client, timeout)
+	return err
+}
+
+// updateCssClusterV1WhiteList enables, updates or disables the access control of the public network
+func updateCssClusterV1WhiteList(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient,
+	timeout time.Duration, prefix string, oldOpts, newOpts map[string]interface{}) error {
+	if newOpts["whitelist_enabled"].(bool) {
+		if oldOpts["whitelist_enabled"].(bool) && oldOpts["whitelist"] == newOpts["whitelist"] {
+			return nil
+		}
+		params := map[string]interface{}{
+			"whiteList": newOpts["whitelist"],
+		}
+		return doCssClusterV1PublicAction(d, config, client, timeout, "POST", prefix+"/whitelist/update", params)
+	}
+
+	if oldOpts["whitelist_enabled"].(bool) {
+		return doCssClusterV1PublicAction(d, config, client, timeout, "PUT", prefix+"/whitelist/close", nil)
+	}
+	return nil
+}
+
+func updateCssClusterV1PublicAccess(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient,
+	timeout time.Duration) error {
+	o, n := d.GetChange("public_access")
+	oldOpts, newOpts := cssPublicAccessOptions(o), cssPublicAccessOptions(n)
+
+	if newOpts == nil {
+		return doCssClusterV1PublicAction(d, config, client, timeout, "PUT", "public/close", nil)
+	}
+
+	if oldOpts == nil {
+		params := map[string]interface{}{
+			"eip": map[string]interface{}{
+				"bandWidth": map[string]interface{}{
+					"size": newOpts["bandwidth"],
+				},
+			},
+			"isAutoPay": 1,
+		}
+		if err := doCssClusterV1PublicAction(d, config, client, timeout, "POST", "public/open", params); err != nil {
+			return err
+		}
+		// the access control is disabled after the public access is opened
+		oldOpts = map[string]interface{}{"whitelist_enabled": false}
+	} else if oldOpts["bandwidth"] != newOpts["bandwidth"] {
+		params := map[string]interface{}{
+			"bandWidth": map[string]interface{}{
+				"size": newOpts["bandwidth"],
+			},
+			"isAutoPay": 1,
+		}
+		if err := doCssClusterV1PublicAction(d, config, client, timeout, "POST", "public/bandwidth", params); err != nil {
+			return err
+		}
+	}
+
+	return updateCssClusterV1WhiteList(d, config, client, timeout, "public", oldOpts, newOpts)
+}
+
+func updateCssClusterV1KibanaPublicAccess(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient,
+	timeout time.Duration) error {
+	o, n := d.GetChange("kibana_public_access")
+	oldOpts, newOpts := cssPublicAccessOptions(o), cssPublicAccessOptions(n)
+
+	if newOpts == nil {
+		return doCssClusterV1PublicAction(d, config, client, timeout, "PUT", "publickibana/close", nil)
+	}
+
+	if oldOpts == nil {
+		// the access control is specified when opening the kibana public access
+		params := map[string]interface{}{
+			"eipSize": newOpts["bandwidth"],
+			"elbWhiteList": map[string]interface{}{
+				"enableWhiteList": newOpts["whitelist_enabled"],
+				"whiteList":       newOpts["whitelist"],
+			},
+		}
+		return doCssClusterV1PublicAction(d, config, client, timeout, "POST", "publickibana/open", params)
+	}
+
+	if oldOpts["bandwidth"] != newOpts["bandwidth"] {
+		params := map[string]interface{}{
+			"bandWidth": map[string]interface{}{
+				"size": newOpts["bandwidth"],
+			},
+		}
+		if err := doCssClusterV1PublicAction(d, config, client, timeout, "POST", "publickibana/bandwidth", params); err != nil {
+			return err
+		}
+	}
+
+	return updateCssClusterV1WhiteList(d, config, client, timeout, "publickibana", oldOpts, newOpts)
+}
+
 func sendCssClusterV1ReadRequest(d *schema.ResourceData, client *golangsdk.ServiceClient) (interface{}, error) {
 	url, err := replaceVars(d, "clusters/{id}", nil)
 	if err != nil {
@@ -814,6 +1281,12 @@ func fillCssClusterV1ReadRespBody(body interface{}) interface{} {
 		result["created"] = nil
 	}
 
+	if v, ok := val["enterpriseProjectId"]; ok {
+		result["enterpriseProjectId"] = v
+	} else {
+		result["enterpriseProjectId"] = nil
+	}
+
 	if v, ok := val["datastore"]; ok {
 		result["datastore"] = fillCssClusterV1ReadRespDatastore(v)
 	} else {
@@ -848,6 +1321,22 @@ func fillCssClusterV1ReadRespBody(body interface{}) interface{} {
 		result["security_mode"] = v
 	}
 
+	for _, key := range []string{"publicIp", "bandwidthSize", "elbWhiteList", "publicKibanaResp"} {
+		if v, ok := val[key]; ok {
+			result[key] = v
+		} else {
+			result[key] = nil
+		}
+	}
+
+	for _, key := range []string{"vpcId", "subnetId", "securityGroupId"} {
+		if v, ok := val[key]; ok {
+			result[key] = v
+		} else {
+			result[key] = nil
+		}
+	}
+
 	if v, ok := val["status"]; ok {
 		result["status"] = v
 	} else {
@@ -929,14 +1418,22 @@ func fillCssClusterV1ReadRespInstances(value interface{}) interface{} {
 			val["type"] = nil
 		}
 
+		for _, key := range []string{"specCode", "azCode", "volume"} {
+			if v, ok := item[key]; ok {
+				val[key] = v
+			} else {
+				val[key] = nil
+			}
+		}
+
 		result[i] = val
 	}
 
 	return result
 }
 
-func setCssClusterV1Properties(d *schema.ResourceData, response map[string]interface{}) error {
-	opts := resourceCssClusterV1UserInputParams(d)
+func setCssClusterV1Properties(d *schema.ResourceData, config *Config, response map[string]interface{}) error {
+	opts := resourceCssClusterV1UserInputParams(d, config)
 
 	v, err := navigateValue(response, []string{"read", "created"}, nil)
 	if err != nil {
@@ -985,6 +1482,13 @@ func setCssClusterV1Properties(d *schema.ResourceData, response map[string]inter
 		}
 	}
 
+	v, err = navigateValue(response, []string{"read", "enterpriseProjectId"}, nil)
+	if err == nil {
+		if err = d.Set("enterprise_project_id", v); err != nil {
+			return fmt.Errorf("Error setting Cluster:enterprise_project_id, err: %s", err)
+		}
+	}
+
 	v, _ = opts["nodes"]
 	v, err = flattenCssClusterV1Nodes(response, nil, v)
 	if err != nil {
@@ -994,14 +1498,160 @@ func setCssClusterV1Properties(d *schema.ResourceData, response map[string]inter
 		return fmt.Errorf("Error setting Cluster:nodes, err: %s", err)
 	}
 
-	nodeNum := len(v.([]interface{}))
-	if err = d.Set("node_number", nodeNum); err != nil {
+	essNodes := filterCssClusterV1Instances(response, cssNodeTypeEss)
+	if err = d.Set("node_number", len(essNodes)); err != nil {
 		return fmt.Errorf("Error setting Cluster:nodes number, err: %s", err)
 	}
 
+	if nodeConfig := flattenCssClusterV1NodeConfig(d, response); nodeConfig != nil {
+		if err = d.Set("node_config", nodeConfig); err != nil {
+			return fmt.Errorf("Error setting Cluster:node_config, err: %s", err)
+		}
+	}
+
+	for key, nodeType := range cssRoleConfigs {
+		if err = d.Set(key, flattenCssClusterV1RoleNodeConfig(response, nodeType)); err != nil {
+			return fmt.Errorf("Error setting Cluster:%s, err: %s", key, err)
+		}
+	}
+
+	if err = d.Set("public_access", flattenCssClusterV1PublicAccess(response)); err != nil {
+		return fmt.Errorf("Error setting Cluster:public_access, err: %s", err)
+	}
+	if err = d.Set("kibana_public_access", flattenCssClusterV1KibanaPublicAccess(response)); err != nil {
+		return fmt.Errorf("Error setting Cluster:kibana_public_access, err: %s", err)
+	}
+
 	return nil
 }
 
+// filterCssClusterV1Instances returns the nodes of the specified type
+func filterCssClusterV1Instances(response map[string]interface{}, nodeType string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	instances, err := navigateValue(response, []string{"read", "instances"}, nil)
+	if err != nil {
+		return result
+	}
+
+	nodes, _ := instances.([]interface{})
+	for _, v := range nodes {
+		node, ok := v.(map[string]interface{})
+		if ok && node["type"] == nodeType {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+func flattenCssClusterV1RoleNodeConfig(response map[string]interface{}, nodeType string) []map[string]interface{} {
+	nodes := filterCssClusterV1Instances(response, nodeType)
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	node := nodes[0]
+	volumes := []map[string]interface{}{}
+	if volume, ok := node["volume"].(map[string]interface{}); ok {
+		volumes = append(volumes, map[string]interface{}{
+			"volume_type": volume["type"],
+			"size":        volume["size"],
+		})
+	}
+
+	return []map[string]interface{}{
+		{
+			"flavor":          node["specCode"],
+			"instance_number": len(nodes),
+			"volume":          volumes,
+		},
+	}
+}
+
+func flattenCssClusterV1PublicAccess(response map[string]interface{}) []map[string]interface{} {
+	publicIP, _ := navigateValue(response, []string{"read", "publicIp"}, nil)
+	if ip, ok := publicIP.(string); !ok || ip == "" {
+		return nil
+	}
+
+	bandwidth, _ := navigateValue(response, []string{"read", "bandwidthSize"}, nil)
+	enabled, _ := navigateValue(response, []string{"read", "elbWhiteList", "enableWhiteList"}, nil)
+	whitelist, _ := navigateValue(response, []string{"read", "elbWhiteList", "whiteList"}, nil)
+
+	return []map[string]interface{}{
+		{
+			"bandwidth":         bandwidth,
+			"whitelist_enabled": enabled,
+			"whitelist":         whitelist,
+			"public_ip":         publicIP,
+		},
+	}
+}
+
+func flattenCssClusterV1KibanaPublicAccess(response map[string]interface{}) []map[string]interface{} {
+	kibana, _ := navigateValue(response, []string{"read", "publicKibanaResp"}, nil)
+	if kibana == nil {
+		return nil
+	}
+
+	bandwidth, _ := navigateValue(kibana, []string{"eipSize"}, nil)
+	enabled, _ := navigateValue(kibana, []string{"elbWhiteListResp", "enableWhiteList"}, nil)
+	whitelist, _ := navigateValue(kibana, []string{"elbWhiteListResp", "whiteList"}, nil)
+	publicIP, _ := navigateValue(kibana, []string{"publicKibanaIp"}, nil)
+
+	return []map[string]interface{}{
+		{
+			"bandwidth":         bandwidth,
+			"whitelist_enabled": enabled,
+			"whitelist":         whitelist,
+			"public_ip":         publicIP,
+		},
+	}
+}
+
+// flattenCssClusterV1NodeConfig builds the node_config from the network settings of
+// the cluster and the specification of its first data node.
+func flattenCssClusterV1NodeConfig(d *schema.ResourceData, response map[string]interface{}) []map[string]interface{} {
+	nodes := filterCssClusterV1Instances(response, cssNodeTypeEss)
+	if len(nodes) == 0 {
+		return nil
+	}
+	node := nodes[0]
+
+	networkInfo := map[string]interface{}{}
+	for key, field := range map[string]string{
+		"vpc_id":            "vpcId",
+		"subnet_id":         "subnetId",
+		"security_group_id": "securityGroupId",
+	} {
+		if v, err := navigateValue(response, []string{"read", field}, nil); err == nil && v != nil {
+			networkInfo[key] = v
+		}
+	}
+
+	volumes := []map[string]interface{}{}
+	if volume, ok := node["volume"].(map[string]interface{}); ok {
+		volumes = append(volumes, map[string]interface{}{
+			"volume_type": volume["type"],
+			"size":        volume["size"],
+		})
+	}
+
+	// availability_zone is optional, keep the configured value unless it is being imported
+	az := d.Get("node_config.0.availability_zone").(string)
+	if _, ok := d.GetOk("node_config"); !ok && node["azCode"] != nil {
+		az = node["azCode"].(string)
+	}
+
+	return []map[string]interface{}{
+		{
+			"flavor":            node["specCode"],
+			"network_info":      []map[string]interface{}{networkInfo},
+			"volume":            volumes,
+			"availability_zone": az,
+		},
+	}
+}
+
 func flattenCssClusterV1Nodes(d interface{}, arrayIndex map[string]int, currentValue interface{}) (interface{}, error) {
 	n := 0
 	hasInitValue := true
@@ -52,6 +52,11 @@ func TestAccCssClusterV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.key_update", "value"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -79,6 +84,78 @@ func TestAccCssClusterV1_security(t *testing.T) {
 	})
 }
 
+func TestAccCssClusterV1_scale(t *testing.T) {
+	randName := acctest.RandString(6)
+	resourceName := "flexibleengine_css_cluster_v1.cluster"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCssClusterV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCssClusterV1_basic(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCssClusterV1Exists(),
+					resource.TestCheckResourceAttr(resourceName, "node_number", "1"),
+					resource.TestCheckResourceAttr(resourceName, "node_config.0.flavor", "ess.spec-4u16g"),
+					resource.TestCheckResourceAttr(resourceName, "node_config.0.volume.0.size", "40"),
+				),
+			},
+			{
+				Config: testAccCssClusterV1_scale(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCssClusterV1Exists(),
+					resource.TestCheckResourceAttr(resourceName, "node_number", "2"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "node_config.0.flavor", "ess.spec-4u32g"),
+					resource.TestCheckResourceAttr(resourceName, "node_config.0.volume.0.size", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCssClusterV1_roles(t *testing.T) {
+	randName := acctest.RandString(6)
+	resourceName := "flexibleengine_css_cluster_v1.cluster"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCssClusterV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCssClusterV1_roles(randName, 3, 1, 5, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCssClusterV1Exists(),
+					resource.TestCheckResourceAttr(resourceName, "node_number", "1"),
+					resource.TestCheckResourceAttr(resourceName, "master_node_config.0.instance_number", "3"),
+					resource.TestCheckResourceAttr(resourceName, "client_node_config.0.instance_number", "1"),
+					resource.TestCheckResourceAttr(resourceName, "public_access.0.bandwidth", "5"),
+					resource.TestCheckResourceAttr(resourceName, "public_access.0.whitelist_enabled", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "public_access.0.public_ip"),
+					resource.TestCheckResourceAttr(resourceName, "kibana_public_access.0.bandwidth", "5"),
+					resource.TestCheckResourceAttrSet(resourceName, "kibana_public_access.0.public_ip"),
+				),
+			},
+			{
+				Config: testAccCssClusterV1_roles(randName, 5, 2, 10, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCssClusterV1Exists(),
+					resource.TestCheckResourceAttr(resourceName, "master_node_config.0.instance_number", "5"),
+					resource.TestCheckResourceAttr(resourceName, "client_node_config.0.instance_number", "2"),
+					resource.TestCheckResourceAttr(resourceName, "public_access.0.bandwidth", "10"),
+					resource.TestCheckResourceAttr(resourceName, "public_access.0.whitelist_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "public_access.0.whitelist", "121.10.10.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "kibana_public_access.0.bandwidth", "10"),
+					resource.TestCheckResourceAttr(resourceName, "kibana_public_access.0.whitelist_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCssClusterV1Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	client, err := config.CssV1Client(OS_REGION_NAME)
@@ -232,3 +309,98 @@ resource "flexibleengine_css_cluster_v1" "cluster" {
 }
 	`, val, val, OS_NETWORK_ID, OS_VPC_ID, OS_AVAILABILITY_ZONE)
 }
+
+func testAccCssClusterV1_scale(val string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_networking_secgroup_v2" "secgroup" {
+  name = "terraform_test_security_group%s"
+  description = "terraform security group acceptance test"
+}
+
+resource "flexibleengine_css_cluster_v1" "cluster" {
+  name = "terraform_test_cluster%s"
+  engine_version = "7.1.1"
+  node_number    = 2
+
+  node_config {
+    flavor = "ess.spec-4u32g"
+    network_info {
+      security_group_id = flexibleengine_networking_secgroup_v2.secgroup.id
+      subnet_id = "%s"
+      vpc_id = "%s"
+    }
+    volume {
+      volume_type = "COMMON"
+      size = 60
+    }
+    availability_zone = "%s"
+  }
+  tags = {
+    foo = "bar"
+    key = "value"
+  }
+}
+	`, val, val, OS_NETWORK_ID, OS_VPC_ID, OS_AVAILABILITY_ZONE)
+}
+
+func testAccCssClusterV1_roles(val string, masterNum, clientNum, bandwidth int, whitelist bool) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_networking_secgroup_v2" "secgroup" {
+  name = "terraform_test_security_group%s"
+  description = "terraform security group acceptance test"
+}
+
+resource "flexibleengine_css_cluster_v1" "cluster" {
+  name = "terraform_test_cluster%s"
+  engine_version = "7.6.2"
+  node_number    = 1
+  security_mode  = true
+  password       = "Test@passw0rd"
+
+  node_config {
+    flavor = "ess.spec-4u16g"
+    network_info {
+      security_group_id = flexibleengine_networking_secgroup_v2.secgroup.id
+      subnet_id = "%s"
+      vpc_id = "%s"
+    }
+    volume {
+      volume_type = "COMMON"
+      size = 40
+    }
+    availability_zone = "%s"
+  }
+
+  master_node_config {
+    flavor          = "ess.spec-4u16g"
+    instance_number = %d
+    volume {
+      volume_type = "COMMON"
+      size        = 40
+    }
+  }
+
+  client_node_config {
+    flavor          = "ess.spec-4u16g"
+    instance_number = %d
+    volume {
+      volume_type = "COMMON"
+      size        = 40
+    }
+  }
+
+  public_access {
+    bandwidth         = %d
+    whitelist_enabled = %t
+    whitelist         = "121.10.10.0/24"
+  }
+
+  kibana_public_access {
+    bandwidth         = %d
+    whitelist_enabled = %t
+    whitelist         = "121.10.10.0/24"
+  }
+}
+	`, val, val, OS_NETWORK_ID, OS_VPC_ID, OS_AVAILABILITY_ZONE, masterNum, clientNum,
+		bandwidth, whitelist, bandwidth, whitelist)
+}
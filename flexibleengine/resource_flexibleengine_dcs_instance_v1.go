@@ -1,8 +1,10 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/dcs/v1/instances"
 	"github.com/chnsz/golangsdk/openstack/dcs/v1/products"
+	"github.com/chnsz/golangsdk/openstack/dcs/v2/whitelists"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,9 +30,12 @@ func resourceDcsInstanceV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 
+		CustomizeDiff: resourceDcsInstancesV1CustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -57,13 +63,11 @@ func resourceDcsInstanceV1() *schema.Resource {
 			"capacity": {
 				Type:     schema.TypeFloat,
 				Required: true,
-				ForceNew: true,
 			},
 			"password": {
 				Type:      schema.TypeString,
 				Sensitive: true,
 				Required:  true,
-				ForceNew:  true,
 			},
 			"access_user": {
 				Type:     schema.TypeString,
@@ -117,35 +121,55 @@ func resourceDcsInstanceV1() *schema.Resource {
 			"save_days": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 			},
 			"backup_type": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"begin_at": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"period_type": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"backup_at": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeInt},
 			},
+			"whitelist_enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"whitelists": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				MaxItems:      4,
+				ConflictsWith: []string{"security_group_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 			"port": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				ForceNew: true,
 				Computed: true,
 			},
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 			"vpc_name": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -209,6 +233,88 @@ func resourceDcsInstancesCheck(d *schema.ResourceData) error {
 	return nil
 }
 
+// resourceDcsInstancesV1CustomizeDiff refuses the capacity changes rejected by the extend API during the plan
+func resourceDcsInstancesV1CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("capacity") || !d.NewValueKnown("capacity") {
+		return nil
+	}
+
+	o, n := d.GetChange("capacity")
+	if n.(float64) < o.(float64) {
+		return fmt.Errorf("capacity can only be increased, the current value is %v", o)
+	}
+	// the extend API only supports the specifications in integral GB
+	if n.(float64) != math.Trunc(n.(float64)) {
+		return fmt.Errorf("capacity can only be extended to an integer, got %v", n)
+	}
+	return nil
+}
+
+// dcsWhitelistSupported returns true if the instance uses whitelists instead of security groups,
+// which is only available for Redis 4.0 and later.
+func dcsWhitelistSupported(d *schema.ResourceData) bool {
+	engine := strings.ToLower(d.Get("engine").(string))
+	return engine == "redis" && d.Get("engine_version").(string) != "3.0"
+}
+
+// buildDcsWhitelistOpts enables the whitelists unless whitelist_enable is set to false, the whitelists
+// are always disabled without any group, otherwise no client can access the instance.
+func buildDcsWhitelistOpts(d *schema.ResourceData) whitelists.WhitelistOpts {
+	groupList := d.Get("whitelists").(*schema.Set).List()
+	enable := len(groupList) > 0
+	if enable && isParamConfigured(d, "whitelist_enable") {
+		enable = d.Get("whitelist_enable").(bool)
+	}
+
+	groups := make([]whitelists.WhitelistGroupOpts, len(groupList))
+	for i, v := range groupList {
+		item := v.(map[string]interface{})
+		groups[i] = whitelists.WhitelistGroupOpts{
+			GroupName: item["group_name"].(string),
+			IPList:    expandStringList(item["ip_address"].([]interface{})),
+		}
+	}
+
+	return whitelists.WhitelistOpts{
+		Enable: &enable,
+		Groups: groups,
+	}
+}
+
+func updateDcsWhitelists(d *schema.ResourceData, config *Config, timeout time.Duration) error {
+	dcsV2Client, err := config.DcsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine dcs v2 client: %s", err)
+	}
+
+	whitelistOpts := buildDcsWhitelistOpts(d)
+	log.Printf("[DEBUG] Whitelist Options: %#v", whitelistOpts)
+	err = whitelists.Put(dcsV2Client, d.Id(), whitelistOpts).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error updating whitelists of DCS instance %s: %s", d.Id(), err)
+	}
+
+	enable := strconv.FormatBool(*whitelistOpts.Enable)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{strconv.FormatBool(!*whitelistOpts.Enable)},
+		Target:  []string{enable},
+		Refresh: func() (interface{}, string, error) {
+			r, err := whitelists.Get(dcsV2Client, d.Id()).Extract()
+			if err != nil {
+				return nil, "", err
+			}
+			return r, strconv.FormatBool(r.Enable), nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err = stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for whitelists of DCS instance %s to be updated: %s", d.Id(), err)
+	}
+	return nil
+}
+
 func getInstanceBackupPolicy(d *schema.ResourceData) *instances.InstanceBackupPolicy {
 	backupAts := d.Get("backup_at").([]interface{})
 	ats := make([]int, len(backupAts))
@@ -285,6 +391,8 @@ func resourceDcsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 		MaintainBegin:    d.Get("maintain_begin").(string),
 		MaintainEnd:      d.Get("maintain_end").(string),
 		Port:             d.Get("port").(int),
+
+		EnterpriseProjectID: config.GetEnterpriseProjectID(d),
 	}
 
 	product_id, product_ok := d.GetOk("product_id")
@@ -331,6 +439,19 @@ func resourceDcsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 	// Store the instance ID now
 	d.SetId(v.InstanceID)
 
+	// the whitelists can only be configured when the instance is running
+	if dcsWhitelistSupported(d) && d.Get("whitelists").(*schema.Set).Len() > 0 {
+		if err := updateDcsWhitelists(d, config, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "dcs", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceDcsInstancesV1Read(d, meta)
 }
 
@@ -370,6 +491,7 @@ func resourceDcsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	d.Set("vpc_name", v.VPCName)
 	d.Set("product_id", v.ProductID)
 	d.Set("security_group_id", v.SecurityGroupID)
+	d.Set("enterprise_project_id", v.EnterpriseProjectID)
 	d.Set("security_group_name", v.SecurityGroupName)
 	d.Set("subnet_name", v.SubnetName)
 	d.Set("maintain_begin", v.MaintainBegin)
@@ -387,9 +509,41 @@ func resourceDcsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	}
 	d.Set("capacity", capacity)
 
+	if dcsWhitelistSupported(d) {
+		if err := setDcsWhitelists(d, config); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func setDcsWhitelists(d *schema.ResourceData, config *Config) error {
+	dcsV2Client, err := config.DcsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine dcs v2 client: %s", err)
+	}
+
+	wList, err := whitelists.Get(dcsV2Client, d.Id()).Extract()
+	if err != nil {
+		// the whitelists may be unavailable in some cases, keep the configured values
+		log.Printf("[WARN] Error fetching whitelists of DCS instance %s: %s", d.Id(), err)
+		return nil
+	}
+
+	groups := make([]map[string]interface{}, len(wList.Groups))
+	for i, group := range wList.Groups {
+		groups[i] = map[string]interface{}{
+			"group_name": group.GroupName,
+			"ip_address": group.IPList,
+		}
+	}
+	if err := d.Set("whitelists", groups); err != nil {
+		return fmt.Errorf("Error saving whitelists of DCS instance %s: %s", d.Id(), err)
+	}
+	return d.Set("whitelist_enable", wList.Enable)
+}
+
 func resourceDcsInstancesV1Update(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	dcsV1Client, err := config.DcsV1Client(GetRegion(d, config))
@@ -421,15 +575,79 @@ func resourceDcsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 		security_group_id := d.Get("security_group_id").(string)
 		updateOpts.SecurityGroupID = security_group_id
 	}
+	if d.HasChanges("save_days", "backup_type", "begin_at", "period_type", "backup_at") {
+		updateOpts.InstanceBackupPolicy = getInstanceBackupPolicy(d)
+	}
 
-	err = instances.Update(dcsV1Client, d.Id(), updateOpts).Err
-	if err != nil {
-		return fmt.Errorf("Error updating FlexibleEngine Dcs Instance: %s", err)
+	if d.HasChanges("name", "description", "maintain_begin", "maintain_end", "security_group_id",
+		"save_days", "backup_type", "begin_at", "period_type", "backup_at") {
+		err = instances.Update(dcsV1Client, d.Id(), updateOpts).Err
+		if err != nil {
+			return fmt.Errorf("Error updating FlexibleEngine Dcs Instance: %s", err)
+		}
+	}
+
+	if d.HasChange("password") {
+		oldPassword, newPassword := d.GetChange("password")
+		passwordOpts := instances.UpdatePasswordOpts{
+			OldPassword: oldPassword.(string),
+			NewPassword: newPassword.(string),
+		}
+		result, err := instances.UpdatePassword(dcsV1Client, d.Id(), passwordOpts).Extract()
+		if err == nil && result.Result != "Success" {
+			err = fmt.Errorf("%s, %s", result.Result, result.Message)
+		}
+		if err != nil {
+			// keep the old password in the state, otherwise the failed change is not planned again
+			if setErr := d.Set("password", oldPassword); setErr != nil {
+				log.Printf("[WARN] Error restoring the password of DCS instance %s: %s", d.Id(), setErr)
+			}
+			return fmt.Errorf("Error changing the password of DCS instance %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("capacity") {
+		if err := extendDcsInstance(d, dcsV1Client); err != nil {
+			return err
+		}
+	}
+
+	// the whitelist API is only called for the configured whitelists, or to disable the removed ones
+	oldWhitelists, newWhitelists := d.GetChange("whitelists")
+	if d.HasChanges("whitelists", "whitelist_enable") && dcsWhitelistSupported(d) &&
+		(newWhitelists.(*schema.Set).Len() > 0 || oldWhitelists.(*schema.Set).Len() > 0) {
+		if err := updateDcsWhitelists(d, config, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
 	}
 
 	return resourceDcsInstancesV1Read(d, meta)
 }
 
+func extendDcsInstance(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	extendOpts := instances.ExtendOpts{
+		NewCapacity: int(d.Get("capacity").(float64)),
+	}
+	log.Printf("[DEBUG] Extend Options: %#v", extendOpts)
+	err := instances.Extend(client, d.Id(), extendOpts).Err
+	if err != nil {
+		return fmt.Errorf("Error extending DCS instance %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"EXTENDING"},
+		Target:     []string{"RUNNING"},
+		Refresh:    DcsInstancesV1StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err = stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to be extended: %s", d.Id(), err)
+	}
+	return nil
+}
+
 func resourceDcsInstancesV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	dcsV1Client, err := config.DcsV1Client(GetRegion(d, config))
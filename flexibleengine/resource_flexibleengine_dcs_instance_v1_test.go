@@ -1,16 +1,83 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/chnsz/golangsdk/openstack/dcs/v1/instances"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
 
+func TestDcsInstanceV1CapacityDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "instance",
+		Attributes: map[string]string{
+			"id":                "instance",
+			"name":              "dcs",
+			"engine":            "Redis",
+			"engine_version":    "5.0",
+			"capacity":          "2",
+			"password":          "Password@123",
+			"vpc_id":            "vpc-id",
+			"network_id":        "subnet-id",
+			"available_zones.#": "1",
+			"available_zones.0": "eu-west-0a",
+		},
+	}
+
+	cases := []struct {
+		capacity float64
+		valid    bool
+	}{
+		{4, true},
+		{1, false},
+		{4.5, false},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":            "dcs",
+			"engine":          "Redis",
+			"engine_version":  "5.0",
+			"capacity":        tc.capacity,
+			"password":        "Password@123",
+			"vpc_id":          "vpc-id",
+			"network_id":      "subnet-id",
+			"available_zones": []interface{}{"eu-west-0a"},
+		})
+		_, err := resourceDcsInstanceV1().Diff(context.Background(), state, config, nil)
+		if (err == nil) != tc.valid {
+			t.Fatalf("capacity %v: expected the change to be valid: %t, got error: %v", tc.capacity, tc.valid, err)
+		}
+	}
+}
+
+func TestBuildDcsWhitelistOpts(t *testing.T) {
+	group := map[string]interface{}{"group_name": "test", "ip_address": []interface{}{"192.168.0.0/24"}}
+	cases := []struct {
+		name   string
+		raw    map[string]interface{}
+		enable bool
+		groups int
+	}{
+		{"groups", map[string]interface{}{"whitelists": []interface{}{group}}, true, 1},
+		{"no group", map[string]interface{}{"whitelist_enable": true}, false, 0},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceDcsInstanceV1().Schema, tc.raw)
+		opts := buildDcsWhitelistOpts(d)
+		if *opts.Enable != tc.enable || len(opts.Groups) != tc.groups {
+			t.Fatalf("%s: unexpected whitelist options: enable %t, %d groups", tc.name, *opts.Enable, len(opts.Groups))
+		}
+	}
+}
+
 func TestAccDcsInstancesV1_basic(t *testing.T) {
 	var instance instances.Instance
 	var randName = fmt.Sprintf("acc_test_%s", acctest.RandString(5))
@@ -27,6 +94,17 @@ func TestAccDcsInstancesV1_basic(t *testing.T) {
 					testAccCheckDcsV1InstanceExists(resourceName, instance),
 					resource.TestCheckResourceAttr(resourceName, "name", randName),
 					resource.TestCheckResourceAttr(resourceName, "engine", "Redis"),
+					resource.TestCheckResourceAttr(resourceName, "capacity", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccDcsV1Instance_update(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDcsV1InstanceExists(resourceName, instance),
+					resource.TestCheckResourceAttr(resourceName, "capacity", "4"),
+					resource.TestCheckResourceAttr(resourceName, "save_days", "2"),
+					resource.TestCheckResourceAttr(resourceName, "backup_at.#", "2"),
 					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
 				),
 			},
@@ -60,6 +138,16 @@ func TestAccDcsInstancesV1_redisV5(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "engine", "Redis"),
 					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "port", "9999"),
+					resource.TestCheckResourceAttr(resourceName, "whitelist_enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "whitelists.#", "1"),
+				),
+			},
+			{
+				Config: testAccDcsV1Instance_redisV5Whitelists(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDcsV1InstanceExists(resourceName, instance),
+					resource.TestCheckResourceAttr(resourceName, "whitelist_enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "whitelists.#", "2"),
 				),
 			},
 			{
@@ -201,6 +289,31 @@ resource "flexibleengine_dcs_instance_v1" "instance_1" {
 `, testAccDcsV1Instance_network(rName), rName)
 }
 
+func testAccDcsV1Instance_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_dcs_instance_v1" "instance_1" {
+  name              = "%s"
+  engine            = "Redis"
+  engine_version    = "3.0"
+  password          = "Huawei_test_update"
+  product_id        = "dcs.master_standby-h"
+  capacity          = 4
+  vpc_id            = flexibleengine_vpc_v1.vpc_1.id
+  network_id        = flexibleengine_vpc_subnet_v1.subnet_1.id
+  security_group_id = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  available_zones   = ["eu-west-0a"]
+
+  save_days   = 2
+  backup_type = "manual"
+  begin_at    = "01:00-02:00"
+  period_type = "weekly"
+  backup_at   = [1, 2]
+}
+`, testAccDcsV1Instance_network(rName), rName)
+}
+
 func testAccDcsV1Instance_redisV5(rName string) string {
 	return fmt.Sprintf(`
 data "flexibleengine_dcs_product_v1" "product_ha" {
@@ -230,6 +343,53 @@ resource "flexibleengine_dcs_instance_v1" "instance_1" {
   begin_at    = "00:00-01:00"
   period_type = "weekly"
   backup_at   = [1]
+
+  whitelists {
+    group_name = "test-group1"
+    ip_address = ["192.168.10.100", "192.168.0.0/24"]
+  }
+}
+`, testAccDcsV1Instance_network(rName), rName)
+}
+
+func testAccDcsV1Instance_redisV5Whitelists(rName string) string {
+	return fmt.Sprintf(`
+data "flexibleengine_dcs_product_v1" "product_ha" {
+  engine         = "Redis"
+  engine_version = "4.0;5.0"
+  cache_mode     = "ha"
+  capacity       = 0.5
+  replica_count  = 2
+}
+
+%s
+
+resource "flexibleengine_dcs_instance_v1" "instance_1" {
+  name            = "%s"
+  engine          = "Redis"
+  engine_version  = "5.0"
+  password        = "Huawei_test"
+  product_id      = data.flexibleengine_dcs_product_v1.product_ha.id
+  capacity        = 0.5
+  vpc_id          = flexibleengine_vpc_v1.vpc_1.id
+  network_id      = flexibleengine_vpc_subnet_v1.subnet_1.id
+  available_zones = ["eu-west-0a", "eu-west-0c"]
+  port            = "9999"
+
+  save_days   = 1
+  backup_type = "manual"
+  begin_at    = "00:00-01:00"
+  period_type = "weekly"
+  backup_at   = [1]
+
+  whitelists {
+    group_name = "test-group1"
+    ip_address = ["192.168.10.100", "192.168.0.0/24"]
+  }
+  whitelists {
+    group_name = "test-group2"
+    ip_address = ["172.16.10.100", "172.16.0.0/24"]
+  }
 }
 `, testAccDcsV1Instance_network(rName), rName)
 }
@@ -1,15 +1,20 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/dds/v3/instances"
+	"github.com/chnsz/golangsdk/openstack/dds/v3/jobs"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,9 +26,18 @@ func resourceDdsInstanceV3() *schema.Resource {
 		Read:   resourceDdsInstanceV3Read,
 		Update: resourceDdsInstanceV3Update,
 		Delete: resourceDdsInstanceV3Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: customdiff.All(
+			resourceDdsInstanceV3CustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
@@ -91,7 +105,6 @@ func resourceDdsInstanceV3() *schema.Resource {
 				Type:      schema.TypeString,
 				Sensitive: true,
 				Required:  true,
-				ForceNew:  true,
 			},
 			"disk_encryption_id": {
 				Type:      schema.TypeString,
@@ -110,7 +123,6 @@ func resourceDdsInstanceV3() *schema.Resource {
 			"flavor": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -124,7 +136,6 @@ func resourceDdsInstanceV3() *schema.Resource {
 						"num": {
 							Type:     schema.TypeInt,
 							Required: true,
-							ForceNew: true,
 						},
 						"storage": {
 							Type:     schema.TypeString,
@@ -134,12 +145,29 @@ func resourceDdsInstanceV3() *schema.Resource {
 						"size": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true,
 						},
 						"spec_code": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
+						},
+					},
+				},
+			},
+			"configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"mongos", "shard", "config", "replica",
+							}, false),
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
 						},
 					},
 				},
@@ -147,7 +175,6 @@ func resourceDdsInstanceV3() *schema.Resource {
 			"backup_strategy": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
@@ -160,6 +187,13 @@ func resourceDdsInstanceV3() *schema.Resource {
 							Type:     schema.TypeInt,
 							Required: true,
 						},
+						"period": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-7](,[1-7])*$`),
+								"the period must be the days of week from 1 to 7 separated by commas"),
+						},
 					},
 				},
 			},
@@ -167,9 +201,10 @@ func resourceDdsInstanceV3() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
-				ForceNew: true,
 			},
-			"tags": tagsSchema(),
+			"tags":                  tagsSchema(),
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 			"db_username": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -254,6 +289,19 @@ func resourceDdsFlavors(d *schema.ResourceData) []instances.Flavor {
 	return flavors
 }
 
+func resourceDdsConfigurations(d *schema.ResourceData) []instances.Configuration {
+	var configurations []instances.Configuration
+	for _, raw := range d.Get("configuration").([]interface{}) {
+		configuration := raw.(map[string]interface{})
+		configurations = append(configurations, instances.Configuration{
+			Type: configuration["type"].(string),
+			Id:   configuration["id"].(string),
+		})
+	}
+	log.Printf("[DEBUG] configurations: %+v", configurations)
+	return configurations
+}
+
 func resourceDdsBackupStrategy(d *schema.ResourceData) instances.BackupStrategy {
 	var backupStrategy instances.BackupStrategy
 	backupStrategyRaw := d.Get("backup_strategy").([]interface{})
@@ -263,6 +311,8 @@ func resourceDdsBackupStrategy(d *schema.ResourceData) instances.BackupStrategy
 	if len(backupStrategyRaw) == 1 {
 		startTime = backupStrategyRaw[0].(map[string]interface{})["start_time"].(string)
 		keepDays = backupStrategyRaw[0].(map[string]interface{})["keep_days"].(int)
+		// the period is the current one of the instance if it is not specified
+		backupStrategy.Period = backupStrategyRaw[0].(map[string]interface{})["period"].(string)
 	}
 	backupStrategy.StartTime = startTime
 	backupStrategy.KeepDays = &keepDays
@@ -313,7 +363,10 @@ func resourceDdsInstanceV3Create(d *schema.ResourceData, meta interface{}) error
 		DiskEncryptionId: d.Get("disk_encryption_id").(string),
 		Mode:             d.Get("mode").(string),
 		Flavor:           resourceDdsFlavors(d),
+		Configuration:    resourceDdsConfigurations(d),
 		BackupStrategy:   resourceDdsBackupStrategy(d),
+
+		EnterpriseProjectID: config.GetEnterpriseProjectID(d),
 	}
 	if d.Get("ssl").(bool) {
 		createOpts.Ssl = "1"
@@ -346,7 +399,7 @@ func resourceDdsInstanceV3Create(d *schema.ResourceData, meta interface{}) error
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "instances", instance.Id, taglist).ExtractErr(); tagErr != nil {
@@ -402,6 +455,7 @@ func resourceDdsInstanceV3Read(d *schema.ResourceData, meta interface{}) error {
 		d.Set("db_username", instance.DbUserName),
 		d.Set("status", instance.Status),
 		d.Set("ssl", sslEnable),
+		d.Set("enterprise_project_id", instance.EnterpriseProjectID),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
 		return err
@@ -427,20 +481,38 @@ func resourceDdsInstanceV3Read(d *schema.ResourceData, meta interface{}) error {
 	backupStrategy := map[string]interface{}{
 		"start_time": instance.BackupStrategy.StartTime,
 		"keep_days":  instance.BackupStrategy.KeepDays,
+		"period":     instance.BackupStrategy.Period,
 	}
 	backupStrategyList = append(backupStrategyList, backupStrategy)
 	d.Set("backup_strategy", backupStrategyList)
 
+	// the availability_zone may contain several zones separated by commas, keep the configured value
+	if _, ok := d.GetOk("availability_zone"); !ok {
+		d.Set("availability_zone", getDdsInstanceV3AvailabilityZone(instance))
+	}
+
+	if err := d.Set("flavor", flattenDdsInstanceV3Flavor(d, instance)); err != nil {
+		return fmt.Errorf("Error setting flavor of DDS instance, err: %s", err)
+	}
+
 	// save nodes attribute
 	err = d.Set("nodes", flattenDdsInstanceV3Nodes(instance))
 	if err != nil {
 		return fmt.Errorf("Error setting nodes of DDS instance, err: %s", err)
 	}
 
+	configurations, err := flattenDdsInstanceV3Configurations(client, d)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("configuration", configurations); err != nil {
+		return fmt.Errorf("Error setting configuration of DDS instance, err: %s", err)
+	}
+
 	// save tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
 		tagmap := tagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := setResourceTags(d, config, tagmap); err != nil {
 			return fmt.Errorf("Error saving tags to state for DDS instance (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -457,16 +529,413 @@ func resourceDdsInstanceV3Update(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating FlexibleEngine DDS client: %s ", err)
 	}
 
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(client, d, "instances", d.Id())
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.HasChange("ssl") {
+		opt := instances.UpdateOpt{
+			Param:  "ssl_option",
+			Value:  "0",
+			Action: "switch-ssl",
+			Method: "post",
+		}
+		if d.Get("ssl").(bool) {
+			opt.Value = "1"
+		}
+		if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("password") {
+		opt := instances.UpdateOpt{
+			Param:  "user_pwd",
+			Value:  d.Get("password").(string),
+			Action: "reset-password",
+			Method: "put",
+		}
+		if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("backup_strategy") {
+		backupStrategy := resourceDdsBackupStrategy(d)
+		if backupStrategy.Period == "" {
+			backupStrategy.Period = "1,2,3,4,5,6,7"
+		}
+		opt := instances.UpdateOpt{
+			Param:  "backup_policy",
+			Value:  backupStrategy,
+			Action: "backups/policy",
+			Method: "put",
+		}
+		if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("flavor") {
+		if err := updateDdsInstanceV3Flavors(client, d, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("configuration") {
+		if err := applyDdsInstanceV3Configurations(client, d, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(client, d, config, "instances", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of DDS instance:%s, err:%s", d.Id(), tagErr)
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "dds", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceDdsInstanceV3Read(d, meta)
 }
 
+func resourceDdsInstanceV3CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("flavor") {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange("flavor")
+	oldFlavors, newFlavors := oldRaw.([]interface{}), newRaw.([]interface{})
+	// adding or removing a flavor can not be done in place
+	if len(oldFlavors) != len(newFlavors) {
+		return d.ForceNew("flavor")
+	}
+
+	for i := range newFlavors {
+		oldFlavor := oldFlavors[i].(map[string]interface{})
+		newFlavor := newFlavors[i].(map[string]interface{})
+		flavorType := strings.ToLower(newFlavor["type"].(string))
+		if flavorType != strings.ToLower(oldFlavor["type"].(string)) {
+			return d.ForceNew("flavor")
+		}
+
+		oldNum, newNum := oldFlavor["num"].(int), newFlavor["num"].(int)
+		if newNum != oldNum {
+			if flavorType != "mongos" && flavorType != "shard" {
+				return fmt.Errorf("the num of %s can not be changed", flavorType)
+			}
+			if newNum < oldNum {
+				return fmt.Errorf("the num of %s can only be increased, the current value is %d", flavorType, oldNum)
+			}
+		}
+
+		oldSize, newSize := oldFlavor["size"].(int), newFlavor["size"].(int)
+		if newSize != oldSize {
+			if flavorType != "replica" && flavorType != "shard" {
+				return fmt.Errorf("the size of %s can not be changed", flavorType)
+			}
+			if newSize < oldSize {
+				return fmt.Errorf("the size of %s can only be increased, the current value is %d", flavorType, oldSize)
+			}
+		}
+
+		if newFlavor["spec_code"].(string) != oldFlavor["spec_code"].(string) && flavorType == "config" {
+			return fmt.Errorf("the spec_code of config can not be changed")
+		}
+	}
+	return nil
+}
+
+// updateDdsInstanceV3 sends an update request and waits for the job and the instance to be completed
+func updateDdsInstanceV3(client *golangsdk.ServiceClient, instanceID string, opt instances.UpdateOpt,
+	timeout time.Duration) error {
+	log.Printf("[DEBUG] Updating %s of DDS instance %s", opt.Action, instanceID)
+	resp, err := instances.Update(client, instanceID, []instances.UpdateOpt{opt}).Extract()
+	if err != nil {
+		return fmt.Errorf("Error updating %s of DDS instance %s: %s", opt.Action, instanceID, err)
+	}
+
+	return waitForDdsInstanceV3Job(client, instanceID, resp.JobId, timeout)
+}
+
+func waitForDdsInstanceV3Job(client *golangsdk.ServiceClient, instanceID, jobID string, timeout time.Duration) error {
+	if jobID != "" {
+		stateConf := &resource.StateChangeConf{
+			Pending:      []string{"Running"},
+			Target:       []string{"Completed"},
+			Refresh:      ddsJobStateRefreshFunc(client, jobID),
+			Timeout:      timeout,
+			Delay:        10 * time.Second,
+			PollInterval: 10 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for the job (%s) of DDS instance %s to complete: %s", jobID, instanceID, err)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"updating"},
+		Target:     []string{"normal"},
+		Refresh:    DdsInstanceStateRefreshFunc(client, instanceID),
+		Timeout:    timeout,
+		Delay:      15 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to become ready: %s ", instanceID, err)
+	}
+	return nil
+}
+
+func ddsJobStateRefreshFunc(client *golangsdk.ServiceClient, jobID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		job, err := jobs.Get(client, jobID)
+		if err != nil {
+			return nil, "", err
+		}
+		if job.Status == "Failed" {
+			return job, job.Status, fmt.Errorf("the job failed: %s", job.FailReason)
+		}
+		return job, job.Status, nil
+	}
+}
+
+func getDdsInstanceV3ByID(client *golangsdk.ServiceClient, instanceID string) (*instances.InstanceResponse, error) {
+	opts := instances.ListInstanceOpts{
+		Id: instanceID,
+	}
+	allPages, err := instances.List(client, &opts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching DDS instance: %s", err)
+	}
+	instanceList, err := instances.ExtractInstances(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting DDS instance: %s", err)
+	}
+	if instanceList.TotalCount == 0 {
+		return nil, fmt.Errorf("DDS instance %s was not found", instanceID)
+	}
+	return &instanceList.Instances[0], nil
+}
+
+// getDdsInstanceV3EntityIDs returns the IDs of the entities to which a flavor type applies:
+// the node IDs for mongos, the group IDs for shard and config and the instance ID for replica
+func getDdsInstanceV3EntityIDs(client *golangsdk.ServiceClient, instanceID, flavorType string) ([]string, error) {
+	if flavorType == "replica" {
+		return []string{instanceID}, nil
+	}
+
+	instance, err := getDdsInstanceV3ByID(client, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for _, group := range instance.Groups {
+		if group.Type != flavorType {
+			continue
+		}
+		if flavorType == "mongos" {
+			for _, node := range group.Nodes {
+				ids = append(ids, node.Id)
+			}
+		} else {
+			ids = append(ids, group.Id)
+		}
+	}
+	return ids, nil
+}
+
+// updateDdsInstanceV3Flavors expands the storage, adds the nodes and changes the specification in turn,
+// the storage must be expanded ahead of adding nodes, otherwise the new shards are created with the new size
+// and the expansion of them fails
+func updateDdsInstanceV3Flavors(client *golangsdk.ServiceClient, d *schema.ResourceData, timeout time.Duration) error {
+	for i, raw := range d.Get("flavor").([]interface{}) {
+		flavor := raw.(map[string]interface{})
+		flavorType := strings.ToLower(flavor["type"].(string))
+		specCode := flavor["spec_code"].(string)
+		size := flavor["size"].(int)
+
+		if d.HasChange(fmt.Sprintf("flavor.%d.size", i)) {
+			var groupIDs []string
+			if flavorType == "shard" {
+				ids, err := getDdsInstanceV3EntityIDs(client, d.Id(), flavorType)
+				if err != nil {
+					return err
+				}
+				groupIDs = ids
+			} else {
+				// the replica set has no group ID
+				groupIDs = []string{""}
+			}
+
+			for _, groupID := range groupIDs {
+				opt := instances.UpdateOpt{
+					Value: instances.UpdateVolumeOpts{
+						Volume: instances.VolumeOpts{
+							GroupID: groupID,
+							Size:    &size,
+						},
+					},
+					Action: "enlarge-volume",
+					Method: "post",
+				}
+				if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+					return err
+				}
+			}
+		}
+
+		if d.HasChange(fmt.Sprintf("flavor.%d.num", i)) {
+			oldNum, newNum := d.GetChange(fmt.Sprintf("flavor.%d.num", i))
+			nodeNumOpts := instances.UpdateNodeNumOpts{
+				Type:     flavorType,
+				SpecCode: specCode,
+				Num:      newNum.(int) - oldNum.(int),
+			}
+			if flavorType == "shard" {
+				nodeNumOpts.Volume = &instances.VolumeOpts{
+					Size: &size,
+				}
+			}
+			opt := instances.UpdateOpt{
+				Value:  nodeNumOpts,
+				Action: "enlarge",
+				Method: "post",
+			}
+			if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange(fmt.Sprintf("flavor.%d.spec_code", i)) {
+			entityIDs, err := getDdsInstanceV3EntityIDs(client, d.Id(), flavorType)
+			if err != nil {
+				return err
+			}
+
+			for _, entityID := range entityIDs {
+				specOpts := instances.SpecOpts{
+					TargetID:       entityID,
+					TargetSpecCode: specCode,
+				}
+				if flavorType != "replica" {
+					specOpts.TargetType = flavorType
+				}
+				opt := instances.UpdateOpt{
+					Value: instances.UpdateSpecOpts{
+						Resize: specOpts,
+					},
+					Action: "resize",
+					Method: "post",
+				}
+				if err := updateDdsInstanceV3(client, d.Id(), opt, timeout); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applyDdsInstanceV3Configurations applies the changed parameter templates to the entities of the instance
+func applyDdsInstanceV3Configurations(client *golangsdk.ServiceClient, d *schema.ResourceData,
+	timeout time.Duration) error {
+	oldRaw, _ := d.GetChange("configuration")
+	applied := make(map[string]string)
+	for _, raw := range oldRaw.([]interface{}) {
+		configuration := raw.(map[string]interface{})
+		applied[configuration["type"].(string)] = configuration["id"].(string)
+	}
+
+	for _, configuration := range resourceDdsConfigurations(d) {
+		if applied[configuration.Type] == configuration.Id {
+			continue
+		}
+
+		entityIDs, err := getDdsInstanceV3EntityIDs(client, d.Id(), configuration.Type)
+		if err != nil {
+			return err
+		}
+
+		url := client.ServiceURL("configurations", configuration.Id, "apply")
+		params := map[string]interface{}{
+			"entity_ids": entityIDs,
+		}
+		log.Printf("[DEBUG] Applying the parameter template %s to DDS instance %s: %#v",
+			configuration.Id, d.Id(), params)
+
+		r := golangsdk.Result{}
+		_, r.Err = client.Put(url, params, &r.Body, &golangsdk.RequestOpts{
+			OkCodes: successHTTPCodes,
+		})
+		if r.Err != nil {
+			return fmt.Errorf("Error applying the parameter template %s to DDS instance %s: %s",
+				configuration.Id, d.Id(), r.Err)
+		}
+
+		var resp instances.UpdateResp
+		if err := r.ExtractInto(&resp); err != nil {
+			return fmt.Errorf("Error extracting the job of applying the parameter template: %s", err)
+		}
+		if err := waitForDdsInstanceV3Job(client, d.Id(), resp.JobId, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenDdsInstanceV3Configurations reads back the parameter templates applied to the instance. The instance
+// does not report its templates, so the application records of each template in the state are checked: the
+// template is kept unless the latest application to the instance failed or the template has been deleted.
+// The templates specified during the creation have no application record.
+func flattenDdsInstanceV3Configurations(client *golangsdk.ServiceClient,
+	d *schema.ResourceData) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0)
+	for _, configuration := range resourceDdsConfigurations(d) {
+		var rst struct {
+			Histories []struct {
+				InstanceID    string `json:"instance_id"`
+				AppliedAt     string `json:"applied_at"`
+				ApplyResult   string `json:"apply_result"`
+				FailureReason string `json:"failure_reason"`
+			} `json:"histories"`
+		}
+		url := client.ServiceURL("configurations", configuration.Id, "applied-histories")
+		_, err := client.Get(url, &rst, nil)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				log.Printf("[WARN] The parameter template %s of DDS instance %s has been deleted",
+					configuration.Id, d.Id())
+				continue
+			}
+			return nil, fmt.Errorf("Error fetching the application records of parameter template %s: %s",
+				configuration.Id, err)
+		}
+
+		var latestAt, latestResult, reason string
+		for _, history := range rst.Histories {
+			// the time in the format of "yyyy-MM-ddTHH:mm:ssZ" can be compared as a string
+			if history.InstanceID == d.Id() && history.AppliedAt > latestAt {
+				latestAt, latestResult, reason = history.AppliedAt, history.ApplyResult, history.FailureReason
+			}
+		}
+		if latestResult == "FAILED" {
+			log.Printf("[WARN] Failed to apply the parameter template %s to DDS instance %s: %s",
+				configuration.Id, d.Id(), reason)
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"type": configuration.Type,
+			"id":   configuration.Id,
+		})
+	}
+	return result, nil
+}
+
 func resourceDdsInstanceV3Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.DdsV3Client(GetRegion(d, config))
@@ -498,6 +967,77 @@ func resourceDdsInstanceV3Delete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+func getDdsInstanceV3AvailabilityZone(dds instances.InstanceResponse) string {
+	for _, group := range dds.Groups {
+		for _, node := range group.Nodes {
+			if node.AvailabilityZone != "" {
+				return node.AvailabilityZone
+			}
+		}
+	}
+	return ""
+}
+
+// flattenDdsInstanceV3Flavor rebuilds the flavor list from the groups of the instance.
+// The storage type is not returned by the API, so it is kept from the current state.
+func flattenDdsInstanceV3Flavor(d *schema.ResourceData, dds instances.InstanceResponse) []map[string]interface{} {
+	flavorTypes := []string{"mongos", "shard", "config"}
+	if dds.Mode == "ReplicaSet" {
+		flavorTypes = []string{"replica"}
+	}
+
+	storages := make(map[string]string)
+	if v, ok := d.GetOk("flavor"); ok {
+		flavorTypes = make([]string, 0)
+		for _, raw := range v.([]interface{}) {
+			flavor := raw.(map[string]interface{})
+			flavorType := strings.ToLower(flavor["type"].(string))
+			flavorTypes = append(flavorTypes, flavorType)
+			storages[flavorType] = flavor["storage"].(string)
+		}
+	}
+
+	flavors := make(map[string]map[string]interface{})
+	for _, group := range dds.Groups {
+		flavorType := group.Type
+		if dds.Mode == "ReplicaSet" {
+			flavorType = "replica"
+		}
+		if len(group.Nodes) == 0 {
+			continue
+		}
+
+		flavor, ok := flavors[flavorType]
+		if !ok {
+			flavor = map[string]interface{}{
+				"type":      flavorType,
+				"num":       0,
+				"storage":   storages[flavorType],
+				"spec_code": group.Nodes[0].SpecCode,
+			}
+			if size, err := strconv.Atoi(group.Volume.Size); err == nil {
+				flavor["size"] = size
+			}
+			flavors[flavorType] = flavor
+		}
+
+		// the num of mongos is the count of nodes, and the others are the count of groups
+		if flavorType == "mongos" {
+			flavor["num"] = flavor["num"].(int) + len(group.Nodes)
+		} else {
+			flavor["num"] = flavor["num"].(int) + 1
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(flavors))
+	for _, flavorType := range flavorTypes {
+		if flavor, ok := flavors[flavorType]; ok {
+			result = append(result, flavor)
+		}
+	}
+	return result
+}
+
 func flattenDdsInstanceV3Nodes(dds instances.InstanceResponse) interface{} {
 	nodesList := make([]map[string]interface{}, 0)
 	for _, group := range dds.Groups {
@@ -31,6 +31,30 @@ func TestAccDDSV3Instance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform"),
 				),
 			},
+			{
+				Config: testAccDDSInstanceV3Config_update(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDDSV3InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "ssl", "false"),
+					resource.TestCheckResourceAttr(resourceName, "flavor.0.num", "3"),
+					resource.TestCheckResourceAttr(resourceName, "flavor.1.size", "30"),
+					resource.TestCheckResourceAttr(resourceName, "flavor.1.spec_code", "dds.mongodb.s3.large.4.shard"),
+					resource.TestCheckResourceAttr(resourceName, "backup_strategy.0.start_time", "10:00-11:00"),
+					resource.TestCheckResourceAttr(resourceName, "backup_strategy.0.keep_days", "7"),
+					resource.TestCheckResourceAttrPair(resourceName, "configuration.0.id",
+						"flexibleengine_dds_parameter_template.mongos", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"password",
+					"flavor",
+					"configuration",
+				},
+			},
 		},
 	})
 }
@@ -153,3 +177,69 @@ resource "flexibleengine_dds_instance_v3" "instance" {
   }
 }`, name, OS_REGION_NAME, OS_AVAILABILITY_ZONE, OS_VPC_ID, OS_NETWORK_ID)
 }
+
+func testAccDDSInstanceV3Config_update(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_networking_secgroup_v2" "secgroup_1" {
+  name = "secgroup_dds"
+}
+
+resource "flexibleengine_dds_parameter_template" "mongos" {
+  name         = "%[1]s"
+  node_type    = "mongos"
+  node_version = "3.4"
+
+  parameter_values = {
+    connPoolMaxConnsPerHost = 500
+  }
+}
+
+resource "flexibleengine_dds_instance_v3" "instance" {
+  name              = "%[1]s"
+  region            = "%[2]s"
+  availability_zone = "%[3]s"
+  vpc_id            = "%[4]s"
+  subnet_id         = "%[5]s"
+  security_group_id = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  password          = "Terraform@12345"
+  mode              = "Sharding"
+  ssl               = false
+
+  datastore {
+    type           = "DDS-Community"
+    version        = "3.4"
+    storage_engine = "wiredTiger"
+  }
+  flavor {
+    type      = "mongos"
+    num       = 3
+    spec_code = "dds.mongodb.s3.medium.4.mongos"
+  }
+  flavor {
+    type      = "shard"
+    num       = 2
+    storage   = "ULTRAHIGH"
+    size      = 30
+    spec_code = "dds.mongodb.s3.large.4.shard"
+  }
+  flavor {
+    type      = "config"
+    num       = 1
+    storage   = "ULTRAHIGH"
+    size      = 20
+    spec_code = "dds.mongodb.s3.large.2.config"
+  }
+  configuration {
+    type = "mongos"
+    id   = flexibleengine_dds_parameter_template.mongos.id
+  }
+  backup_strategy {
+    start_time = "10:00-11:00"
+    keep_days  = "7"
+  }
+  tags = {
+    foo   = "bar"
+    owner = "terraform"
+  }
+}`, name, OS_REGION_NAME, OS_AVAILABILITY_ZONE, OS_VPC_ID, OS_NETWORK_ID)
+}
@@ -1,11 +1,16 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"time"
 
+	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/dis/v2/streams"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -16,11 +21,22 @@ func resourceDisStreamV2() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDisStreamCreate,
 		Read:   resourceDisStreamRead,
+		Update: resourceDisStreamUpdate,
 		Delete: resourceDisStreamDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			suppressDisPartitionCountDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -36,9 +52,11 @@ func resourceDisStreamV2() *schema.Resource {
 					"1 to 64 in length, only letters, digits, hyphens (-), and underscores (_) are allowed."),
 			},
 			"partition_count": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"partition_count", "auto_scale_min_partition_count"},
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -52,11 +70,40 @@ func resourceDisStreamV2() *schema.Resource {
 			"data_duration": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 				Default:  24,
 			},
+			"data_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"BLOB", "JSON", "CSV",
+				}, false),
+			},
+			"data_schema": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auto_scale_min_partition_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"auto_scale_max_partition_count"},
+			},
+			"auto_scale_max_partition_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"auto_scale_min_partition_count"},
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			// Attributes
+			"stream_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -89,6 +136,26 @@ func resourceDisStreamV2() *schema.Resource {
 	}
 }
 
+// suppressDisPartitionCountDiff ignores the changes of partition_count while the auto scaling is enabled,
+// the partitions are scaled by the service and the count read back differs from the configuration.
+func suppressDisPartitionCountDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("partition_count") {
+		return nil
+	}
+	if d.Get("auto_scale_min_partition_count").(int) > 0 && d.Get("auto_scale_max_partition_count").(int) > 0 {
+		return d.Clear("partition_count")
+	}
+	return nil
+}
+
+// streamPartitionCount returns the initial partition count, which defaults to the minimum count of the auto scaling.
+func streamPartitionCount(d *schema.ResourceData) int {
+	if count, ok := d.GetOk("partition_count"); ok {
+		return count.(int)
+	}
+	return d.Get("auto_scale_min_partition_count").(int)
+}
+
 func resourceDisStreamCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	region := GetRegion(d, config)
@@ -103,8 +170,20 @@ func resourceDisStreamCreate(d *schema.ResourceData, meta interface{}) error {
 	createOpts := streams.CreateOpts{
 		StreamName:     streamName,
 		StreamType:     d.Get("type").(string),
-		PartitionCount: d.Get("partition_count").(int),
+		PartitionCount: streamPartitionCount(d),
 		DataDuration:   d.Get("data_duration").(int),
+		DataType:       d.Get("data_type").(string),
+		DataSchema:     d.Get("data_schema").(string),
+	}
+
+	if minCount, ok := d.GetOk("auto_scale_min_partition_count"); ok {
+		createOpts.AutoScaleEnabled = golangsdk.Enabled
+		createOpts.AutoScaleMinPartitionCount = golangsdk.IntToPointer(minCount.(int))
+		createOpts.AutoScaleMaxPartitionCount = golangsdk.IntToPointer(d.Get("auto_scale_max_partition_count").(int))
+	}
+
+	if tagmap := getResourceTags(d, config); len(tagmap) > 0 {
+		createOpts.Tags = expandResourceTags(tagmap)
 	}
 
 	log.Printf("[DEBUG] Create dis stream using parameters: %+v", createOpts)
@@ -115,6 +194,10 @@ func resourceDisStreamCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(streamName)
 
+	if err := waitForDisStreamRunning(disClient, streamName, 0, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
 	return resourceDisStreamRead(d, meta)
 }
 
@@ -138,12 +221,30 @@ func resourceDisStreamRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.Set("name", streamDetail.StreamName)
+	d.Set("stream_id", streamDetail.StreamId)
 	d.Set("data_duration", streamDetail.RetentionPeriod)
+	d.Set("data_type", streamDetail.DataType)
+	d.Set("data_schema", streamDetail.DataSchema)
 	d.Set("status", streamDetail.Status)
-	if !streamDetail.HasMorePartitions {
+	if err := setResourceTags(d, config, tagsToMap(streamDetail.Tags)); err != nil {
+		return err
+	}
+
+	// the partitions of a scaled-in stream are kept until they expire, so prefer the writable count
+	if streamDetail.WritablePartitionCount > 0 {
+		d.Set("partition_count", streamDetail.WritablePartitionCount)
+	} else if !streamDetail.HasMorePartitions {
 		d.Set("partition_count", len(streamDetail.Partitions))
 	}
 
+	if streamDetail.AutoScaleEnabled {
+		d.Set("auto_scale_min_partition_count", streamDetail.AutoScaleMinPartitionCount)
+		d.Set("auto_scale_max_partition_count", streamDetail.AutoScaleMaxPartitionCount)
+	} else {
+		d.Set("auto_scale_min_partition_count", nil)
+		d.Set("auto_scale_max_partition_count", nil)
+	}
+
 	if err := d.Set("partitions", flattenDisPartitions(streamDetail.Partitions)); err != nil {
 		return fmt.Errorf("Error setting partitions field: %s", err)
 	}
@@ -151,6 +252,54 @@ func resourceDisStreamRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func resourceDisStreamUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+
+	disClient, err := config.DisV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Creating dis client failed, err=%s", err)
+	}
+
+	streamName := d.Id()
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("partition_count") {
+		target := d.Get("partition_count").(int)
+		updateOpts := streams.UpdatePartitionOpt{
+			StreamName:           streamName,
+			TargetPartitionCount: target,
+		}
+
+		log.Printf("[DEBUG] Update partitions of dis stream %s using parameters: %+v", streamName, updateOpts)
+		if _, err := streams.UpdatePartition(disClient, streamName, updateOpts); err != nil {
+			return fmt.Errorf("Error updating partitions of dis stream %s: %s", streamName, err)
+		}
+		if err := waitForDisStreamRunning(disClient, streamName, target, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("data_duration", "data_type", "data_schema",
+		"auto_scale_min_partition_count", "auto_scale_max_partition_count") {
+		if err := updateDisStreamAttributes(disClient, d); err != nil {
+			return fmt.Errorf("Error updating dis stream %s: %s", streamName, err)
+		}
+		if err := waitForDisStreamRunning(disClient, streamName, 0, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(disClient, d, config, "stream", d.Get("stream_id").(string))
+		if tagErr != nil {
+			return fmt.Errorf("Error updating tags of dis stream %s: %s", streamName, tagErr)
+		}
+	}
+
+	return resourceDisStreamRead(d, meta)
+}
+
 func resourceDisStreamDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	region := GetRegion(d, config)
@@ -185,3 +334,71 @@ func flattenDisPartitions(partitions []streams.Partition) []map[string]interface
 
 	return result
 }
+
+// updateDisStreamAttributes calls the UpdateStream API (PUT /v2/{project_id}/streams/{stream_name}/update)
+// which is not yet provided by golangsdk.
+func updateDisStreamAttributes(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+	minCount := d.Get("auto_scale_min_partition_count").(int)
+	maxCount := d.Get("auto_scale_max_partition_count").(int)
+	updateOpts := map[string]interface{}{
+		"stream_name":        d.Id(),
+		"data_duration":      d.Get("data_duration").(int),
+		"auto_scale_enabled": minCount > 0 && maxCount > 0,
+	}
+	if v, ok := d.GetOk("data_type"); ok {
+		updateOpts["data_type"] = v.(string)
+	}
+	if v, ok := d.GetOk("data_schema"); ok {
+		updateOpts["data_schema"] = v.(string)
+	}
+	if minCount > 0 && maxCount > 0 {
+		updateOpts["auto_scale_min_partition_count"] = minCount
+		updateOpts["auto_scale_max_partition_count"] = maxCount
+	}
+
+	log.Printf("[DEBUG] Update dis stream %s using parameters: %+v", d.Id(), updateOpts)
+	_, err := client.Put(client.ServiceURL("streams", d.Id(), "update"), updateOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201, 204},
+		MoreHeaders: map[string]string{
+			"Content-Type": "application/json",
+			"region":       client.AKSKAuthOptions.Region,
+		},
+	})
+	return err
+}
+
+// waitForDisStreamRunning waits for the stream to be RUNNING, and when partitionCount is greater than 0,
+// for the number of its writable partitions to reach partitionCount.
+func waitForDisStreamRunning(client *golangsdk.ServiceClient, streamName string, partitionCount int,
+	timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"RUNNING"},
+		Pending:    []string{"CREATING", "PENDING"},
+		Refresh:    getDisStreamStatus(client, streamName, partitionCount),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for dis stream %s to become RUNNING: %s", streamName, err)
+	}
+	return nil
+}
+
+func getDisStreamStatus(client *golangsdk.ServiceClient, streamName string, partitionCount int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		streamDetail, err := streams.Get(client, streamName, streams.GetOpts{})
+		if err != nil {
+			return nil, "", err
+		}
+
+		if streamDetail.Status == "RUNNING" && partitionCount > 0 &&
+			streamDetail.WritablePartitionCount != partitionCount {
+			log.Printf("[DEBUG] dis stream %s has %d writable partitions, expected %d",
+				streamName, streamDetail.WritablePartitionCount, partitionCount)
+			return streamDetail, "PENDING", nil
+		}
+		return streamDetail, streamDetail.Status, nil
+	}
+}
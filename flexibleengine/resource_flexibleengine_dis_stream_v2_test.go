@@ -1,6 +1,7 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,6 +12,47 @@ import (
 	"github.com/chnsz/golangsdk/openstack/dis/v2/streams"
 )
 
+func TestDisStreamPartitionCountDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "stream",
+		Attributes: map[string]string{
+			"id":                             "stream",
+			"name":                           "stream",
+			"partition_count":                "5",
+			"type":                           "COMMON",
+			"data_duration":                  "24",
+			"auto_scale_min_partition_count": "1",
+			"auto_scale_max_partition_count": "6",
+		},
+	}
+
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		changed bool
+	}{
+		{"auto scaling", map[string]interface{}{
+			"partition_count": 3, "auto_scale_min_partition_count": 1, "auto_scale_max_partition_count": 6,
+		}, false},
+		{"auto scaling without partition_count", map[string]interface{}{
+			"auto_scale_min_partition_count": 1, "auto_scale_max_partition_count": 6,
+		}, false},
+		{"manual scaling", map[string]interface{}{"partition_count": 3}, true},
+	}
+
+	for _, tc := range cases {
+		tc.config["name"] = "stream"
+		diff, err := resourceDisStreamV2().Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		_, changed := diff.Attributes["partition_count"]
+		if changed != tc.changed {
+			t.Fatalf("%s: expected the change of partition_count to be %t", tc.name, tc.changed)
+		}
+	}
+}
+
 func TestAccDisStreamV2_basic(t *testing.T) {
 	streamName := fmt.Sprintf("ACCPTTEST-%s", acctest.RandString(5))
 	resourceName := "flexibleengine_dis_stream.test"
@@ -28,6 +70,28 @@ func TestAccDisStreamV2_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "data_duration", "24"),
 					resource.TestCheckResourceAttr(resourceName, "partition_count", "3"),
 					resource.TestCheckResourceAttr(resourceName, "partitions.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccDisStreamV2_update(streamName, streams.StreamTypeCommon, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDisStreamV2Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "data_duration", "48"),
+					resource.TestCheckResourceAttr(resourceName, "partition_count", "4"),
+					resource.TestCheckResourceAttr(resourceName, "auto_scale_min_partition_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "auto_scale_max_partition_count", "6"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar_update"),
+					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccDisStreamV2_basic(streamName, streams.StreamTypeCommon, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDisStreamV2Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "data_duration", "24"),
+					resource.TestCheckResourceAttr(resourceName, "partition_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
 				),
 			},
 			{
@@ -48,6 +112,27 @@ resource flexibleengine_dis_stream "test" {
   name			= "%s"
   type			= "%s"
   partition_count	= "%d"
+
+  tags = {
+    foo = "bar"
+  }
+}`, streamName, streamType, partitionCount)
+}
+
+func testAccDisStreamV2_update(streamName string, streamType string, partitionCount int) string {
+	return fmt.Sprintf(`
+resource flexibleengine_dis_stream "test" {
+  name            = "%s"
+  type            = "%s"
+  partition_count = %d
+  data_duration   = 48
+
+  auto_scale_min_partition_count = 1
+  auto_scale_max_partition_count = 6
+
+  tags = {
+    foo = "bar_update"
+  }
 }`, streamName, streamType, partitionCount)
 }
 
@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/dli/v1/queues"
 )
 
@@ -37,6 +36,8 @@ func ResourceDliQueueV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceTagsForceNewCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(10 * time.Minute),
 		},
@@ -86,6 +87,8 @@ func ResourceDliQueueV1() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"tags_all": tagsAllSchema(),
+
 			"create_time": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -112,7 +115,7 @@ func resourceDliQueueCreate(d *schema.ResourceData, meta interface{}) error {
 		Description:  d.Get("description").(string),
 		CuCount:      d.Get("cu_count").(int),
 		ResourceMode: d.Get("resource_mode").(int),
-		Tags:         assembleTagsFromRecource("tags", d),
+		Tags:         expandResourceTags(getResourceTags(d, config)),
 	}
 
 	log.Printf("[DEBUG] create dli queues using paramaters: %+v", createOpts)
@@ -123,22 +126,14 @@ func resourceDliQueueCreate(d *schema.ResourceData, meta interface{}) error {
 
 	//query queue detail,trriger read to refresh the state
 	d.SetId(queueName)
+	// the tags of queue are not returned by the query API
+	d.Set("tags_all", getResourceTags(d, config))
 	// This is a workaround to avoid issue: the queue is assigning, which is not available
 	time.Sleep(120 * time.Second) //lintignore:R018
 
 	return resourceDliQueueRead(d, meta)
 }
 
-func assembleTagsFromRecource(key string, d *schema.ResourceData) []tags.ResourceTag {
-	if v, ok := d.GetOk(key); ok {
-		tagRaw := v.(map[string]interface{})
-		taglist := expandResourceTags(tagRaw)
-		return taglist
-	}
-	return nil
-
-}
-
 func resourceDliQueueRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	region := GetRegion(d, config)
@@ -1,20 +1,24 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/dms/v1/instances"
 	dmsv2 "github.com/chnsz/golangsdk/openstack/dms/v2/kafka/instances"
-	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
@@ -23,6 +27,15 @@ const (
 	sasSpecCode = "dms.physical.storage.high"
 )
 
+// kafkaDefaultBrokerNum is the number of brokers of a newly created instance for each bandwidth,
+// it is also the number of EIPs required to enable the public access.
+var kafkaDefaultBrokerNum = map[string]int{
+	"100MB":  3,
+	"300MB":  3,
+	"600MB":  4,
+	"1200MB": 8,
+}
+
 func resourceDmsKafkaInstances() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDmsKafkaInstancesCreate,
@@ -33,8 +46,14 @@ func resourceDmsKafkaInstances() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceDmsKafkaInstanceCustomizeDiff,
+			resourceTagsCustomizeDiff,
+		),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
@@ -63,7 +82,6 @@ func resourceDmsKafkaInstances() *schema.Resource {
 			"bandwidth": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringInSlice(
 					[]string{"100MB", "300MB", "600MB", "1200MB"},
 					false,
@@ -72,12 +90,16 @@ func resourceDmsKafkaInstances() *schema.Resource {
 			"product_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"storage_space": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
+			},
+			"broker_num": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(3),
 			},
 			"storage_spec_code": {
 				Type:     schema.TypeString,
@@ -123,20 +145,17 @@ func resourceDmsKafkaInstances() *schema.Resource {
 				Type:         schema.TypeString,
 				Sensitive:    true,
 				Optional:     true,
-				ForceNew:     true,
 				RequiredWith: []string{"manager_user"},
 			},
 			"access_user": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				RequiredWith: []string{"password"},
 			},
 			"password": {
 				Type:         schema.TypeString,
 				Sensitive:    true,
 				Optional:     true,
-				ForceNew:     true,
 				RequiredWith: []string{"access_user"},
 			},
 			"maintain_begin": {
@@ -154,9 +173,16 @@ func resourceDmsKafkaInstances() *schema.Resource {
 			"enable_auto_topic": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
+			},
+			"public_ip_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
-			"tags": common.TagsForceNewSchema(),
+			"tags":                  tagsSchema(),
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 
 			"status": {
 				Type:     schema.TypeString,
@@ -210,6 +236,14 @@ func resourceDmsKafkaInstances() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"enable_public_ip": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"public_connect_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"used_storage_space": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -262,8 +296,22 @@ func resourceDmsKafkaInstancesCreate(d *schema.ResourceData, meta interface{}) e
 		EnableAutoTopic:  d.Get("enable_auto_topic").(bool),
 	}
 
+	// the EIPs exceeding the initial broker number are bound to the brokers added by scale-out
+	bandwidth := d.Get("bandwidth").(string)
+	publicIPs := utils.ExpandToStringList(d.Get("public_ip_ids").([]interface{}))
+	if len(publicIPs) > 0 {
+		initialNum := kafkaDefaultBrokerNum[bandwidth]
+		if len(publicIPs) < initialNum {
+			return fmt.Errorf("Error creating FlexibleEngine DMS instance: "+
+				"%d public IP IDs are required when bandwidth is set to %s, but got %d",
+				initialNum, bandwidth, len(publicIPs))
+		}
+		createOpts.EnablePublicip = true
+		createOpts.PublicipId = strings.Join(publicIPs[:initialNum], ",")
+	}
+
 	// set tags
-	if tagRaw := d.Get("tags").(map[string]interface{}); len(tagRaw) > 0 {
+	if tagRaw := getResourceTags(d, config); len(tagRaw) > 0 {
 		createOpts.Tags = utils.ExpandResourceTags(tagRaw)
 	}
 
@@ -296,6 +344,25 @@ func resourceDmsKafkaInstancesCreate(d *schema.ResourceData, meta interface{}) e
 	// Store the instance ID now
 	d.SetId(v.InstanceID)
 
+	// the create API always builds the default number of brokers, so scale out to the expected one
+	if brokerNum := d.Get("broker_num").(int); brokerNum > kafkaDefaultBrokerNum[bandwidth] {
+		var newIPs []string
+		if len(publicIPs) > 0 {
+			newIPs = publicIPs[kafkaDefaultBrokerNum[bandwidth]:]
+		}
+		err = scaleOutDmsKafkaInstance(dmsV2Client, d.Id(), brokerNum, newIPs, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the DMS v1 API can not create an instance in an enterprise project
+	if config.GetEnterpriseProjectID(d) != "" {
+		if err := migrateEnterpriseProject(d, config, "kafka", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceDmsKafkaInstancesRead(d, meta)
 }
 
@@ -346,9 +413,21 @@ func resourceDmsKafkaInstancesRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("maintain_begin", v.MaintainBegin),
 		d.Set("maintain_end", v.MaintainEnd),
 		d.Set("ssl_enable", v.SslEnable),
+		d.Set("broker_num", v.BrokerNum),
+		d.Set("enable_auto_topic", v.EnableAutoTopic),
+		d.Set("enable_public_ip", v.EnablePublicIP),
+		d.Set("public_connect_address", v.PublicConnectionAddress),
 		d.Set("created_at", setResourceTimestamp(v.CreatedAt)),
+		d.Set("enterprise_project_id", v.EnterpriseProjectID),
 	)
 
+	// fetch tags
+	if resourceTags, err := tags.Get(dmsV2Client, "kafka", d.Id()).Extract(); err == nil {
+		mErr = multierror.Append(mErr, setResourceTags(d, config, tagsToMap(resourceTags.Tags)))
+	} else {
+		log.Printf("[WARN] Error fetching tags of DMS instance %s: %s", d.Id(), err)
+	}
+
 	if mErr.ErrorOrNil() != nil {
 		return fmt.Errorf("Error setting DMS product attributes: %s", mErr)
 	}
@@ -399,9 +478,368 @@ func resourceDmsKafkaInstancesUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChanges("product_id", "broker_num", "storage_space", "enable_auto_topic", "public_ip_ids",
+		"access_user", "password", "manager_password") {
+		dmsV2Client, err := config.DmsV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine DMS v2 client: %s", err)
+		}
+		timeout := d.Timeout(schema.TimeoutUpdate)
+
+		// the public access is disabled before the scale-out and enabled after it,
+		// so that one EIP is bound to each of the brokers
+		oldRaw, newRaw := d.GetChange("public_ip_ids")
+		oldIPs := utils.ExpandToStringList(oldRaw.([]interface{}))
+		newIPs := utils.ExpandToStringList(newRaw.([]interface{}))
+		if len(oldIPs) > 0 && len(newIPs) == 0 {
+			if err := updateDmsKafkaPublicAccess(dmsV2Client, d.Id(), nil, timeout); err != nil {
+				return err
+			}
+		}
+
+		if err := resizeDmsKafkaInstance(d, dmsV2Client, timeout); err != nil {
+			return err
+		}
+
+		if len(oldIPs) == 0 && len(newIPs) > 0 {
+			if err := updateDmsKafkaPublicAccess(dmsV2Client, d.Id(), newIPs, timeout); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange("enable_auto_topic") {
+			if err := updateDmsKafkaAutoTopic(dmsV2Client, d.Id(), d.Get("enable_auto_topic").(bool), timeout); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange("access_user") {
+			// the password of the access user is set when SASL_SSL is enabled
+			err := updateDmsKafkaSSL(dmsV2Client, d.Id(), d.Get("access_user").(string), d.Get("password").(string),
+				timeout)
+			if err != nil {
+				return err
+			}
+		} else if d.HasChange("password") {
+			url := dmsV2Client.ServiceURL(dmsV2Client.ProjectID, "instances", d.Id(), "password")
+			if err := resetDmsKafkaPassword(dmsV2Client, url, "POST", d.Get("password").(string)); err != nil {
+				return fmt.Errorf("Error resetting the password of DMS instance %s: %s", d.Id(), err)
+			}
+		}
+		if d.HasChange("manager_password") {
+			url := dmsV2Client.ServiceURL(dmsV2Client.ProjectID, "instances", d.Id(), "kafka-manager-password")
+			if err := resetDmsKafkaPassword(dmsV2Client, url, "PUT", d.Get("manager_password").(string)); err != nil {
+				return fmt.Errorf("Error resetting the manager password of DMS instance %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		dmsV2Client, err := config.DmsV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine DMS v2 client: %s", err)
+		}
+		if err := UpdateResourceTags(dmsV2Client, d, config, "kafka", d.Id()); err != nil {
+			return fmt.Errorf("Error updating tags of DMS instance %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "kafka", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceDmsKafkaInstancesRead(d, meta)
 }
 
+func resourceDmsKafkaInstanceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		publicIPs := d.Get("public_ip_ids").([]interface{})
+		if len(publicIPs) == 0 {
+			return nil
+		}
+		expected := kafkaDefaultBrokerNum[d.Get("bandwidth").(string)]
+		if brokerNum := d.Get("broker_num").(int); brokerNum > expected {
+			expected = brokerNum
+		}
+		if len(publicIPs) != expected {
+			return fmt.Errorf("%d public IP IDs are required, one for each broker, but got %d", expected, len(publicIPs))
+		}
+		return nil
+	}
+
+	if d.HasChange("bandwidth") != d.HasChange("product_id") {
+		return fmt.Errorf("bandwidth and product_id must be changed together")
+	}
+	if d.HasChange("storage_space") {
+		oldVal, newVal := d.GetChange("storage_space")
+		if newVal.(int) < oldVal.(int) {
+			return fmt.Errorf("storage_space can only be increased, the current value is %v", oldVal)
+		}
+	}
+
+	oldBroker, newBroker := d.GetChange("broker_num")
+	if newBroker.(int) < oldBroker.(int) {
+		return fmt.Errorf("broker_num can only be increased, the current value is %v", oldBroker)
+	}
+
+	// SASL_SSL can be enabled or disabled, but the access user can not be renamed
+	oldUser, newUser := d.GetChange("access_user")
+	if oldUser.(string) != "" && newUser.(string) != "" && oldUser.(string) != newUser.(string) {
+		if err := d.ForceNew("access_user"); err != nil {
+			return err
+		}
+	}
+
+	oldRaw, newRaw := d.GetChange("public_ip_ids")
+	oldIPs, newIPs := oldRaw.([]interface{}), newRaw.([]interface{})
+	if len(newIPs) == 0 {
+		return nil
+	}
+	// enabling the public access binds one EIP to each broker
+	if len(oldIPs) == 0 {
+		if len(newIPs) != newBroker.(int) {
+			return fmt.Errorf("%d public IP IDs are required to enable the public access, one for each broker, "+
+				"but got %d", newBroker.(int), len(newIPs))
+		}
+		return nil
+	}
+	if len(newIPs) < len(oldIPs) || !reflect.DeepEqual(oldIPs, newIPs[:len(oldIPs)]) {
+		return fmt.Errorf("the public IP IDs of the existing brokers can not be changed, " +
+			"only the IDs for the new brokers can be appended")
+	}
+	if added := newBroker.(int) - oldBroker.(int); len(newIPs)-len(oldIPs) != added {
+		return fmt.Errorf("%d public IP IDs must be appended for the new brokers, but got %d",
+			added, len(newIPs)-len(oldIPs))
+	}
+
+	return nil
+}
+
+// resizeDmsKafkaInstance changes the flavor, broker number and storage space of the instance in turn
+func resizeDmsKafkaInstance(d *schema.ResourceData, client *golangsdk.ServiceClient, timeout time.Duration) error {
+	if d.HasChange("product_id") {
+		operType := "vertical"
+		productID := d.Get("product_id").(string)
+		opts := dmsv2.ResizeInstanceOpts{
+			OperType:     &operType,
+			NewProductID: &productID,
+		}
+		err := doDmsKafkaInstanceResize(client, d.Id(), opts, timeout, func(v *dmsv2.Instance) bool {
+			return v.ProductID == productID
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("broker_num") {
+		// the EIPs are only bound to the new brokers when the public access has been enabled
+		oldRaw, newRaw := d.GetChange("public_ip_ids")
+		oldIPs := utils.ExpandToStringList(oldRaw.([]interface{}))
+		newIPs := utils.ExpandToStringList(newRaw.([]interface{}))
+		if len(oldIPs) > 0 && len(newIPs) > len(oldIPs) {
+			newIPs = newIPs[len(oldIPs):]
+		} else {
+			newIPs = nil
+		}
+
+		if err := scaleOutDmsKafkaInstance(client, d.Id(), d.Get("broker_num").(int), newIPs, timeout); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("storage_space") {
+		// the storage space of the new brokers is added to the total during the scale-out
+		v, err := dmsv2.Get(client, d.Id()).Extract()
+		if err != nil {
+			return fmt.Errorf("Error retrieving DMS instance %s: %s", d.Id(), err)
+		}
+
+		storageSpace := d.Get("storage_space").(int)
+		if v.TotalStorageSpace < storageSpace {
+			operType := "storage"
+			opts := dmsv2.ResizeInstanceOpts{
+				OperType:        &operType,
+				NewStorageSpace: &storageSpace,
+			}
+			err := doDmsKafkaInstanceResize(client, d.Id(), opts, timeout, func(v *dmsv2.Instance) bool {
+				return v.TotalStorageSpace == storageSpace
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func scaleOutDmsKafkaInstance(client *golangsdk.ServiceClient, instanceID string, brokerNum int,
+	publicIPs []string, timeout time.Duration) error {
+	operType := "horizontal"
+	opts := dmsv2.ResizeInstanceOpts{
+		OperType:     &operType,
+		NewBrokerNum: &brokerNum,
+	}
+	if len(publicIPs) > 0 {
+		publicIPID := strings.Join(publicIPs, ",")
+		opts.PublicIpID = &publicIPID
+	}
+
+	return doDmsKafkaInstanceResize(client, instanceID, opts, timeout, func(v *dmsv2.Instance) bool {
+		return v.BrokerNum == brokerNum
+	})
+}
+
+func doDmsKafkaInstanceResize(client *golangsdk.ServiceClient, instanceID string, opts dmsv2.ResizeInstanceOpts,
+	timeout time.Duration, done func(*dmsv2.Instance) bool) error {
+	log.Printf("[DEBUG] Resizing DMS instance %s (%s): %#v", instanceID, *opts.OperType, opts)
+	if _, err := dmsv2.Resize(client, instanceID, opts); err != nil {
+		return fmt.Errorf("Error resizing DMS instance %s (%s): %s", instanceID, *opts.OperType, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING", "EXTENDING"},
+		Target:       []string{"RUNNING"},
+		Refresh:      dmsKafkaInstanceResizeRefreshFunc(client, instanceID, done),
+		Timeout:      timeout,
+		Delay:        60 * time.Second,
+		PollInterval: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to be resized: %s", instanceID, err)
+	}
+	return nil
+}
+
+func updateDmsKafkaAutoTopic(client *golangsdk.ServiceClient, instanceID string, enable bool,
+	timeout time.Duration) error {
+	url := client.ServiceURL(client.ProjectID, "instances", instanceID, "autotopic")
+	params := map[string]interface{}{
+		"enable_auto_topic": enable,
+	}
+
+	log.Printf("[DEBUG] Updating the automatic topic creation of DMS instance %s: %#v", instanceID, params)
+	_, err := client.Post(url, params, nil, &golangsdk.RequestOpts{
+		OkCodes: successHTTPCodes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating the automatic topic creation of DMS instance %s: %s", instanceID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"RUNNING"},
+		Refresh: dmsKafkaInstanceResizeRefreshFunc(client, instanceID, func(v *dmsv2.Instance) bool {
+			return v.EnableAutoTopic == enable
+		}),
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for the automatic topic creation of instance (%s) to be updated: %s",
+			instanceID, err)
+	}
+	return nil
+}
+
+// updateDmsKafkaPublicAccess enables the public access with the EIPs bound to the brokers,
+// or disables it when no EIP is specified.
+func updateDmsKafkaPublicAccess(client *golangsdk.ServiceClient, instanceID string, publicIPs []string,
+	timeout time.Duration) error {
+	enable := len(publicIPs) > 0
+	url := client.ServiceURL(client.ProjectID, "instances", instanceID)
+	params := map[string]interface{}{
+		"enable_publicip": enable,
+	}
+	if enable {
+		params["publicip_id"] = strings.Join(publicIPs, ",")
+	}
+
+	log.Printf("[DEBUG] Updating the public access of DMS instance %s: %#v", instanceID, params)
+	_, err := client.Put(url, params, nil, &golangsdk.RequestOpts{
+		OkCodes: successHTTPCodes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating the public access of DMS instance %s: %s", instanceID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING", "EXTENDING"},
+		Target:  []string{"RUNNING"},
+		Refresh: dmsKafkaInstanceResizeRefreshFunc(client, instanceID, func(v *dmsv2.Instance) bool {
+			return v.EnablePublicIP == enable
+		}),
+		Timeout:      timeout,
+		Delay:        30 * time.Second,
+		PollInterval: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for the public access of instance (%s) to be updated: %s", instanceID, err)
+	}
+	return nil
+}
+
+// updateDmsKafkaSSL enables SASL_SSL with the access user, or disables it when the user is empty.
+func updateDmsKafkaSSL(client *golangsdk.ServiceClient, instanceID, accessUser, password string,
+	timeout time.Duration) error {
+	enable := accessUser != ""
+	url := client.ServiceURL(client.ProjectID, "kafka", "instances", instanceID, "plain-ssl-switch")
+	params := map[string]interface{}{
+		"ssl_enable": enable,
+	}
+	if enable {
+		params["access_user"] = accessUser
+	}
+
+	log.Printf("[DEBUG] Updating SASL_SSL of DMS instance %s: %#v", instanceID, params)
+	// add password here so it wouldn't go in the above log entry
+	if enable {
+		params["password"] = password
+	}
+	_, err := client.Post(url, params, nil, &golangsdk.RequestOpts{
+		OkCodes: successHTTPCodes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating SASL_SSL of DMS instance %s: %s", instanceID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING", "EXTENDING"},
+		Target:  []string{"RUNNING"},
+		Refresh: dmsKafkaInstanceResizeRefreshFunc(client, instanceID, func(v *dmsv2.Instance) bool {
+			return v.SslEnable == enable
+		}),
+		Timeout:      timeout,
+		Delay:        30 * time.Second,
+		PollInterval: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for SASL_SSL of instance (%s) to be updated: %s", instanceID, err)
+	}
+	return nil
+}
+
+func resetDmsKafkaPassword(client *golangsdk.ServiceClient, url, method, password string) error {
+	params := map[string]interface{}{
+		"new_password": password,
+	}
+	opts := golangsdk.RequestOpts{
+		OkCodes: successHTTPCodes,
+	}
+
+	var err error
+	if method == "PUT" {
+		_, err = client.Put(url, params, nil, &opts)
+	} else {
+		_, err = client.Post(url, params, nil, &opts)
+	}
+	return err
+}
+
 func resourceDmsKafkaInstancesDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
@@ -451,3 +889,20 @@ func dmsKafkaInstancesStateRefreshFunc(client *golangsdk.ServiceClient, instance
 		return v, v.Status, nil
 	}
 }
+
+// dmsKafkaInstanceResizeRefreshFunc returns PENDING until the change is visible in the instance details,
+// and then the status of the instance
+func dmsKafkaInstanceResizeRefreshFunc(client *golangsdk.ServiceClient, instanceID string,
+	done func(*dmsv2.Instance) bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		v, err := dmsv2.Get(client, instanceID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !done(v) {
+			return v, "PENDING", nil
+		}
+		return v, v.Status, nil
+	}
+}
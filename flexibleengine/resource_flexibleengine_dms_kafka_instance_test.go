@@ -42,6 +42,8 @@ func TestAccDmsKafkaInstance_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", instanceUpdate),
 					resource.TestCheckResourceAttr(resourceName, "description", "instance update description"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo1", "bar_update"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value_update"),
 				),
 			},
 			{
@@ -49,13 +51,81 @@ func TestAccDmsKafkaInstance_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"access_user", "password", "manager_user", "manager_password", "tags",
+					"access_user", "password", "manager_user", "manager_password",
 				},
 			},
 		},
 	})
 }
 
+func TestAccDmsKafkaInstance_scale(t *testing.T) {
+	var instanceName = fmt.Sprintf("ACCPTTEST-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_dms_kafka_instance.instance_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      testAccCheckDmsKafkaInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDmsKafkaInstance_scale(instanceName, 3, 1200, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDmsKafkaInstanceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "broker_num", "3"),
+					resource.TestCheckResourceAttr(resourceName, "storage_space", "1200"),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_topic", "false"),
+					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccDmsKafkaInstance_scale(instanceName, 4, 1600, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "broker_num", "4"),
+					resource.TestCheckResourceAttr(resourceName, "storage_space", "1600"),
+					resource.TestCheckResourceAttr(resourceName, "enable_auto_topic", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDmsKafkaInstance_access(t *testing.T) {
+	var instanceName = fmt.Sprintf("ACCPTTEST-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_dms_kafka_instance.instance_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: TestAccProviderFactories,
+		CheckDestroy:      testAccCheckDmsKafkaInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDmsKafkaInstance_access(instanceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDmsKafkaInstanceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ssl_enable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enable_public_ip", "false"),
+				),
+			},
+			{
+				Config: testAccDmsKafkaInstance_access(instanceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ssl_enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enable_public_ip", "true"),
+					resource.TestCheckResourceAttr(resourceName, "public_ip_ids.#", "3"),
+				),
+			},
+			{
+				Config: testAccDmsKafkaInstance_access(instanceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ssl_enable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enable_public_ip", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDmsKafkaInstanceDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	client, err := config.DmsV1Client(OS_REGION_NAME)
@@ -175,8 +245,71 @@ resource "flexibleengine_dms_kafka_instance" "instance_1" {
   engine_version     = data.flexibleengine_dms_product.product_1.engine_version
 
   tags = {
-    foo = "bar"
-    key = "value"
+    foo1 = "bar_update"
+    key  = "value_update"
   }
 }`, testAccDmsKafkaInstance_base(resName), resUpdate)
 }
+
+func testAccDmsKafkaInstance_scale(resName string, brokerNum, storageSpace int, autoTopic bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_dms_kafka_instance" "instance_1" {
+  name               = "%s"
+  manager_user       = "admin"
+  manager_password   = "Dmstest@123"
+  vpc_id             = flexibleengine_vpc_v1.vpc_1.id
+  network_id         = flexibleengine_vpc_subnet_v1.vpc_subnet_1.id
+  security_group_id  = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  availability_zones = data.flexibleengine_dms_product.product_1.availability_zones
+  bandwidth          = data.flexibleengine_dms_product.product_1.bandwidth
+  product_id         = data.flexibleengine_dms_product.product_1.id
+  engine_version     = data.flexibleengine_dms_product.product_1.engine_version
+  broker_num         = %d
+  storage_space      = %d
+  enable_auto_topic  = %t
+}`, testAccDmsKafkaInstance_base(resName), resName, brokerNum, storageSpace, autoTopic)
+}
+
+func testAccDmsKafkaInstance_access(resName string, enable bool) string {
+	accessConfig := ""
+	if enable {
+		accessConfig = `
+  access_user   = "user"
+  password      = "Kafkatest@123"
+  public_ip_ids = flexibleengine_vpc_eip.eip[*].id`
+	}
+
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_vpc_eip" "eip" {
+  count = 3
+
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name        = "%s-${count.index}"
+    size        = 5
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+}
+
+resource "flexibleengine_dms_kafka_instance" "instance_1" {
+  name               = "%s"
+  manager_user       = "admin"
+  manager_password   = "Dmstest@123"
+  vpc_id             = flexibleengine_vpc_v1.vpc_1.id
+  network_id         = flexibleengine_vpc_subnet_v1.vpc_subnet_1.id
+  security_group_id  = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  availability_zones = data.flexibleengine_dms_product.product_1.availability_zones
+  bandwidth          = data.flexibleengine_dms_product.product_1.bandwidth
+  product_id         = data.flexibleengine_dms_product.product_1.id
+  engine_version     = data.flexibleengine_dms_product.product_1.engine_version
+  storage_space      = data.flexibleengine_dms_product.product_1.storage_space
+%s
+}`, testAccDmsKafkaInstance_base(resName), resName, resName, accessConfig)
+}
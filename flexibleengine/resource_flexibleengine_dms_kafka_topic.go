@@ -6,7 +6,8 @@ import (
 	"log"
 	"strings"
 
-	"github.com/chnsz/golangsdk/openstack/dms/v1/topics"
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/dms/v2/kafka/topics"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,12 +18,24 @@ func resourceDmsKafkaTopic() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceDmsKafkaTopicCreate,
 		ReadContext:   resourceDmsKafkaTopicRead,
+		UpdateContext: resourceDmsKafkaTopicUpdate,
 		DeleteContext: resourceDmsKafkaTopicDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDmsKafkaTopicImport,
 		},
 
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			// the partitions of a topic can not be reduced
+			if d.Id() != "" && d.HasChange("partitions") {
+				oldNum, newNum := d.GetChange("partitions")
+				if newNum.(int) < oldNum.(int) {
+					return fmt.Errorf("partitions can only be increased, the current value is %d", oldNum)
+				}
+			}
+			return nil
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -44,7 +57,6 @@ func resourceDmsKafkaTopic() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"replicas": {
 				Type:     schema.TypeInt,
@@ -56,13 +68,11 @@ func resourceDmsKafkaTopic() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"sync_replication": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"sync_flushing": {
 				Type:     schema.TypeBool,
@@ -70,29 +80,36 @@ func resourceDmsKafkaTopic() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 	}
 }
 
 func resourceDmsKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dmsv1Client, err := config.DmsV1Client(config.GetRegion(d))
+	dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
 	if err != nil {
 		return diag.Errorf("error creating FlexibleEngine DMS client: %s", err)
 	}
 
-	createOpts := &topics.CreateOps{
-		Name:             d.Get("name").(string),
-		Partition:        d.Get("partitions").(int),
-		Replication:      d.Get("replicas").(int),
-		RetentionTime:    d.Get("aging_time").(int),
-		SyncReplication:  d.Get("sync_replication").(bool),
-		SyncMessageFlush: d.Get("sync_flushing").(bool),
+	createOpts := kafkaTopicCreateOpts{
+		CreateOps: topics.CreateOps{
+			Name:             d.Get("name").(string),
+			Partition:        d.Get("partitions").(int),
+			Replication:      d.Get("replicas").(int),
+			RetentionTime:    d.Get("aging_time").(int),
+			SyncReplication:  d.Get("sync_replication").(bool),
+			SyncMessageFlush: d.Get("sync_flushing").(bool),
+		},
+		Description: d.Get("description").(string),
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	instanceID := d.Get("instance_id").(string)
-	v, err := topics.Create(dmsv1Client, instanceID, createOpts).Extract()
+	v, err := topics.Create(dmsV2Client, instanceID, createOpts).Extract()
 	if err != nil {
 		return diag.Errorf("error creating FlexibleEngine DMS kafka topic: %s", err)
 	}
@@ -104,19 +121,22 @@ func resourceDmsKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, me
 
 func resourceDmsKafkaTopicRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dmsv1Client, err := config.DmsV1Client(config.GetRegion(d))
+	dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
 	if err != nil {
 		return diag.Errorf("error creating FlexibleEngine DMS client: %s", err)
 	}
 
 	instanceID := d.Get("instance_id").(string)
-	allTopics, err := topics.List(dmsv1Client, instanceID).Extract()
-	if err != nil {
+	var listResp struct {
+		Topics []kafkaTopic `json:"topics"`
+	}
+	if err := topics.List(dmsV2Client, instanceID).ExtractInto(&listResp); err != nil {
 		return CheckDeletedDiag(d, err, "DMS kafka topic")
 	}
 
 	topicID := d.Id()
-	var found *topics.Topic
+	allTopics := listResp.Topics
+	var found *kafkaTopic
 	for _, item := range allTopics {
 		if item.Name == topicID {
 			found = &item
@@ -140,6 +160,7 @@ func resourceDmsKafkaTopicRead(_ context.Context, d *schema.ResourceData, meta i
 		d.Set("aging_time", found.RetentionTime),
 		d.Set("sync_replication", found.SyncReplication),
 		d.Set("sync_flushing", found.SyncMessageFlush),
+		d.Set("description", found.Description),
 	)
 	if mErr.ErrorOrNil() != nil {
 		return diag.FromErr(mErr)
@@ -148,16 +169,54 @@ func resourceDmsKafkaTopicRead(_ context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceDmsKafkaTopicUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating FlexibleEngine DMS client: %s", err)
+	}
+
+	updateItem := kafkaTopicUpdateItem{
+		Name: d.Id(),
+	}
+	if d.HasChange("partitions") {
+		updateItem.Partition = golangsdk.IntToPointer(d.Get("partitions").(int))
+	}
+	if d.HasChange("aging_time") {
+		updateItem.RetentionTime = golangsdk.IntToPointer(d.Get("aging_time").(int))
+	}
+	if d.HasChange("sync_replication") {
+		syncReplication := d.Get("sync_replication").(bool)
+		updateItem.SyncReplication = &syncReplication
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateItem.Description = &description
+	}
+
+	updateOpts := kafkaTopicUpdateOpts{
+		Topics: []kafkaTopicUpdateItem{updateItem},
+	}
+
+	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+	instanceID := d.Get("instance_id").(string)
+	if err := topics.Update(dmsV2Client, instanceID, updateOpts).ExtractErr(); err != nil {
+		return diag.Errorf("error updating DMS kafka topic %s: %s", d.Id(), err)
+	}
+
+	return resourceDmsKafkaTopicRead(ctx, d, meta)
+}
+
 func resourceDmsKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dmsv1Client, err := config.DmsV1Client(config.GetRegion(d))
+	dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
 	if err != nil {
 		return diag.Errorf("error creating FlexibleEngine DMS client: %s", err)
 	}
 
 	topicID := d.Id()
 	instanceID := d.Get("instance_id").(string)
-	response, err := topics.Delete(dmsv1Client, instanceID, []string{topicID}).Extract()
+	response, err := topics.Delete(dmsV2Client, instanceID, []string{topicID}).Extract()
 	if err != nil {
 		return diag.Errorf("error deleting DMS kafka topic: %s", err)
 	}
@@ -195,3 +254,46 @@ func resourceDmsKafkaTopicImport(_ context.Context, d *schema.ResourceData, meta
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// kafkaTopic is the topic returned by the list API, which also contains the description
+type kafkaTopic struct {
+	topics.Topic
+	Description string `json:"topic_desc"`
+}
+
+// kafkaTopicCreateOpts adds the description of the topic to topics.CreateOps
+type kafkaTopicCreateOpts struct {
+	topics.CreateOps
+	Description string
+}
+
+// ToTopicCreateMap is used for type convert
+func (opts kafkaTopicCreateOpts) ToTopicCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOps.ToTopicCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Description != "" {
+		b["topic_desc"] = opts.Description
+	}
+	return b, nil
+}
+
+// kafkaTopicUpdateOpts is the same as topics.UpdateOpts but is able to update the description
+type kafkaTopicUpdateOpts struct {
+	Topics []kafkaTopicUpdateItem `json:"topics" required:"true"`
+}
+
+type kafkaTopicUpdateItem struct {
+	Name            string  `json:"id" required:"true"`
+	Partition       *int    `json:"new_partition_numbers,omitempty"`
+	RetentionTime   *int    `json:"retention_time,omitempty"`
+	SyncReplication *bool   `json:"sync_replication,omitempty"`
+	Description     *string `json:"topic_desc,omitempty"`
+}
+
+// ToTopicUpdateMap is used for type convert
+func (opts kafkaTopicUpdateOpts) ToTopicUpdateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
@@ -2,6 +2,7 @@ package flexibleengine
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/chnsz/golangsdk"
@@ -31,8 +32,23 @@ func TestAccDmsKafkaTopic_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(topicName, "aging_time", "36"),
 					resource.TestCheckResourceAttr(topicName, "sync_replication", "false"),
 					resource.TestCheckResourceAttr(topicName, "sync_flushing", "false"),
+					resource.TestCheckResourceAttr(topicName, "description", "created by acceptance test"),
 				),
 			},
+			{
+				Config: testAccDmsKafkaTopic_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKafkaTopicExists(topicName),
+					resource.TestCheckResourceAttr(topicName, "partitions", "20"),
+					resource.TestCheckResourceAttr(topicName, "aging_time", "72"),
+					resource.TestCheckResourceAttr(topicName, "sync_replication", "true"),
+					resource.TestCheckResourceAttr(topicName, "description", "updated by acceptance test"),
+				),
+			},
+			{
+				Config:      testAccDmsKafkaTopic_decrease(rName),
+				ExpectError: regexp.MustCompile("partitions can only be increased"),
+			},
 			{
 				ResourceName:      topicName,
 				ImportState:       true,
@@ -133,6 +149,37 @@ resource "flexibleengine_dms_kafka_topic" "topic" {
   name        = "%s"
   partitions  = 10
   aging_time  = 36
+  description = "created by acceptance test"
+}
+`, testAccDmsKafkaInstance_basic(rName), rName)
+}
+
+func testAccDmsKafkaTopic_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_dms_kafka_topic" "topic" {
+  instance_id      = flexibleengine_dms_kafka_instance.instance_1.id
+  name             = "%s"
+  partitions       = 20
+  aging_time       = 72
+  sync_replication = true
+  description      = "updated by acceptance test"
+}
+`, testAccDmsKafkaInstance_basic(rName), rName)
+}
+
+func testAccDmsKafkaTopic_decrease(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_dms_kafka_topic" "topic" {
+  instance_id      = flexibleengine_dms_kafka_instance.instance_1.id
+  name             = "%s"
+  partitions       = 15
+  aging_time       = 72
+  sync_replication = true
+  description      = "updated by acceptance test"
 }
 `, testAccDmsKafkaInstance_basic(rName), rName)
 }
@@ -31,6 +31,8 @@ func resourceDNSPtrRecordV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -57,7 +59,8 @@ func resourceDNSPtrRecordV2() *schema.Resource {
 				Default:      300,
 				ValidateFunc: validation.IntBetween(1, 2147483647),
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"address": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -74,7 +77,7 @@ func resourceDNSPtrRecordV2Create(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error creating FlexibleEngine DNS client: %s", err)
 	}
 
-	tagmap := d.Get("tags").(map[string]interface{})
+	tagmap := getResourceTags(d, config)
 	taglist := []ptrrecords.Tag{}
 	for k, v := range tagmap {
 		tag := ptrrecords.Tag{
@@ -150,8 +153,9 @@ func resourceDNSPtrRecordV2Read(d *schema.ResourceData, meta interface{}) error
 	// save tags
 	resourceTags, err := tags.Get(dnsClient, "DNS-ptr_record", d.Id()).Extract()
 	if err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] Error fetching FlexibleEngine DNS ptr record tags: %s", err)
 	}
@@ -202,7 +206,7 @@ func resourceDNSPtrRecordV2Update(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// update tags
-	tagErr := UpdateResourceTags(dnsClient, d, "DNS-ptr_record", d.Id())
+	tagErr := UpdateResourceTags(dnsClient, d, config, "DNS-ptr_record", d.Id())
 	if tagErr != nil {
 		return fmt.Errorf("Error updating tags of DNS PTR record %s: %s", d.Id(), tagErr)
 	}
@@ -32,6 +32,8 @@ func resourceDNSRecordSetV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -79,7 +81,8 @@ func resourceDNSRecordSetV2() *schema.Resource {
 				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -143,7 +146,7 @@ func resourceDNSRecordSetV2Create(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		resourceType, err := getDNSRecordSetTagType(zoneType)
 		if err != nil {
@@ -199,8 +202,9 @@ func resourceDNSRecordSetV2Read(d *schema.ResourceData, meta interface{}) error
 	if resourceType, err := getDNSRecordSetTagType(zoneType); err == nil {
 		resourceTags, err := tags.Get(dnsClient, resourceType, recordsetID).Extract()
 		if err == nil {
-			tagmap := tagsToMap(resourceTags.Tags)
-			d.Set("tags", tagmap)
+			if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+				return err
+			}
 		} else {
 			log.Printf("[WARN] Error fetching FlexibleEngine DNS record set tags: %s", err)
 		}
@@ -279,7 +283,7 @@ func resourceDNSRecordSetV2Update(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error getting resource type of DNS record set %s: %s", d.Id(), err)
 	}
 
-	tagErr := UpdateResourceTags(dnsClient, d, resourceType, recordsetID)
+	tagErr := UpdateResourceTags(dnsClient, d, config, resourceType, recordsetID)
 	if tagErr != nil {
 		return fmt.Errorf("Error updating tags of DNS record set %s: %s", d.Id(), tagErr)
 	}
@@ -30,6 +30,8 @@ func resourceDNSZoneV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -95,7 +97,8 @@ func resourceDNSZoneV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -210,7 +213,7 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		resourceType, err := getDNSZoneTagType(zoneType)
 		if err != nil {
@@ -255,8 +258,9 @@ func resourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 	if resourceType, err := getDNSZoneTagType(n.ZoneType); err == nil {
 		resourceTags, err := tags.Get(dnsClient, resourceType, d.Id()).Extract()
 		if err == nil {
-			tagmap := tagsToMap(resourceTags.Tags)
-			d.Set("tags", tagmap)
+			if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+				return err
+			}
 		} else {
 			log.Printf("[WARN] Error fetching FlexibleEngine DNS zone tags: %s", err)
 		}
@@ -388,7 +392,7 @@ func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error getting resource type of DNS zone %s: %s", d.Id(), err)
 	}
 
-	tagErr := UpdateResourceTags(dnsClient, d, resourceType, d.Id())
+	tagErr := UpdateResourceTags(dnsClient, d, config, resourceType, d.Id())
 	if tagErr != nil {
 		return fmt.Errorf("Error updating tags of DNS zone %s: %s", d.Id(), tagErr)
 	}
@@ -20,6 +20,9 @@ func resourceReplication() *schema.Resource {
 		Create: resourceReplicationCreate,
 		Read:   resourceReplicationRead,
 		Delete: resourceReplicationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		DeprecationMessage: "It has been deprecated",
 		Schema: map[string]*schema.Schema{
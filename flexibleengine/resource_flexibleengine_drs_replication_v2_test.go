@@ -25,6 +25,11 @@ func TestAccDRSV2Replication_basic(t *testing.T) {
 					testAccCheckDRSV2ReplicationExists("flexibleengine_drs_replication_v2.replication_1", &replication),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_drs_replication_v2.replication_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
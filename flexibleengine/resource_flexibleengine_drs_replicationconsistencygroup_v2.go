@@ -18,6 +18,9 @@ func resourceReplicationConsistencyGroup() *schema.Resource {
 		Read:   resourceReplicationConsistencyGroupRead,
 		Delete: resourceReplicationConsistencyGroupDelete,
 		Update: resourceReplicationConsistencyGroupUpdate,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		DeprecationMessage: "It has been deprecated",
 		Schema: map[string]*schema.Schema{
@@ -40,6 +40,11 @@ func TestAccDRSV2ReplicationConsistencyGroup_basic(t *testing.T) {
 						"The description of replicationconsistencygroup_1_updated"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_drs_replicationconsistencygroup_v2.replicationconsistencygroup_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
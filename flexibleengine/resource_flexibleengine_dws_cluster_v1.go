@@ -1,8 +1,10 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
@@ -15,6 +17,7 @@ func resourceDWSClusterV1() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDWSClusterV1Create,
 		Read:   resourceDWSClusterV1Read,
+		Update: resourceDWSClusterV1Update,
 		Delete: resourceDWSClusterV1Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -22,9 +25,21 @@ func resourceDWSClusterV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			// the DWS cluster only supports scaling out
+			if d.Id() != "" && d.HasChange("number_of_node") {
+				oldNum, newNum := d.GetChange("number_of_node")
+				if newNum.(int) < oldNum.(int) {
+					return fmt.Errorf("number_of_node can only be increased, the current value is %d", oldNum)
+				}
+			}
+			return nil
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -55,7 +70,6 @@ func resourceDWSClusterV1() *schema.Resource {
 			"number_of_node": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"user_name": {
@@ -67,7 +81,6 @@ func resourceDWSClusterV1() *schema.Resource {
 			"user_pwd": {
 				Type:      schema.TypeString,
 				Required:  true,
-				ForceNew:  true,
 				Sensitive: true,
 			},
 
@@ -96,7 +109,6 @@ func resourceDWSClusterV1() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -104,18 +116,17 @@ func resourceDWSClusterV1() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 							Computed: true,
-							ForceNew: true,
 						},
 
 						"public_bind_type": {
 							Type:     schema.TypeString,
 							Optional: true,
 							Computed: true,
-							ForceNew: true,
 						},
 					},
 				},
 			},
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 
 			// attributes
 			"endpoints": {
@@ -226,6 +237,8 @@ func resourceDWSClusterV1Create(d *schema.ResourceData, meta interface{}) error
 		SecurityGroupID:  d.Get("security_group_id").(string),
 		Port:             d.Get("port").(int),
 		PublicIp:         getPublicIP(d),
+
+		EnterpriseProjectId: config.GetEnterpriseProjectID(d),
 	}
 	log.Printf("[DEBUG] Create DWS-Cluster Options: %#v", opts)
 
@@ -295,10 +308,154 @@ func resourceDWSClusterV1Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("private_ip", r.PrivateIp)
 	d.Set("created", r.Created)
 	d.Set("updated", r.Updated)
+	d.Set("enterprise_project_id", r.EnterpriseProjectId)
+
+	return nil
+}
+
+func resourceDWSClusterV1Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	client, err := config.DwsV1Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine client: %s", err)
+	}
+
+	clusterID := d.Id()
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("number_of_node") {
+		oldNum, newNum := d.GetChange("number_of_node")
+		scaleOutCount := newNum.(int) - oldNum.(int)
+
+		log.Printf("[DEBUG] Scaling out DWS-Cluster %s with %d nodes", clusterID, scaleOutCount)
+		if _, err := cluster.Resize(client, clusterID, scaleOutCount); err != nil {
+			return fmt.Errorf("Error scaling out DWS-Cluster %s: %s", clusterID, err)
+		}
+
+		if err := waitForDWSClusterTaskCompleted(client, clusterID, newNum.(int), timeout); err != nil {
+			return fmt.Errorf("Error waiting for DWS-Cluster %s to scale out: %s", clusterID, err)
+		}
+	}
+
+	if d.HasChange("user_pwd") {
+		opts := cluster.ResetPasswordOpts{
+			NewPassword: d.Get("user_pwd").(string),
+		}
+
+		log.Printf("[DEBUG] Resetting the password of DWS-Cluster %s", clusterID)
+		if _, err := cluster.ResetPassword(client, clusterID, opts); err != nil {
+			return fmt.Errorf("Error resetting the password of DWS-Cluster %s: %s", clusterID, err)
+		}
+
+		if err := waitForDWSClusterTaskCompleted(client, clusterID, 0, timeout); err != nil {
+			return fmt.Errorf("Error waiting for the password of DWS-Cluster %s to be reset: %s", clusterID, err)
+		}
+	}
+
+	if d.HasChange("public_ip") {
+		if err := updateDWSClusterPublicIP(d, config, region); err != nil {
+			return err
+		}
+
+		if err := waitForDWSClusterTaskCompleted(client, clusterID, 0, timeout); err != nil {
+			return fmt.Errorf("Error waiting for the public IP of DWS-Cluster %s to be updated: %s", clusterID, err)
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "dws", clusterID); err != nil {
+			return err
+		}
+	}
+
+	return resourceDWSClusterV1Read(d, meta)
+}
+
+// updateDWSClusterPublicIP unbinds the old EIP and binds the new one through the DWS v2 API:
+// POST/DELETE /v2/{project_id}/clusters/{cluster_id}/eips/{eip_id}
+func updateDWSClusterPublicIP(d *schema.ResourceData, config *Config, region string) error {
+	client, err := config.NewServiceClient("dwsv2", region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine DWS v2 client: %s", err)
+	}
+
+	clusterID := d.Id()
+	oldEip, newEip := d.GetChange("public_ip.0.eip_id")
+	oldEipID := oldEip.(string)
+	newEipID := newEip.(string)
+	if oldEipID == newEipID {
+		return nil
+	}
+
+	if newEipID == "" && strings.EqualFold(d.Get("public_ip.0.public_bind_type").(string), "auto_assign") {
+		return fmt.Errorf("public_bind_type auto_assign is only supported when creating the DWS-Cluster, " +
+			"please specify eip_id to bind an existing EIP")
+	}
+
+	reqOpts := &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 202, 204},
+		MoreHeaders: cluster.RequestOpts.MoreHeaders,
+	}
+
+	if oldEipID != "" {
+		log.Printf("[DEBUG] Unbinding EIP %s from DWS-Cluster %s", oldEipID, clusterID)
+		url := client.ServiceURL("clusters", clusterID, "eips", oldEipID)
+		if _, err := client.Delete(url, reqOpts); err != nil {
+			return fmt.Errorf("Error unbinding EIP %s from DWS-Cluster %s: %s", oldEipID, clusterID, err)
+		}
+	}
+
+	if newEipID != "" {
+		log.Printf("[DEBUG] Binding EIP %s to DWS-Cluster %s", newEipID, clusterID)
+		url := client.ServiceURL("clusters", clusterID, "eips", newEipID)
+		if _, err := client.Post(url, nil, nil, reqOpts); err != nil {
+			return fmt.Errorf("Error binding EIP %s to DWS-Cluster %s: %s", newEipID, clusterID, err)
+		}
+	}
 
 	return nil
 }
 
+// waitForDWSClusterTaskCompleted waits until the cluster is AVAILABLE and has no running task,
+// and if nodeNum is greater than 0, the number of nodes is equal to nodeNum.
+func waitForDWSClusterTaskCompleted(client *golangsdk.ServiceClient, clusterID string, nodeNum int,
+	timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"COMPLETED"},
+		Pending:    []string{"PENDING"},
+		Refresh:    getDWSClusterTaskStatus(client, clusterID, nodeNum),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func getDWSClusterTaskStatus(client *golangsdk.ServiceClient, clusterID string, nodeNum int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		r, err := cluster.Get(client, clusterID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] DWS-Cluster %s: status=%s, task_status=%s, number_of_node=%d",
+			clusterID, r.Status, r.TaskStatus, r.NumberOfNode)
+		if strings.HasSuffix(r.TaskStatus, "FAILURE") {
+			return r, r.TaskStatus, fmt.Errorf("the task of DWS-Cluster %s failed: %s", clusterID, r.TaskStatus)
+		}
+		if r.Status != "AVAILABLE" || r.TaskStatus != "" {
+			return r, "PENDING", nil
+		}
+		if nodeNum > 0 && r.NumberOfNode != nodeNum {
+			return r, "PENDING", nil
+		}
+
+		return r, "COMPLETED", nil
+	}
+}
+
 func resourceDWSClusterV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.DwsV1Client(GetRegion(d, config))
@@ -25,6 +25,16 @@ func TestDWSClusterBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testDWSClusterExists(resourceName, &ar),
 					resource.TestCheckResourceAttr(resourceName, "name", "cluster-"+rName),
+					resource.TestCheckResourceAttr(resourceName, "number_of_node", "3"),
+				),
+			},
+			{
+				Config: testDWSClusterUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testDWSClusterExists(resourceName, &ar),
+					resource.TestCheckResourceAttr(resourceName, "number_of_node", "4"),
+					resource.TestCheckResourceAttr(resourceName, "status", "AVAILABLE"),
+					resource.TestCheckResourceAttr(resourceName, "task_status", ""),
 				),
 			},
 		},
@@ -83,6 +93,14 @@ func testDWSClusterExists(n string, ar *cluster.Cluster) resource.TestCheckFunc
 }
 
 func testDWSClusterBasic(name string) string {
+	return testDWSClusterConfig(name, 3, "cluster123@!")
+}
+
+func testDWSClusterUpdate(name string) string {
+	return testDWSClusterConfig(name, 4, "cluster456@!")
+}
+
+func testDWSClusterConfig(name string, nodeNum int, password string) string {
 	return fmt.Sprintf(`
 data "flexibleengine_dws_flavors" "test" {
   availability_zone = "%[2]s"
@@ -97,12 +115,12 @@ resource "flexibleengine_dws_cluster_v1" "cluster" {
   name              = "cluster-%[1]s"
   availability_zone = "%[2]s"
   node_type         = data.flexibleengine_dws_flavors.test.flavors[0].flavor_id
-  number_of_node    = 3
+  number_of_node    = %[3]d
   user_name         = "test_cluster_admin"
-  user_pwd          = "cluster123@!"
-  vpc_id            = "%s"
-  subnet_id         = "%s"
+  user_pwd          = "%[4]s"
+  vpc_id            = "%[5]s"
+  subnet_id         = "%[6]s"
   security_group_id = flexibleengine_networking_secgroup_v2.secgroup.id
 }
-`, name, OS_AVAILABILITY_ZONE, OS_VPC_ID, OS_NETWORK_ID)
+`, name, OS_AVAILABILITY_ZONE, nodeNum, password, OS_VPC_ID, OS_NETWORK_ID)
 }
@@ -3,6 +3,7 @@ package flexibleengine
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +19,10 @@ func resourceBackend() *schema.Resource {
 		Read:   resourceBackendRead,
 		Delete: resourceBackendDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceBackendImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -149,3 +154,19 @@ func resourceBackendDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Successfully deleted backend member %s", id)
 	return nil
 }
+
+func resourceBackendImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		err := fmt.Errorf("Invalid format specified for backend member. Format must be <listener id>/<backend id>")
+		return nil, err
+	}
+
+	listenerID := parts[0]
+	backendID := parts[1]
+
+	d.SetId(backendID)
+	d.Set("listener_id", listenerID)
+
+	return []*schema.ResourceData{d}, nil
+}
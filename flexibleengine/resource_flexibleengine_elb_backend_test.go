@@ -12,6 +12,7 @@ import (
 
 func TestAccELBBackend_basic(t *testing.T) {
 	var backend backendmember.Backend
+	resourceName := "flexibleengine_elb_backend.backend_flexibleengine_acctest"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheckDeprecated(t) },
@@ -21,13 +22,34 @@ func TestAccELBBackend_basic(t *testing.T) {
 			{
 				Config: TestAccELBBackendConfig_basic,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckELBBackendExists("flexibleengine_elb_backend.backend_flexibleengine_acctest", &backend),
+					testAccCheckELBBackendExists(resourceName, &backend),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccELBBackendImportStateIdFunc(resourceName),
+			},
 		},
 	})
 }
 
+func testAccELBBackendImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		backend, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("ELB backend not found: %s", name)
+		}
+
+		listenerID := backend.Primary.Attributes["listener_id"]
+		if listenerID == "" || backend.Primary.ID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", listenerID, backend.Primary.ID)
+		}
+		return fmt.Sprintf("%s/%s", listenerID, backend.Primary.ID), nil
+	}
+}
+
 func testAccCheckELBBackendDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	client, err := otcV1Client(config, OS_REGION_NAME)
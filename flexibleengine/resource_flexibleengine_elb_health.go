@@ -17,6 +17,10 @@ func resourceHealth() *schema.Resource {
 		Update: resourceHealthUpdate,
 		Delete: resourceHealthDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -117,7 +121,7 @@ func resourceHealthRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("listener_id", health.ListenerID)
 	d.Set("healthcheck_protocol", health.HealthcheckProtocol)
 	d.Set("healthcheck_uri", health.HealthcheckUri)
-	d.Set("healtcheck_connect_port", health.HealthcheckConnectPort)
+	d.Set("healthcheck_connect_port", health.HealthcheckConnectPort)
 	d.Set("healthy_threshold", health.HealthyThreshold)
 	d.Set("unhealthy_threshold", health.UnhealthyThreshold)
 	d.Set("healthcheck_timeout", health.HealthcheckTimeout)
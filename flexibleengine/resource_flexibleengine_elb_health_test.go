@@ -34,6 +34,11 @@ func TestAccELBHealth_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("flexibleengine_elb_health.health_1", "healthcheck_timeout", "15"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_elb_health.health_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -29,6 +29,10 @@ func resourceEListener() *schema.Resource {
 		Update: resourceEListenerUpdate,
 		Delete: resourceEListenerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -215,7 +219,7 @@ func resourceEListenerRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("backend_protocol", listener.BackendProtocol)
 	d.Set("session_sticky_type", listener.StickySessionType)
 	d.Set("description", listener.Description)
-	d.Set("load_balancer_id", listener.LoadbalancerID)
+	d.Set("loadbalancer_id", listener.LoadbalancerID)
 	d.Set("protocol", listener.Protocol)
 	d.Set("protocol_port", listener.ProtocolPort)
 	d.Set("cookie_timeout", listener.CookieTimeout)
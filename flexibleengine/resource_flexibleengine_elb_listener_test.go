@@ -30,6 +30,11 @@ func TestAccELBListener_basic(t *testing.T) {
 						"flexibleengine_elb_listener.listener_1", "name", "listener_1_updated"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_elb_listener.listener_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -18,6 +18,10 @@ func resourceELoadBalancer() *schema.Resource {
 		Update: resourceELoadBalancerUpdate,
 		Delete: resourceELoadBalancerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -177,6 +181,7 @@ func resourceELoadBalancerRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("admin_state_up", basu)
 	d.Set("vip_subnet_id", lb.VipSubnetID)
 	d.Set("vip_address", lb.VipAddress)
+	d.Set("az", lb.AZ)
 	secgroup_Id := lb.SecurityGroupID
 	// flexibleengine will return empty string of security_group_id when lb type is External
 	if lb.Type == "External" {
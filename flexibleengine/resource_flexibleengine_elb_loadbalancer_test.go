@@ -33,6 +33,11 @@ func TestAccELBLoadBalancer_basic(t *testing.T) {
 						"flexibleengine_elb_loadbalancer.loadbalancer_1", "name", "loadbalancer_1_updated"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_elb_loadbalancer.loadbalancer_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
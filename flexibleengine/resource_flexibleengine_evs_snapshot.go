@@ -0,0 +1,213 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/snapshots"
+)
+
+func resourceEvsSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEvsSnapshotCreate,
+		Read:   resourceEvsSnapshotRead,
+		Update: resourceEvsSnapshotUpdate,
+		Delete: resourceEvsSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEvsSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	createOpts := snapshots.CreateOpts{
+		VolumeID:    d.Get("volume_id").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Metadata:    resourceContainerMetadataV2(d),
+		Force:       d.Get("force").(bool),
+	}
+
+	log.Printf("[DEBUG] Create EVS snapshot options: %#v", createOpts)
+	snapshot, err := snapshots.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine EVS snapshot: %s", err)
+	}
+	d.SetId(snapshot.ID)
+
+	log.Printf("[DEBUG] Waiting for EVS snapshot (%s) to become available", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    evsSnapshotStateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for EVS snapshot (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceEvsSnapshotRead(d, meta)
+}
+
+func resourceEvsSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	blockStorageClient, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	snapshot, err := snapshots.Get(blockStorageClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "EVS snapshot")
+	}
+	log.Printf("[DEBUG] Retrieved EVS snapshot %s: %+v", d.Id(), snapshot)
+
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("volume_id", snapshot.VolumeID),
+		d.Set("name", snapshot.Name),
+		d.Set("description", snapshot.Description),
+		d.Set("metadata", snapshot.Metadata),
+		d.Set("status", snapshot.Status),
+		d.Set("size", snapshot.Size),
+		d.Set("created_at", snapshot.CreatedAt.Format(time.RFC3339)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmt.Errorf("Error setting EVS snapshot fields: %s", err)
+	}
+	return nil
+}
+
+func resourceEvsSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	updateOpts := snapshots.UpdateOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	log.Printf("[DEBUG] Update EVS snapshot %s options: %#v", d.Id(), updateOpts)
+	if _, err := snapshots.Update(blockStorageClient, d.Id(), updateOpts).Extract(); err != nil {
+		return fmt.Errorf("Error updating FlexibleEngine EVS snapshot %s: %s", d.Id(), err)
+	}
+
+	return resourceEvsSnapshotRead(d, meta)
+}
+
+func resourceEvsSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	if err := snapshots.Delete(blockStorageClient, d.Id()).ExtractErr(); err != nil {
+		return CheckDeleted(d, err, "EVS snapshot")
+	}
+
+	log.Printf("[DEBUG] Waiting for EVS snapshot (%s) to delete", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting", "available"},
+		Target:     []string{"deleted"},
+		Refresh:    evsSnapshotStateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for EVS snapshot (%s) to delete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func evsSnapshotStateRefreshFunc(client *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		snapshot, err := snapshots.Get(client, id).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return snapshot, "deleted", nil
+			}
+			return nil, "", err
+		}
+
+		if snapshot.Status == "error" || snapshot.Status == "error_deleting" {
+			return snapshot, snapshot.Status, fmt.Errorf("the EVS snapshot is in %s status", snapshot.Status)
+		}
+		return snapshot, snapshot.Status, nil
+	}
+}
@@ -0,0 +1,115 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+)
+
+// resourceEvsSnapshotRollback is a one-time action resource, the volume is restored from the snapshot
+// when the resource is created, and nothing happens when it is deleted.
+func resourceEvsSnapshotRollback() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEvsSnapshotRollbackCreate,
+		Read:   resourceEvsSnapshotRollbackRead,
+		Delete: resourceEvsSnapshotRollbackDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+type evsSnapshotRollbackOpts struct {
+	Rollback evsSnapshotRollbackSpec `json:"rollback"`
+}
+
+type evsSnapshotRollbackSpec struct {
+	VolumeID string `json:"volume_id"`
+	Name     string `json:"name,omitempty"`
+}
+
+func resourceEvsSnapshotRollbackCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	snapshotID := d.Get("snapshot_id").(string)
+	volumeID := d.Get("volume_id").(string)
+	opts := evsSnapshotRollbackOpts{
+		Rollback: evsSnapshotRollbackSpec{
+			VolumeID: volumeID,
+			Name:     d.Get("volume_name").(string),
+		},
+	}
+
+	log.Printf("[DEBUG] Rollback EVS volume %s from snapshot %s: %#v", volumeID, snapshotID, opts)
+	url := blockStorageClient.ServiceURL("cloudsnapshots", snapshotID, "rollback")
+	_, err = blockStorageClient.Post(url, opts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		return fmt.Errorf("Error rolling back FlexibleEngine EVS volume %s from snapshot %s: %s",
+			volumeID, snapshotID, err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", snapshotID, volumeID))
+
+	log.Printf("[DEBUG] Waiting for EVS volume (%s) to be restored", volumeID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"restoring-backup", "restoring"},
+		Target:     []string{"available"},
+		Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, volumeID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for EVS volume (%s) to be restored: %s", volumeID, err)
+	}
+
+	return resourceEvsSnapshotRollbackRead(d, meta)
+}
+
+func resourceEvsSnapshotRollbackRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	return d.Set("region", GetRegion(d, config))
+}
+
+func resourceEvsSnapshotRollbackDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Deleting the rollback resource %s only removes it from the state", d.Id())
+	d.SetId("")
+	return nil
+}
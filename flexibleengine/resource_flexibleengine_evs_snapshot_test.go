@@ -0,0 +1,164 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/evs/v2/snapshots"
+)
+
+func TestAccEvsSnapshot_basic(t *testing.T) {
+	var snapshot snapshots.Snapshot
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_evs_snapshot.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEvsSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEvsSnapshot_basic(rName, "created by acc test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEvsSnapshotExists(resourceName, &snapshot),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "created by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+					resource.TestCheckResourceAttrPair(resourceName, "volume_id",
+						"flexibleengine_blockstorage_volume_v2.test", "id"),
+				),
+			},
+			{
+				Config: testAccEvsSnapshot_basic(rName+"-update", "updated by acc test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &snapshot.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated by acc test"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force",
+				},
+			},
+		},
+	})
+}
+
+func TestAccEvsSnapshot_rollback(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_evs_snapshot_rollback.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEvsSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEvsSnapshot_rollback(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "snapshot_id",
+						"flexibleengine_evs_snapshot.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "volume_id",
+						"flexibleengine_blockstorage_volume_v2.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckEvsSnapshotDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	blockStorageClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "flexibleengine_evs_snapshot" {
+			continue
+		}
+
+		_, err := snapshots.Get(blockStorageClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("EVS snapshot still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckEvsSnapshotExists(n string, snapshot *snapshots.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		blockStorageClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine block storage client: %s", err)
+		}
+
+		found, err := snapshots.Get(blockStorageClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("EVS snapshot not found")
+		}
+
+		*snapshot = *found
+		return nil
+	}
+}
+
+func testAccEvsSnapshot_base(rName string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_blockstorage_volume_v2" "test" {
+  name = "%s"
+  size = 10
+}
+`, rName)
+}
+
+func testAccEvsSnapshot_basic(rName, description string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_evs_snapshot" "test" {
+  volume_id   = flexibleengine_blockstorage_volume_v2.test.id
+  name        = "%s"
+  description = "%s"
+}
+`, testAccEvsSnapshot_base(rName), rName, description)
+}
+
+func testAccEvsSnapshot_rollback(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_evs_snapshot" "test" {
+  volume_id = flexibleengine_blockstorage_volume_v2.test.id
+  name      = "%s"
+}
+
+resource "flexibleengine_evs_snapshot_rollback" "test" {
+  snapshot_id = flexibleengine_evs_snapshot.test.id
+  volume_id   = flexibleengine_blockstorage_volume_v2.test.id
+}
+`, testAccEvsSnapshot_base(rName), rName)
+}
@@ -18,6 +18,10 @@ func resourceCertificateV2() *schema.Resource {
 		Update: resourceCertificateV2Update,
 		Delete: resourceCertificateV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -31,6 +31,15 @@ func TestAccLBV2Certificate_basic(t *testing.T) {
 						"flexibleengine_lb_certificate_v2.certificate_1", "name", "certificate_1_updated"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_lb_certificate_v2.certificate_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"private_key",
+					"certificate",
+				},
+			},
 		},
 	})
 }
@@ -24,12 +24,18 @@ func resourceListenerV2() *schema.Resource {
 		Update: resourceListenerUpdate,
 		Delete: resourceListenerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -121,7 +127,8 @@ func resourceListenerV2() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validation.IntBetween(1, 300),
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"tenant_id": {
 				Type:       schema.TypeString,
@@ -216,7 +223,7 @@ func resourceListenerCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(listener.ID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
 		if tagErr := tags.Create(lbClient, "listeners", listener.ID, taglist).ExtractErr(); tagErr != nil {
@@ -261,15 +268,20 @@ func resourceListenerRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("idle_timeout", listener.KeepaliveTimeout),
 		d.Set("request_timeout", listener.ClientTimeout),
 		d.Set("response_timeout", listener.MemberTimeout),
+		d.Set("admin_state_up", listener.AdminStateUp),
 	)
+	if len(listener.Loadbalancers) > 0 {
+		mErr = multierror.Append(mErr, d.Set("loadbalancer_id", listener.Loadbalancers[0].ID))
+	}
 	if mErr.ErrorOrNil() != nil {
 		return mErr
 	}
 
 	// fetch tags
 	if resourceTags, err := tags.Get(lbClient, "listeners", d.Id()).Extract(); err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] fetching tags of elb listener failed: %s", err)
 	}
@@ -362,8 +374,8 @@ func resourceListenerUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// update tags
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(lbClient, d, "listeners", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(lbClient, d, config, "listeners", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of elb listener:%s, err:%s", d.Id(), tagErr)
 		}
@@ -30,6 +30,8 @@ func resourceLoadBalancerV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -70,6 +72,8 @@ func resourceLoadBalancerV2() *schema.Resource {
 			},
 
 			"tags": tagsSchema(),
+
+			"tags_all": tagsAllSchema(),
 			"loadbalancer_provider": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -161,7 +165,7 @@ func resourceLoadBalancerV2Create(d *schema.ResourceData, meta interface{}) erro
 	d.SetId(lb.ID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
 		if tagErr := tags.Create(lbClient, "loadbalancers", lb.ID, taglist).ExtractErr(); tagErr != nil {
@@ -217,8 +221,9 @@ func resourceLoadBalancerV2Read(d *schema.ResourceData, meta interface{}) error
 
 	// fetch tags
 	if resourceTags, err := tags.Get(lbClient, "loadbalancers", d.Id()).Extract(); err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] fetching tags of elb loadbalancer failed: %s", err)
 	}
@@ -286,8 +291,8 @@ func resourceLoadBalancerV2Update(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// update tags
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(lbClient, d, "loadbalancers", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(lbClient, d, config, "loadbalancers", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of load balancer:%s, err:%s", d.Id(), tagErr)
 		}
@@ -34,10 +34,31 @@ func TestAccLBV2Member_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("flexibleengine_lb_member_v2.member_2", "weight", "15"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_lb_member_v2.member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccLBV2MemberImportStateIdFunc("flexibleengine_lb_member_v2.member_1"),
+			},
 		},
 	})
 }
 
+func testAccLBV2MemberImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		member, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("LB member not found: %s", name)
+		}
+
+		poolID := member.Primary.Attributes["pool_id"]
+		if poolID == "" || member.Primary.ID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", poolID, member.Primary.ID)
+		}
+		return fmt.Sprintf("%s/%s", poolID, member.Primary.ID), nil
+	}
+}
+
 func testAccCheckLBV2MemberDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	lbClient, err := config.ElbV2Client(OS_REGION_NAME)
@@ -16,6 +16,10 @@ func resourceWhitelistV2() *schema.Resource {
 		Update: resourceWhitelistV2Update,
 		Delete: resourceWhitelistV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -29,6 +29,11 @@ func TestAccLBV2Whitelist_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("flexibleengine_lb_whitelist_v2.whitelist_1", "enable_whitelist", "true"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_lb_whitelist_v2.whitelist_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -6,12 +6,14 @@ import (
 
 	"github.com/chnsz/golangsdk/openstack/lts/huawei/loggroups"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceLTSGroupV2() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceLTSGroupV2Create,
 		Read:   resourceLTSGroupV2Read,
+		Update: resourceLTSGroupV2Update,
 		Delete: resourceLTSGroupV2Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -30,8 +32,10 @@ func resourceLTSGroupV2() *schema.Resource {
 				ForceNew: true,
 			},
 			"ttl_in_days": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 365),
 			},
 		},
 	}
@@ -46,7 +50,10 @@ func resourceLTSGroupV2Create(d *schema.ResourceData, meta interface{}) error {
 
 	createOpts := &loggroups.CreateOpts{
 		LogGroupName: d.Get("group_name").(string),
-		TTL:          7, // fixed to 7days
+		TTL:          7,
+	}
+	if ttl, ok := d.GetOk("ttl_in_days"); ok {
+		createOpts.TTL = ttl.(int)
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
@@ -88,6 +95,28 @@ func resourceLTSGroupV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func resourceLTSGroupV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.LtsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	if d.HasChange("ttl_in_days") {
+		updateOpts := loggroups.UpdateOpts{
+			TTL: d.Get("ttl_in_days").(int),
+		}
+
+		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		_, err = loggroups.Update(client, updateOpts, d.Id()).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating log group %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceLTSGroupV2Read(d, meta)
+}
+
 func resourceLTSGroupV2Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.LtsV2Client(GetRegion(d, config))
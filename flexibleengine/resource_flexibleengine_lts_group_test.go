@@ -28,6 +28,14 @@ func TestAccLTSGroupV2_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "ttl_in_days", "7"),
 				),
 			},
+			{
+				Config: testAccLTSGroupV2_update(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLTSGroupV2Exists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "group_name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "ttl_in_days", "30"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
@@ -102,3 +110,12 @@ resource "flexibleengine_lts_group" "testacc_group" {
 }
 `, name)
 }
+
+func testAccLTSGroupV2_update(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_lts_group" "testacc_group" {
+  group_name  = "%s"
+  ttl_in_days = 30
+}
+`, name)
+}
@@ -0,0 +1,315 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ltsDefaultTokenizer is the default delimiter set of the LTS index
+const ltsDefaultTokenizer = ", '\";=()[]{}@&<>/:\\?\n\t\r"
+
+type ltsFullTextIndex struct {
+	Enable         bool   `json:"enable"`
+	CaseSensitive  bool   `json:"caseSensitive"`
+	IncludeChinese bool   `json:"includeChinese"`
+	Tokenizer      string `json:"tokenizer"`
+}
+
+type ltsIndexField struct {
+	FieldType      string `json:"fieldType"`
+	FieldName      string `json:"fieldName"`
+	CaseSensitive  bool   `json:"caseSensitive"`
+	IncludeChinese bool   `json:"includeChinese"`
+	Tokenizer      string `json:"tokenizer"`
+	QuickAnalysis  bool   `json:"quickAnalysis"`
+}
+
+type ltsIndexConfig struct {
+	LogStreamID   string            `json:"logStreamId,omitempty"`
+	FullTextIndex *ltsFullTextIndex `json:"fullTextIndex,omitempty"`
+	Fields        []ltsIndexField   `json:"fields"`
+}
+
+func resourceLTSIndexConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLTSIndexConfigurationCreate,
+		Read:   resourceLTSIndexConfigurationRead,
+		Update: resourceLTSIndexConfigurationUpdate,
+		Delete: resourceLTSIndexConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLTSIndexConfigurationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"topic_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"full_text_index": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"case_sensitive": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"include_chinese": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"tokenizer": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  ltsDefaultTokenizer,
+						},
+					},
+				},
+			},
+			"fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"field_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "string",
+							ValidateFunc: validation.StringInSlice([]string{
+								"string", "long", "float",
+							}, false),
+						},
+						"case_sensitive": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"include_chinese": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"tokenizer": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"quick_analysis": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ltsIndexConfigURL(client *golangsdk.ServiceClient, groupID, topicID string) string {
+	// the index configuration API only exists in v1.0
+	return fmt.Sprintf("%sv1.0/%s/groups/%s/stream/%s/index/config", client.Endpoint, client.ProjectID, groupID, topicID)
+}
+
+func buildLTSIndexConfig(d *schema.ResourceData) *ltsIndexConfig {
+	opts := ltsIndexConfig{
+		LogStreamID: d.Get("topic_id").(string),
+		FullTextIndex: &ltsFullTextIndex{
+			Enable:    true,
+			Tokenizer: ltsDefaultTokenizer,
+		},
+		Fields: []ltsIndexField{},
+	}
+
+	if rawList := d.Get("full_text_index").([]interface{}); len(rawList) > 0 && rawList[0] != nil {
+		raw := rawList[0].(map[string]interface{})
+		opts.FullTextIndex = &ltsFullTextIndex{
+			Enable:         raw["enabled"].(bool),
+			CaseSensitive:  raw["case_sensitive"].(bool),
+			IncludeChinese: raw["include_chinese"].(bool),
+			Tokenizer:      raw["tokenizer"].(string),
+		}
+	}
+
+	for _, v := range d.Get("fields").([]interface{}) {
+		raw := v.(map[string]interface{})
+		field := ltsIndexField{
+			FieldName:      raw["field_name"].(string),
+			FieldType:      raw["field_type"].(string),
+			CaseSensitive:  raw["case_sensitive"].(bool),
+			IncludeChinese: raw["include_chinese"].(bool),
+			Tokenizer:      raw["tokenizer"].(string),
+			QuickAnalysis:  raw["quick_analysis"].(bool),
+		}
+		if field.Tokenizer == "" {
+			field.Tokenizer = ltsDefaultTokenizer
+		}
+		opts.Fields = append(opts.Fields, field)
+	}
+
+	return &opts
+}
+
+func flattenLTSIndexFields(fields []ltsIndexField) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(fields))
+	for i, field := range fields {
+		result[i] = map[string]interface{}{
+			"field_name":      field.FieldName,
+			"field_type":      field.FieldType,
+			"case_sensitive":  field.CaseSensitive,
+			"include_chinese": field.IncludeChinese,
+			"tokenizer":       field.Tokenizer,
+			"quick_analysis":  field.QuickAnalysis,
+		}
+	}
+	return result
+}
+
+func resourceLTSIndexConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.LtsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	groupID := d.Get("group_id").(string)
+	topicID := d.Get("topic_id").(string)
+	createOpts := buildLTSIndexConfig(d)
+
+	// the log topic always has a default index configuration, so we modify it instead of creating a new one
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	_, err = client.Put(ltsIndexConfigURL(client, groupID, topicID), createOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating the index configuration of log topic %s: %s", topicID, err)
+	}
+
+	d.SetId(topicID)
+	return resourceLTSIndexConfigurationRead(d, meta)
+}
+
+func resourceLTSIndexConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	client, err := config.LtsV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	groupID := d.Get("group_id").(string)
+	var indexConfig ltsIndexConfig
+	_, err = client.Get(ltsIndexConfigURL(client, groupID, d.Id()), &indexConfig, nil)
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving the index configuration of log topic")
+	}
+
+	log.Printf("[DEBUG] Retrieved the index configuration of log topic %s: %#v", d.Id(), indexConfig)
+	d.Set("region", region)
+	d.Set("topic_id", d.Id())
+	if indexConfig.FullTextIndex != nil {
+		fullTextIndex := []map[string]interface{}{
+			{
+				"enabled":         indexConfig.FullTextIndex.Enable,
+				"case_sensitive":  indexConfig.FullTextIndex.CaseSensitive,
+				"include_chinese": indexConfig.FullTextIndex.IncludeChinese,
+				"tokenizer":       indexConfig.FullTextIndex.Tokenizer,
+			},
+		}
+		d.Set("full_text_index", fullTextIndex)
+	}
+	if err := d.Set("fields", flattenLTSIndexFields(indexConfig.Fields)); err != nil {
+		return fmt.Errorf("Error saving fields of the LTS index configuration: %s", err)
+	}
+
+	return nil
+}
+
+func resourceLTSIndexConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.LtsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	groupID := d.Get("group_id").(string)
+	updateOpts := buildLTSIndexConfig(d)
+
+	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+	_, err = client.Put(ltsIndexConfigURL(client, groupID, d.Id()), updateOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating the index configuration of log topic %s: %s", d.Id(), err)
+	}
+
+	return resourceLTSIndexConfigurationRead(d, meta)
+}
+
+func resourceLTSIndexConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.LtsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	// the index configuration can not be deleted, so we restore it to the default one:
+	// full-text index is enabled and there is no field index.
+	groupID := d.Get("group_id").(string)
+	resetOpts := ltsIndexConfig{
+		LogStreamID: d.Id(),
+		FullTextIndex: &ltsFullTextIndex{
+			Enable:    true,
+			Tokenizer: ltsDefaultTokenizer,
+		},
+		Fields: []ltsIndexField{},
+	}
+
+	_, err = client.Put(ltsIndexConfigURL(client, groupID, d.Id()), resetOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return CheckDeleted(d, err, "Error resetting the index configuration of log topic")
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceLTSIndexConfigurationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		err := fmt.Errorf("Invalid format specified for LTS index configuration. Format must be <group id>/<topic id>")
+		return nil, err
+	}
+
+	d.SetId(parts[1])
+	d.Set("group_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
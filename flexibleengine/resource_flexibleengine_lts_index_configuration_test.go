@@ -0,0 +1,139 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLTSIndexConfiguration_basic(t *testing.T) {
+	var indexConfig ltsIndexConfig
+	rand := acctest.RandString(5)
+	resourceName := "flexibleengine_lts_index_configuration.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLTSTopicV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLTSIndexConfiguration_basic(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLTSIndexConfigurationExists(resourceName, &indexConfig),
+					resource.TestCheckResourceAttrPair(resourceName, "topic_id", "flexibleengine_lts_topic.topic_1", "id"),
+					resource.TestCheckResourceAttr(resourceName, "full_text_index.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "fields.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "fields.0.field_name", "srcip"),
+					resource.TestCheckResourceAttr(resourceName, "fields.0.quick_analysis", "true"),
+				),
+			},
+			{
+				Config: testAccLTSIndexConfiguration_update(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLTSIndexConfigurationExists(resourceName, &indexConfig),
+					resource.TestCheckResourceAttr(resourceName, "full_text_index.0.enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "fields.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "fields.1.field_name", "bytes"),
+					resource.TestCheckResourceAttr(resourceName, "fields.1.field_type", "long"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccLTSIndexConfigurationImportStateIDFunc(resourceName),
+			},
+		},
+	})
+}
+
+func testAccCheckLTSIndexConfigurationExists(n string, indexConfig *ltsIndexConfig) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		ltsclient, err := config.LtsV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+		}
+
+		groupID := rs.Primary.Attributes["group_id"]
+		var found ltsIndexConfig
+		_, err = ltsclient.Get(ltsIndexConfigURL(ltsclient, groupID, rs.Primary.ID), &found, nil)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the index configuration of LTS topic %s: %s", rs.Primary.ID, err)
+		}
+
+		*indexConfig = found
+		return nil
+	}
+}
+
+func testAccLTSIndexConfigurationImportStateIDFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("LTS index configuration not found")
+		}
+
+		groupID := rs.Primary.Attributes["group_id"]
+		if groupID == "" || rs.Primary.ID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", groupID, rs.Primary.ID)
+		}
+		return fmt.Sprintf("%s/%s", groupID, rs.Primary.ID), nil
+	}
+}
+
+func testAccLTSIndexConfiguration_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_lts_index_configuration" "test" {
+  group_id = flexibleengine_lts_group.group_1.id
+  topic_id = flexibleengine_lts_topic.topic_1.id
+
+  full_text_index {
+    enabled = true
+  }
+
+  fields {
+    field_name     = "srcip"
+    quick_analysis = true
+  }
+}
+`, testAccLTSTopicV2_basic(name))
+}
+
+func testAccLTSIndexConfiguration_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_lts_index_configuration" "test" {
+  group_id = flexibleengine_lts_group.group_1.id
+  topic_id = flexibleengine_lts_topic.topic_1.id
+
+  full_text_index {
+    enabled = false
+  }
+
+  fields {
+    field_name     = "srcip"
+    quick_analysis = true
+  }
+  fields {
+    field_name = "bytes"
+    field_type = "long"
+  }
+}
+`, testAccLTSTopicV2_basic(name))
+}
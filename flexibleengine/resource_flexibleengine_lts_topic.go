@@ -8,12 +8,20 @@ import (
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/lts/huawei/logstreams"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// ltsTopic is the log stream object with the log expiration time, which is missing in logstreams.LogStream
+type ltsTopic struct {
+	logstreams.LogStream
+	TTL int `json:"ttl_in_days"`
+}
+
 func resourceLTSTopicV2() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceLTSTopicV2Create,
 		Read:   resourceLTSTopicV2Read,
+		Update: resourceLTSTopicV2Update,
 		Delete: resourceLTSTopicV2Delete,
 		Importer: &schema.ResourceImporter{
 			State: resourceLTSTopicV2Import,
@@ -36,6 +44,12 @@ func resourceLTSTopicV2() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"ttl_in_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 365),
+			},
 
 			"filter_count": {
 				Type:     schema.TypeInt,
@@ -60,6 +74,7 @@ func resourceLTSTopicV2Create(d *schema.ResourceData, meta interface{}) error {
 	groupID := d.Get("group_id").(string)
 	createOpts := &logstreams.CreateOpts{
 		LogStreamName: d.Get("topic_name").(string),
+		TTL:           d.Get("ttl_in_days").(int),
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
@@ -82,7 +97,10 @@ func resourceLTSTopicV2Read(d *schema.ResourceData, meta interface{}) error {
 
 	topicID := d.Id()
 	groupID := d.Get("group_id").(string)
-	streams, err := logstreams.List(client, groupID).Extract()
+	var streams struct {
+		LogStreams []ltsTopic `json:"log_streams"`
+	}
+	err = logstreams.List(client, groupID).ExtractInto(&streams)
 	if err != nil {
 		if _, ok := err.(golangsdk.ErrDefault400); ok {
 			log.Printf("[WARN] log group topic %s: the log group %s is gone", topicID, groupID)
@@ -100,6 +118,10 @@ func resourceLTSTopicV2Read(d *schema.ResourceData, meta interface{}) error {
 			d.Set("region", region)
 			d.Set("topic_name", stream.Name)
 			d.Set("filter_count", stream.FilterCount)
+			// the log topic inherits the log expiration time of the log group when it was not specified
+			if stream.TTL != 0 {
+				d.Set("ttl_in_days", stream.TTL)
+			}
 			return nil
 		}
 	}
@@ -110,6 +132,33 @@ func resourceLTSTopicV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func resourceLTSTopicV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	client, err := config.LtsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine LTS client: %s", err)
+	}
+
+	if d.HasChange("ttl_in_days") {
+		groupID := d.Get("group_id").(string)
+		updateURL := client.ServiceURL("groups", groupID, "streams-ttl", d.Id())
+		updateOpts := map[string]interface{}{
+			"ttl_in_days": d.Get("ttl_in_days").(int),
+		}
+
+		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		_, err = client.Put(updateURL, updateOpts, nil, &golangsdk.RequestOpts{
+			OkCodes:     []int{200, 204},
+			MoreHeaders: map[string]string{"Content-Type": "application/json;charset=utf8"},
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating the expiration time of log topic %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceLTSTopicV2Read(d, meta)
+}
+
 func resourceLTSTopicV2Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	client, err := config.LtsV2Client(GetRegion(d, config))
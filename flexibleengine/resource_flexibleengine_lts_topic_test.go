@@ -25,6 +25,15 @@ func TestAccLTSTopicV2_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckLTSTopicV2Exists(resourceName, &topic),
 					resource.TestCheckResourceAttr(resourceName, "topic_name", fmt.Sprintf("testacc_topic-%s", rand)),
+					resource.TestCheckResourceAttr(resourceName, "ttl_in_days", "7"),
+				),
+			},
+			{
+				Config: testAccLTSTopicV2_update(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLTSTopicV2Exists(resourceName, &topic),
+					resource.TestCheckResourceAttr(resourceName, "topic_name", fmt.Sprintf("testacc_topic-%s", rand)),
+					resource.TestCheckResourceAttr(resourceName, "ttl_in_days", "3"),
 				),
 			},
 			{
@@ -121,3 +130,17 @@ resource "flexibleengine_lts_topic" "topic_1" {
 }
 `, name, name)
 }
+
+func testAccLTSTopicV2_update(name string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_lts_group" "group_1" {
+  group_name = "testacc_group-%s"
+}
+
+resource "flexibleengine_lts_topic" "topic_1" {
+  group_id    = flexibleengine_lts_group.group_1.id
+  topic_name  = "testacc_topic-%s"
+  ttl_in_days = 3
+}
+`, name, name)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -207,11 +208,9 @@ func resourceMRSClusterV2() *schema.Resource {
 				Elem:     nodeGroupSchemaResource("", false, 1, 500),
 			},
 
-			"tags": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-			},
+			"tags":                  tagsSchema(),
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 			"total_node_number": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -266,7 +265,7 @@ func resourceMRSClusterV2() *schema.Resource {
 			},
 		},
 
-		CustomizeDiff: func(c context.Context, rd *schema.ResourceDiff, i interface{}) error {
+		CustomizeDiff: customdiff.All(func(c context.Context, rd *schema.ResourceDiff, i interface{}) error {
 			nodeGroupNameArray := [6]string{"master_nodes", "analysis_core_nodes", "analysis_task_nodes",
 				"streaming_core_nodes", "streaming_task_nodes", "custom_nodes"}
 
@@ -279,7 +278,7 @@ func resourceMRSClusterV2() *schema.Resource {
 				}
 			}
 			return nil
-		},
+		}, resourceTagsCustomizeDiff),
 	}
 }
 
@@ -508,7 +507,7 @@ func addTagsToMrsCluster(d *schema.ResourceData, config *Config) error {
 		return fmt.Errorf("Error creating FlexibleEngine MRS V1 client: %s", err)
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "clusters", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -573,6 +572,7 @@ func resourceMRSClusterV2Create(d *schema.ResourceData, meta interface{}) error
 		SafeMode:             buildMrsSafeMode(d),
 		SecurityGroupsIds:    buildMrsSecurityGroupIds(d),
 		TemplateId:           d.Get("template_id").(string),
+		EnterpriseProjectId:  config.GetEnterpriseProjectID(d),
 	}
 
 	resp, err := clusterV2.Create(mrsV2Client, createOpts).Extract()
@@ -764,13 +764,13 @@ func parseHostIps(groupName string, isCustomNode bool, clustHostMap map[string][
 	return rt
 }
 
-func setClsuterTags(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+func setClsuterTags(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient) error {
 	resourceTags, err := tags.Get(client, "clusters", d.Id()).Extract()
 	if err != nil {
 		return fmt.Errorf("Error Fetching tags of MapReduce cluster form server: %s", err)
 	}
 	tagmap := tagsToMap(resourceTags.Tags)
-	return d.Set("tags", tagmap)
+	return setResourceTags(d, config, tagmap)
 }
 
 func getMrsClusterFromServer(d *schema.ResourceData, client *golangsdk.ServiceClient) (*cluster.Cluster, error) {
@@ -812,6 +812,7 @@ func resourceMRSClusterV2Read(d *schema.ResourceData, meta interface{}) error {
 		d.Set("status", resp.Clusterstate),
 		d.Set("public_ip", resp.EipAddress),
 		d.Set("eip_id", resp.EipId),
+		d.Set("enterprise_project_id", resp.EnterpriseProjectId),
 		setMrsClsuterType(d, resp),
 		setMrsClsuterComponentList(d, resp),
 		setMrsClsuterComponents(d, resp),
@@ -824,7 +825,7 @@ func resourceMRSClusterV2Read(d *schema.ResourceData, meta interface{}) error {
 		setMrsClsuterChargingTimestamp(d, resp),
 		setMrsClsuterCreateTimestamp(d, resp),
 		setMrsClusterNodeGroups(d, client, resp),
-		setClsuterTags(d, client),
+		setClsuterTags(d, config, client),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
 		return fmt.Errorf("Error setting vault fields: %s", err)
@@ -1007,8 +1008,8 @@ func resourceMRSClusterV2Update(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating FlexibleEngine MRS client: %s", err)
 	}
 
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(client, d, "clusters", d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(client, d, config, "clusters", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of MRS cluster:%s, err:%s", d.Id(), tagErr)
 		}
@@ -1023,6 +1024,12 @@ func resourceMRSClusterV2Update(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "mrs", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceMRSClusterV2Read(d, meta)
 }
 
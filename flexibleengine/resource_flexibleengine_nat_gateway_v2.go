@@ -26,6 +26,7 @@ func resourceNatGatewayV2() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -66,6 +67,7 @@ func resourceNatGatewayV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 
 			"status": {
 				Type:     schema.TypeString,
@@ -115,12 +117,13 @@ func resourceNatGatewayV2Create(d *schema.ResourceData, meta interface{}) error
 	}
 
 	createOpts := &natgateways.CreateOpts{
-		Name:              d.Get("name").(string),
-		Description:       d.Get("description").(string),
-		Spec:              d.Get("spec").(string),
-		TenantID:          d.Get("tenant_id").(string),
-		RouterID:          vpcID,
-		InternalNetworkID: subnetID,
+		Name:                d.Get("name").(string),
+		Description:         d.Get("description").(string),
+		Spec:                d.Get("spec").(string),
+		TenantID:            d.Get("tenant_id").(string),
+		RouterID:            vpcID,
+		InternalNetworkID:   subnetID,
+		EnterpriseProjectID: config.GetEnterpriseProjectID(d),
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
@@ -167,6 +170,7 @@ func resourceNatGatewayV2Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("vpc_id", natGateway.RouterID)
 	d.Set("subnet_id", natGateway.InternalNetworkID)
 	d.Set("status", natGateway.Status)
+	d.Set("enterprise_project_id", natGateway.EnterpriseProjectID)
 	d.Set("region", GetRegion(d, config))
 
 	return nil
@@ -179,23 +183,31 @@ func resourceNatGatewayV2Update(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating FlexibleEngine nat client: %s", err)
 	}
 
-	var updateOpts natgateways.UpdateOpts
+	if d.HasChanges("name", "description", "spec") {
+		var updateOpts natgateways.UpdateOpts
 
-	if d.HasChange("name") {
-		updateOpts.Name = d.Get("name").(string)
-	}
-	if d.HasChange("description") {
-		updateOpts.Description = d.Get("description").(string)
-	}
-	if d.HasChange("spec") {
-		updateOpts.Spec = d.Get("spec").(string)
-	}
+		if d.HasChange("name") {
+			updateOpts.Name = d.Get("name").(string)
+		}
+		if d.HasChange("description") {
+			updateOpts.Description = d.Get("description").(string)
+		}
+		if d.HasChange("spec") {
+			updateOpts.Spec = d.Get("spec").(string)
+		}
 
-	log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
 
-	_, err = natgateways.Update(natClient, d.Id(), updateOpts).Extract()
-	if err != nil {
-		return fmt.Errorf("Error updating Nat Gateway: %s", err)
+		_, err = natgateways.Update(natClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating Nat Gateway: %s", err)
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "nat_gateways", d.Id()); err != nil {
+			return err
+		}
 	}
 
 	return resourceNatGatewayV2Read(d, meta)
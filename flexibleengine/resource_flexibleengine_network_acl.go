@@ -23,6 +23,10 @@ func resourceNetworkACL() *schema.Resource {
 		Update: resourceNetworkACLUpdate,
 		Delete: resourceNetworkACLDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -234,9 +238,44 @@ func resourceNetworkACLRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("[DEBUG] Error saving ports to state for FlexibleEngine firewall group (%s): %s", d.Id(), err)
 	}
 
+	inboundRules, err := getNetworkACLPolicyRules(fwClient, fwGroup.IngressPolicyID)
+	if err != nil {
+		return err
+	}
+	d.Set("inbound_rules", inboundRules)
+
+	outboundRules, err := getNetworkACLPolicyRules(fwClient, fwGroup.EgressPolicyID)
+	if err != nil {
+		return err
+	}
+	d.Set("outbound_rules", outboundRules)
+
+	// the subnet ID is the network ID of the gateway port associated with the firewall group
+	subnetIDs := make([]string, 0, len(fwGroup.PortIDs))
+	for _, portID := range fwGroup.PortIDs {
+		port, err := ports.Get(fwClient, portID).Extract()
+		if err != nil {
+			return fmt.Errorf("Error retrieving port %s of FlexibleEngine firewall group (%s): %s", portID, d.Id(), err)
+		}
+		subnetIDs = append(subnetIDs, port.NetworkID)
+	}
+	d.Set("subnets", subnetIDs)
+
 	return nil
 }
 
+func getNetworkACLPolicyRules(client *golangsdk.ServiceClient, policyID string) ([]string, error) {
+	if policyID == "" {
+		return nil, nil
+	}
+
+	policy, err := policies.Get(client, policyID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving FlexibleEngine firewall policy %s: %s", policyID, err)
+	}
+	return policy.Rules, nil
+}
+
 func resourceNetworkACLUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	fwClient, err := config.NetworkingV2Client(GetRegion(d, config))
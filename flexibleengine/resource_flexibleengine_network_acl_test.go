@@ -42,6 +42,11 @@ func TestAccNetworkACL_basic(t *testing.T) {
 					testAccCheckFWFirewallPortCount(&fwGroup, 2),
 				),
 			},
+			{
+				ResourceName:      resourceKey,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -19,6 +19,10 @@ func resourceNetworkingRouterInterfaceV2() *schema.Resource {
 		Read:   resourceNetworkingRouterInterfaceV2Read,
 		Delete: resourceNetworkingRouterInterfaceV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -32,6 +32,11 @@ func TestAccNetworkingV2RouterInterface_basic_subnet(t *testing.T) {
 					testAccCheckNetworkingV2RouterInterfaceExists("flexibleengine_networking_router_interface_v2.int_1"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_networking_router_interface_v2.int_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
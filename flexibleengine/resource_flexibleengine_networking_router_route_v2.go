@@ -3,6 +3,7 @@ package flexibleengine
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -16,6 +17,10 @@ func resourceNetworkingRouterRouteV2() *schema.Resource {
 		Read:   resourceNetworkingRouterRouteV2Read,
 		Delete: resourceNetworkingRouterRouteV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkingRouterRouteV2Import,
+		},
+
 		DeprecationMessage: "It has been deprecated, using flexibleengine_vpc_route instead",
 
 		Schema: map[string]*schema.Schema{
@@ -204,3 +209,25 @@ func resourceNetworkingRouterRouteV2Delete(d *schema.ResourceData, meta interfac
 
 	return nil
 }
+
+// resourceNetworkingRouterRouteV2Import parses the resource ID which has the format
+// of <router_id>-route-<destination_cidr>-<next_hop>.
+func resourceNetworkingRouterRouteV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "-route-", 2)
+	if len(parts) != 2 {
+		err := fmt.Errorf("Invalid format specified for router route. Format must be <router id>-route-<destination cidr>-<next hop>")
+		return nil, err
+	}
+
+	index := strings.LastIndex(parts[1], "-")
+	if index <= 0 || index == len(parts[1])-1 {
+		err := fmt.Errorf("Invalid format specified for router route. Format must be <router id>-route-<destination cidr>-<next hop>")
+		return nil, err
+	}
+
+	d.Set("router_id", parts[0])
+	d.Set("destination_cidr", parts[1][:index])
+	d.Set("next_hop", parts[1][index+1:])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/layer3/routers"
@@ -12,6 +13,30 @@ import (
 	"github.com/chnsz/golangsdk/openstack/networking/v2/subnets"
 )
 
+func TestResourceNetworkingRouterRouteV2Import(t *testing.T) {
+	routerID := "686fe248-386c-4f70-9f6d-4c9ed69d9cde"
+	d := schema.TestResourceDataRaw(t, resourceNetworkingRouterRouteV2().Schema, map[string]interface{}{})
+	d.SetId(fmt.Sprintf("%s-route-10.0.1.0/24-192.168.199.25", routerID))
+
+	if _, err := resourceNetworkingRouterRouteV2Import(d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := d.Get("router_id").(string); v != routerID {
+		t.Fatalf("expected router_id %s, got %s", routerID, v)
+	}
+	if v := d.Get("destination_cidr").(string); v != "10.0.1.0/24" {
+		t.Fatalf("expected destination_cidr 10.0.1.0/24, got %s", v)
+	}
+	if v := d.Get("next_hop").(string); v != "192.168.199.25" {
+		t.Fatalf("expected next_hop 192.168.199.25, got %s", v)
+	}
+
+	d.SetId(routerID)
+	if _, err := resourceNetworkingRouterRouteV2Import(d, nil); err == nil {
+		t.Fatalf("expected an error for the invalid ID %s", routerID)
+	}
+}
+
 func TestAccNetworkingV2RouterRoute_basic(t *testing.T) {
 	var router routers.Router
 	var network [2]networks.Network
@@ -50,6 +75,11 @@ func TestAccNetworkingV2RouterRoute_basic(t *testing.T) {
 						"flexibleengine_networking_router_route_v2.router_route_2"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_networking_router_route_v2.router_route_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccNetworkingV2RouterRoute_destroy,
 				Check: resource.ComposeTestCheckFunc(
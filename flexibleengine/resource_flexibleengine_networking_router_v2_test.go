@@ -31,6 +31,11 @@ func TestAccNetworkingV2Router_basic(t *testing.T) {
 						"flexibleengine_networking_router_v2.router_1", "name", "router_2"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_networking_router_v2.router_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
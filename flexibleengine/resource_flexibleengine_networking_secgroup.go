@@ -0,0 +1,499 @@
+package flexibleengine
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/common/tags"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+)
+
+func resourceNetworkingSecGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkingSecGroupCreate,
+		Read:   resourceNetworkingSecGroupRead,
+		Update: resourceNetworkingSecGroupUpdate,
+		Delete: resourceNetworkingSecGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"ingress":  secGroupRuleSetSchema(),
+			"egress":   secGroupRuleSetSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	}
+}
+
+func secGroupRuleSetSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Set:      resourceSecGroupRuleHash,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ethertype": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "IPv4",
+					ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+				},
+				"protocol": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"ports": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"remote_ip_prefix": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateCIDR,
+				},
+				"remote_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"remote_address_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "allow",
+					ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+				},
+				"priority": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validation.IntBetween(1, 100),
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// secGroupRule is the normalized form of a security group rule, it is used to compare
+// the rules in the configuration with the ones of the security group.
+type secGroupRule struct {
+	Direction            string
+	Ethertype            string
+	Protocol             string
+	Ports                string
+	RemoteIPPrefix       string
+	RemoteGroupID        string
+	RemoteAddressGroupID string
+	Action               string
+	Priority             int
+	Description          string
+}
+
+// matchKey identifies the rule regardless of its description, two rules with the same
+// matchKey are rejected as duplicated by the API.
+func (r secGroupRule) matchKey() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%d", r.Direction, r.Ethertype, r.Protocol, r.Ports,
+		r.RemoteIPPrefix, r.RemoteGroupID, r.RemoteAddressGroupID, r.Action, r.Priority)
+}
+
+func (r secGroupRule) key() string {
+	return r.matchKey() + "|" + r.Description
+}
+
+func normalizeSecGroupRule(r secGroupRule) secGroupRule {
+	r.Protocol = strings.ToLower(r.Protocol)
+	r.Ports = strings.ReplaceAll(r.Ports, " ", "")
+	r.RemoteIPPrefix = strings.ToLower(r.RemoteIPPrefix)
+	if r.Ethertype == "" {
+		r.Ethertype = "IPv4"
+	}
+	if r.Action == "" {
+		r.Action = "allow"
+	}
+	if r.Priority == 0 {
+		r.Priority = 1
+	}
+	return r
+}
+
+func resourceSecGroupRuleHash(v interface{}) int {
+	var buf bytes.Buffer
+	rule := expandSecGroupRule("", v.(map[string]interface{}))
+	buf.WriteString(rule.key())
+	return schema.HashString(buf.String())
+}
+
+func expandSecGroupRule(direction string, raw map[string]interface{}) secGroupRule {
+	rule := secGroupRule{Direction: direction}
+	if v, ok := raw["ethertype"].(string); ok {
+		rule.Ethertype = v
+	}
+	if v, ok := raw["protocol"].(string); ok {
+		rule.Protocol = v
+	}
+	if v, ok := raw["ports"].(string); ok {
+		rule.Ports = v
+	}
+	if v, ok := raw["remote_ip_prefix"].(string); ok {
+		rule.RemoteIPPrefix = v
+	}
+	if v, ok := raw["remote_group_id"].(string); ok {
+		rule.RemoteGroupID = v
+	}
+	if v, ok := raw["remote_address_group_id"].(string); ok {
+		rule.RemoteAddressGroupID = v
+	}
+	if v, ok := raw["action"].(string); ok {
+		rule.Action = v
+	}
+	if v, ok := raw["priority"].(int); ok {
+		rule.Priority = v
+	}
+	if v, ok := raw["description"].(string); ok {
+		rule.Description = v
+	}
+	return normalizeSecGroupRule(rule)
+}
+
+func expandSecGroupRules(d *schema.ResourceData) []secGroupRule {
+	var result []secGroupRule
+	for _, direction := range []string{"ingress", "egress"} {
+		for _, raw := range d.Get(direction).(*schema.Set).List() {
+			result = append(result, expandSecGroupRule(direction, raw.(map[string]interface{})))
+		}
+	}
+	return result
+}
+
+func secGroupRuleFromAPI(r rules.SecurityGroupRule) secGroupRule {
+	return normalizeSecGroupRule(secGroupRule{
+		Direction:            r.Direction,
+		Ethertype:            r.Ethertype,
+		Protocol:             r.Protocol,
+		Ports:                r.MultiPort,
+		RemoteIPPrefix:       r.RemoteIpPrefix,
+		RemoteGroupID:        r.RemoteGroupId,
+		RemoteAddressGroupID: r.RemoteAddressGroupId,
+		Action:               r.Action,
+		Priority:             r.Priority,
+		Description:          r.Description,
+	})
+}
+
+func flattenSecGroupRules(existing []rules.SecurityGroupRule, direction string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(existing))
+	for _, r := range existing {
+		if r.Direction != direction {
+			continue
+		}
+		rule := secGroupRuleFromAPI(r)
+		result = append(result, map[string]interface{}{
+			"ethertype":               rule.Ethertype,
+			"protocol":                rule.Protocol,
+			"ports":                   rule.Ports,
+			"remote_ip_prefix":        rule.RemoteIPPrefix,
+			"remote_group_id":         rule.RemoteGroupID,
+			"remote_address_group_id": rule.RemoteAddressGroupID,
+			"action":                  rule.Action,
+			"priority":                rule.Priority,
+			"description":             rule.Description,
+		})
+	}
+	return result
+}
+
+// secGroupRuleChanges are the operations to reconcile the rules of a security group.
+type secGroupRuleChanges struct {
+	Create []secGroupRule
+	// DeleteBeforeCreate are the rules which only differ in description from a rule to create
+	DeleteBeforeCreate []string
+	// Delete are removed after the creation to keep the traffic allowed meanwhile
+	Delete []string
+}
+
+// diffSecGroupRules compares the rules of the configuration with the existing rules,
+// the rules which are not in the configuration are removed, including the ones added
+// out of band and the default rules.
+func diffSecGroupRules(desired []secGroupRule, existing []rules.SecurityGroupRule) secGroupRuleChanges {
+	var changes secGroupRuleChanges
+
+	wanted := make(map[string]bool, len(desired))
+	for _, r := range desired {
+		wanted[r.key()] = true
+	}
+
+	kept := make(map[string]bool)
+	var obsolete []rules.SecurityGroupRule
+	for _, r := range existing {
+		key := secGroupRuleFromAPI(r).key()
+		if wanted[key] && !kept[key] {
+			kept[key] = true
+			continue
+		}
+		obsolete = append(obsolete, r)
+	}
+
+	creating := make(map[string]bool)
+	for _, r := range desired {
+		if !kept[r.key()] {
+			changes.Create = append(changes.Create, r)
+			creating[r.matchKey()] = true
+		}
+	}
+
+	for _, r := range obsolete {
+		if creating[secGroupRuleFromAPI(r).matchKey()] {
+			changes.DeleteBeforeCreate = append(changes.DeleteBeforeCreate, r.ID)
+		} else {
+			changes.Delete = append(changes.Delete, r.ID)
+		}
+	}
+
+	sort.Slice(changes.Create, func(i, j int) bool {
+		return changes.Create[i].key() < changes.Create[j].key()
+	})
+	sort.Strings(changes.DeleteBeforeCreate)
+	sort.Strings(changes.Delete)
+	return changes
+}
+
+func deleteSecGroupRules(client *golangsdk.ServiceClient, ids []string) error {
+	for _, id := range ids {
+		log.Printf("[DEBUG] Deleting security group rule %s", id)
+		if err := rules.Delete(client, id).ExtractErr(); err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error deleting security group rule %s: %s", id, err)
+		}
+	}
+	return nil
+}
+
+func applySecGroupRules(client *golangsdk.ServiceClient, groupID string, changes secGroupRuleChanges) error {
+	if err := deleteSecGroupRules(client, changes.DeleteBeforeCreate); err != nil {
+		return err
+	}
+
+	for _, r := range changes.Create {
+		opts := rules.CreateOpts{
+			SecurityGroupId:      groupID,
+			Direction:            r.Direction,
+			Ethertype:            r.Ethertype,
+			Protocol:             r.Protocol,
+			MultiPort:            r.Ports,
+			RemoteIpPrefix:       r.RemoteIPPrefix,
+			RemoteGroupId:        r.RemoteGroupID,
+			RemoteAddressGroupId: r.RemoteAddressGroupID,
+			Action:               r.Action,
+			Priority:             r.Priority,
+			Description:          r.Description,
+		}
+		log.Printf("[DEBUG] Creating security group rule: %#v", opts)
+		if _, err := rules.Create(client, opts); err != nil {
+			return fmt.Errorf("Error creating security group rule: %s", err)
+		}
+	}
+
+	return deleteSecGroupRules(client, changes.Delete)
+}
+
+func reconcileSecGroupRules(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+	securityGroup, err := groups.Get(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving FlexibleEngine Security Group %s: %s", d.Id(), err)
+	}
+
+	changes := diffSecGroupRules(expandSecGroupRules(d), securityGroup.SecurityGroupRules)
+	return applySecGroupRules(client, d.Id(), changes)
+}
+
+func resourceNetworkingSecGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	client, err := config.NetworkingV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	opts := groups.CreateOpts{
+		Name:                d.Get("name").(string),
+		EnterpriseProjectId: config.GetEnterpriseProjectID(d),
+	}
+	log.Printf("[DEBUG] Create FlexibleEngine Security Group: %#v", opts)
+	securityGroup, err := groups.Create(client, opts)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine Security Group: %s", err)
+	}
+	d.SetId(securityGroup.ID)
+
+	if description := d.Get("description").(string); description != "" {
+		updateOpts := groups.UpdateOpts{
+			Description: &description,
+		}
+		if _, err := groups.Update(client, d.Id(), updateOpts); err != nil {
+			return fmt.Errorf("Error updating the description of FlexibleEngine Security Group: %s", err)
+		}
+	}
+
+	if err := reconcileSecGroupRules(client, d); err != nil {
+		return err
+	}
+
+	if tagRaw := getResourceTags(d, config); len(tagRaw) > 0 {
+		tagClient, err := config.NetworkingV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+		}
+		taglist := expandResourceTags(tagRaw)
+		if tagErr := tags.Create(tagClient, "security-groups", d.Id(), taglist).ExtractErr(); tagErr != nil {
+			return fmt.Errorf("Error setting tags of Security Group %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	return resourceNetworkingSecGroupRead(d, meta)
+}
+
+func resourceNetworkingSecGroupRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	client, err := config.NetworkingV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	securityGroup, err := groups.Get(client, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "FlexibleEngine Security Group")
+	}
+
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("name", securityGroup.Name),
+		d.Set("description", securityGroup.Description),
+		d.Set("enterprise_project_id", securityGroup.EnterpriseProjectId),
+		d.Set("ingress", flattenSecGroupRules(securityGroup.SecurityGroupRules, "ingress")),
+		d.Set("egress", flattenSecGroupRules(securityGroup.SecurityGroupRules, "egress")),
+	)
+
+	if tagClient, err := config.NetworkingV2Client(region); err == nil {
+		if resourceTags, err := tags.Get(tagClient, "security-groups", d.Id()).Extract(); err == nil {
+			mErr = multierror.Append(mErr, setResourceTags(d, config, tagsToMap(resourceTags.Tags)))
+		} else {
+			log.Printf("[WARN] Error fetching tags of Security Group %s: %s", d.Id(), err)
+		}
+	}
+
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmt.Errorf("Error setting Security Group fields: %s", err)
+	}
+	return nil
+}
+
+func resourceNetworkingSecGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	client, err := config.NetworkingV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	if d.HasChanges("name", "description") {
+		description := d.Get("description").(string)
+		updateOpts := groups.UpdateOpts{
+			Name:        d.Get("name").(string),
+			Description: &description,
+		}
+		log.Printf("[DEBUG] Updating Security Group %s with options: %#v", d.Id(), updateOpts)
+		if _, err := groups.Update(client, d.Id(), updateOpts); err != nil {
+			return fmt.Errorf("Error updating FlexibleEngine Security Group: %s", err)
+		}
+	}
+
+	if d.HasChanges("ingress", "egress") {
+		if err := reconcileSecGroupRules(client, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		tagClient, err := config.NetworkingV2Client(region)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+		}
+		if tagErr := UpdateResourceTags(tagClient, d, config, "security-groups", d.Id()); tagErr != nil {
+			return fmt.Errorf("Error updating tags of Security Group %s: %s", d.Id(), tagErr)
+		}
+	}
+
+	return resourceNetworkingSecGroupRead(d, meta)
+}
+
+func resourceNetworkingSecGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+	}
+
+	// the security group can be deleted by the v2 API as they share the same ID
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForSecGroupDelete(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error deleting FlexibleEngine Security Group: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -9,21 +9,31 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/security/rules"
+	v3rules "github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
 )
 
+// secGroupRuleV3Params can only be managed through the VPC v3 security group rule API
+var secGroupRuleV3Params = []string{"ports", "remote_address_group_id", "action", "priority"}
+
+// secGroupRuleMutableParams can be updated in place through the VPC v3 security group rule API
+var secGroupRuleMutableParams = []string{"description", "action", "priority"}
+
 func resourceNetworkingSecGroupRuleV2() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNetworkingSecGroupRuleV2Create,
 		Read:   resourceNetworkingSecGroupRuleV2Read,
+		Update: resourceNetworkingSecGroupRuleV2Update,
 		Delete: resourceNetworkingSecGroupRuleV2Delete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -52,42 +62,60 @@ func resourceNetworkingSecGroupRuleV2() *schema.Resource {
 			"port_range_min": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"port_range_max": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
+			"ports": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"port_range_min", "port_range_max"},
+			},
 			"protocol": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"remote_group_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"remote_ip_prefix": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validateCIDR,
 				StateFunc: func(v interface{}) string {
 					return strings.ToLower(v.(string))
 				},
 			},
+			"remote_address_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"remote_group_id", "remote_ip_prefix"},
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 
 			"tenant_id": {
@@ -103,28 +131,68 @@ func resourceNetworkingSecGroupRuleV2() *schema.Resource {
 
 func resourceNetworkingSecGroupRuleV2Create(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
+	id, err := createNetworkingSecGroupRule(d, config)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceNetworkingSecGroupRuleV2Read(d, meta)
+}
+
+// createNetworkingSecGroupRule creates a security group rule with the current configuration
+// and returns its ID, the v3 API is only used when the v3 parameters are specified.
+func createNetworkingSecGroupRule(d *schema.ResourceData, config *Config) (string, error) {
+	for _, key := range secGroupRuleV3Params {
+		if isParamConfigured(d, key) {
+			return createNetworkingSecGroupRuleV3(d, config)
+		}
+	}
+	return createNetworkingSecGroupRuleV2(d, config)
+}
+
+// buildSecGroupRuleMultiPort returns the ports of the v3 API, port_range_min and port_range_max
+// are converted when ports is not specified.
+func buildSecGroupRuleMultiPort(d *schema.ResourceData) string {
+	if ports := getConfiguredString(d, "ports"); ports != "" {
+		return ports
+	}
+
+	portRangeMin := getConfiguredInt(d, "port_range_min")
+	portRangeMax := getConfiguredInt(d, "port_range_max")
+	switch {
+	case portRangeMin == 0 && portRangeMax == 0:
+		return ""
+	case portRangeMin == portRangeMax || portRangeMax == 0:
+		return strconv.Itoa(portRangeMin)
+	default:
+		return fmt.Sprintf("%d-%d", portRangeMin, portRangeMax)
+	}
+}
+
+func createNetworkingSecGroupRuleV2(d *schema.ResourceData, config *Config) (string, error) {
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+		return "", fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
 	}
 
-	portRangeMin := d.Get("port_range_min").(int)
-	portRangeMax := d.Get("port_range_max").(int)
-	protocol := d.Get("protocol").(string)
+	portRangeMin := getConfiguredInt(d, "port_range_min")
+	portRangeMax := getConfiguredInt(d, "port_range_max")
+	protocol := getConfiguredString(d, "protocol")
 
 	if protocol == "" {
 		if portRangeMin != 0 || portRangeMax != 0 {
-			return fmt.Errorf("A protocol must be specified when using port_range_min and port_range_max")
+			return "", fmt.Errorf("A protocol must be specified when using port_range_min and port_range_max")
 		}
 	}
 
 	opts := rules.CreateOpts{
 		SecGroupID:     d.Get("security_group_id").(string),
-		PortRangeMin:   d.Get("port_range_min").(int),
-		PortRangeMax:   d.Get("port_range_max").(int),
-		RemoteGroupID:  d.Get("remote_group_id").(string),
-		RemoteIPPrefix: d.Get("remote_ip_prefix").(string),
-		Description:    d.Get("description").(string),
+		PortRangeMin:   portRangeMin,
+		PortRangeMax:   portRangeMax,
+		RemoteGroupID:  getConfiguredString(d, "remote_group_id"),
+		RemoteIPPrefix: getConfiguredString(d, "remote_ip_prefix"),
+		Description:    getConfiguredString(d, "description"),
 		TenantID:       d.Get("tenant_id").(string),
 	}
 
@@ -138,22 +206,59 @@ func resourceNetworkingSecGroupRuleV2Create(d *schema.ResourceData, meta interfa
 		opts.EtherType = ethertype
 	}
 
-	if v, ok := d.GetOk("protocol"); ok {
-		protocol := resourceNetworkingSecGroupRuleV2DetermineProtocol(v.(string))
-		opts.Protocol = protocol
+	if protocol != "" {
+		opts.Protocol = resourceNetworkingSecGroupRuleV2DetermineProtocol(protocol)
 	}
 
 	log.Printf("[DEBUG] Create FlexibleEngine security group: %#v", opts)
 
 	sgRule, err := rules.Create(networkingClient, opts).Extract()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	log.Printf("[DEBUG] FlexibleEngine Security Group Rule created: %#v", sgRule)
-	d.SetId(sgRule.ID)
+	return sgRule.ID, nil
+}
 
-	return resourceNetworkingSecGroupRuleV2Read(d, meta)
+func createNetworkingSecGroupRuleV3(d *schema.ResourceData, config *Config) (string, error) {
+	region := GetRegion(d, config)
+	networkingClient, err := config.NetworkingV3Client(region)
+	if err != nil {
+		return "", fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	multiPort := buildSecGroupRuleMultiPort(d)
+	if multiPort != "" && getConfiguredString(d, "protocol") == "" {
+		return "", fmt.Errorf("A protocol must be specified when using ports")
+	}
+
+	opts := v3rules.CreateOpts{
+		SecurityGroupId:      d.Get("security_group_id").(string),
+		Description:          getConfiguredString(d, "description"),
+		Direction:            d.Get("direction").(string),
+		Ethertype:            d.Get("ethertype").(string),
+		Protocol:             getConfiguredString(d, "protocol"),
+		MultiPort:            multiPort,
+		RemoteIpPrefix:       getConfiguredString(d, "remote_ip_prefix"),
+		RemoteGroupId:        getConfiguredString(d, "remote_group_id"),
+		RemoteAddressGroupId: getConfiguredString(d, "remote_address_group_id"),
+		Action:               getConfiguredString(d, "action"),
+		Priority:             getConfiguredInt(d, "priority"),
+	}
+
+	log.Printf("[DEBUG] Create FlexibleEngine security group rule with v3 API: %#v", opts)
+
+	sgRule, err := v3rules.Create(networkingClient, opts)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return "", fmt.Errorf("the parameters %v are not supported in region %s", secGroupRuleV3Params, region)
+		}
+		return "", err
+	}
+
+	log.Printf("[DEBUG] FlexibleEngine Security Group Rule created: %#v", sgRule)
+	return sgRule.ID, nil
 }
 
 func resourceNetworkingSecGroupRuleV2Read(d *schema.ResourceData, meta interface{}) error {
@@ -180,6 +285,81 @@ func resourceNetworkingSecGroupRuleV2Read(d *schema.ResourceData, meta interface
 	d.Set("security_group_id", sgRule.SecGroupID)
 	d.Set("description", sgRule.Description)
 
+	// the v3 parameters are skipped if the v3 API is not available in the region
+	if v3Client, err := config.NetworkingV3Client(region); err == nil {
+		if rule, err := v3rules.Get(v3Client, d.Id()); err == nil {
+			d.Set("ports", rule.MultiPort)
+			d.Set("remote_address_group_id", rule.RemoteAddressGroupId)
+			d.Set("action", rule.Action)
+			d.Set("priority", rule.Priority)
+		} else {
+			log.Printf("[DEBUG] Unable to fetch Security Group Rule %s with v3 API: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceNetworkingSecGroupRuleV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	if !d.HasChangesExcept(secGroupRuleMutableParams...) {
+		if err := updateNetworkingSecGroupRuleV3(d, config); err != nil {
+			return err
+		}
+		return resourceNetworkingSecGroupRuleV2Read(d, meta)
+	}
+
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
+	}
+
+	// The other parameters can not be modified, so a new rule is created before the old one
+	// is removed to keep the traffic allowed.
+	oldID := d.Id()
+	newID, err := createNetworkingSecGroupRule(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating the replacement of FlexibleEngine Security Group Rule %s: %s", oldID, err)
+	}
+	d.SetId(newID)
+
+	if err := deleteNetworkingSecGroupRuleV2(networkingClient, oldID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+	return resourceNetworkingSecGroupRuleV2Read(d, meta)
+}
+
+// updateNetworkingSecGroupRuleV3 updates the description, action and priority of the rule in place
+func updateNetworkingSecGroupRuleV3(d *schema.ResourceData, config *Config) error {
+	region := GetRegion(d, config)
+	networkingClient, err := config.NetworkingV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	rule := map[string]interface{}{
+		"description": getConfiguredString(d, "description"),
+	}
+	if action := getConfiguredString(d, "action"); action != "" {
+		rule["action"] = action
+	}
+	if priority := getConfiguredInt(d, "priority"); priority != 0 {
+		rule["priority"] = priority
+	}
+	updateOpts := map[string]interface{}{
+		"security_group_rule": rule,
+	}
+
+	log.Printf("[DEBUG] Update FlexibleEngine security group rule %s with v3 API: %#v", d.Id(), updateOpts)
+	url := networkingClient.ServiceURL("vpc/security-group-rules", d.Id())
+	_, err = networkingClient.Put(url, updateOpts, nil, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return fmt.Errorf("updating %v of a security group rule is not supported in region %s",
+				secGroupRuleMutableParams, region)
+		}
+		return fmt.Errorf("Error updating FlexibleEngine Security Group Rule %s: %s", d.Id(), err)
+	}
 	return nil
 }
 
@@ -192,22 +372,29 @@ func resourceNetworkingSecGroupRuleV2Delete(d *schema.ResourceData, meta interfa
 		return fmt.Errorf("Error creating FlexibleEngine networking client: %s", err)
 	}
 
+	if err := deleteNetworkingSecGroupRuleV2(networkingClient, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func deleteNetworkingSecGroupRuleV2(networkingClient *golangsdk.ServiceClient, id string, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"ACTIVE"},
 		Target:     []string{"DELETED"},
-		Refresh:    waitForSecGroupRuleDelete(networkingClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Refresh:    waitForSecGroupRuleDelete(networkingClient, id),
+		Timeout:    timeout,
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err := stateConf.WaitForState()
 	if err != nil {
 		return fmt.Errorf("Error deleting FlexibleEngine Security Group Rule: %s", err)
 	}
-
-	d.SetId("")
-	return err
+	return nil
 }
 
 func resourceNetworkingSecGroupRuleV2DetermineDirection(v string) rules.RuleDirection {
@@ -1,15 +1,21 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/security/groups"
 	"github.com/chnsz/golangsdk/openstack/networking/v2/extensions/security/rules"
+	v3rules "github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+	th "github.com/chnsz/golangsdk/testhelper"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 func TestAccNetworkingV2SecGroupRule_basic(t *testing.T) {
@@ -39,6 +45,23 @@ func TestAccNetworkingV2SecGroupRule_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "description"),
 				),
 			},
+			{
+				Config: testAccNetworkingV2SecGroupRule_description(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupRuleExists(resourceName, &secgroupRule),
+					resource.TestCheckResourceAttr(resourceName, "port_range_min", "22"),
+					resource.TestCheckResourceAttr(resourceName, "description", "allow SSH from anywhere"),
+				),
+			},
+			{
+				Config: testAccNetworkingV2SecGroupRule_ports(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupRuleExists(resourceName, &secgroupRule),
+					resource.TestCheckResourceAttr(resourceName, "ports", "22,443,8000-8080"),
+					resource.TestCheckResourceAttr(resourceName, "action", "allow"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "10"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
@@ -48,6 +71,128 @@ func TestAccNetworkingV2SecGroupRule_basic(t *testing.T) {
 	})
 }
 
+func TestAccNetworkingV2SecGroupRule_addressGroup(t *testing.T) {
+	var secgroupRule rules.SecGroupRule
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_networking_secgroup_rule_v2.secgroup_rule_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2SecGroupRule_addressGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupRuleExists(resourceName, &secgroupRule),
+					resource.TestCheckResourceAttr(resourceName, "action", "deny"),
+					resource.TestCheckResourceAttrPair(resourceName, "remote_address_group_id",
+						"flexibleengine_vpc_address_group.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildSecGroupRuleMultiPort(t *testing.T) {
+	cases := []struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"ports": "80,443,8000-8080"}, "80,443,8000-8080"},
+		{map[string]interface{}{"port_range_min": 22, "port_range_max": 22}, "22"},
+		{map[string]interface{}{"port_range_min": 8000, "port_range_max": 8080}, "8000-8080"},
+		{map[string]interface{}{}, ""},
+	}
+
+	for _, tc := range cases {
+		tc.raw["direction"] = "ingress"
+		tc.raw["ethertype"] = "IPv4"
+		tc.raw["security_group_id"] = "secgroup-id"
+		d := schema.TestResourceDataRaw(t, resourceNetworkingSecGroupRuleV2().Schema, tc.raw)
+		if v := buildSecGroupRuleMultiPort(d); v != tc.expected {
+			t.Fatalf("expected ports %q for %v, got %q", tc.expected, tc.raw, v)
+		}
+	}
+}
+
+func TestUnitNetworkingV2SecGroupRule_update(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	testProvider, _ := srv.ProviderFactories(Provider)["flexibleengine"]()
+
+	raw := map[string]interface{}{
+		"region":     mockcloud.Region,
+		"auth_url":   srv.Endpoint + "/iam/v3",
+		"access_key": mockcloud.AccessKey,
+		"secret_key": mockcloud.SecretKey,
+		"endpoints": map[string]interface{}{
+			"iam": srv.Endpoint + "/iam/",
+			"vpc": srv.Endpoint + "/vpc/",
+		},
+	}
+	diags := testProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error when configure FlexibleEngine provider: %s", diags[0].Summary)
+	}
+	config := testProvider.Meta().(*Config)
+
+	v3Client, err := config.NetworkingV3Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+	rule, err := v3rules.Create(v3Client, v3rules.CreateOpts{
+		SecurityGroupId: "secgroup-id",
+		Direction:       "ingress",
+		Ethertype:       "IPv4",
+		Protocol:        "tcp",
+		MultiPort:       "80",
+		Priority:        1,
+		Description:     "web",
+	})
+	th.AssertNoErr(t, err)
+
+	resource := resourceNetworkingSecGroupRuleV2()
+	buildConfig := func(ports, description string, priority int) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"security_group_id": "secgroup-id",
+			"direction":         "ingress",
+			"ethertype":         "IPv4",
+			"protocol":          "tcp",
+			"ports":             ports,
+			"priority":          priority,
+			"description":       description,
+		})
+	}
+	apply := func(state *terraform.InstanceState, config *terraform.ResourceConfig) *terraform.InstanceState {
+		diff, err := resource.Diff(context.Background(), state, config, testProvider.Meta())
+		th.AssertNoErr(t, err)
+		newState, diags := resource.Apply(context.Background(), state, diff, testProvider.Meta())
+		if diags.HasError() {
+			t.Fatalf("Error updating the security group rule: %v", diags)
+		}
+		return newState
+	}
+
+	d := resource.Data(nil)
+	d.SetId(rule.ID)
+	th.AssertNoErr(t, resourceNetworkingSecGroupRuleV2Read(d, config))
+
+	// the description and priority are updated in place
+	state := apply(d.State(), buildConfig("80", "web server", 5))
+	th.AssertEquals(t, rule.ID, state.ID)
+	th.AssertEquals(t, "web server", state.Attributes["description"])
+	th.AssertEquals(t, "5", state.Attributes["priority"])
+
+	// the rule is replaced when the ports are changed, the new rule is created before the old one is removed
+	state = apply(state, buildConfig("80,443", "web server", 5))
+	if state.ID == rule.ID || !srv.Exists("security_group_rules", state.ID) {
+		t.Fatalf("expected the security group rule to be replaced, got %s", state.ID)
+	}
+	if srv.Exists("security_group_rules", rule.ID) {
+		t.Fatalf("expected the old security group rule %s to be removed", rule.ID)
+	}
+	th.AssertEquals(t, "80,443", state.Attributes["ports"])
+}
+
 func TestAccNetworkingV2SecGroupRule_remoteGroup(t *testing.T) {
 	var secgroupRule rules.SecGroupRule
 
@@ -213,6 +358,62 @@ resource "flexibleengine_networking_secgroup_rule_v2" "secgroup_rule_1" {
 `, testAccNetworkingV2SecGroupRule_base(rName))
 }
 
+func testAccNetworkingV2SecGroupRule_description(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_networking_secgroup_rule_v2" "secgroup_rule_1" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 22
+  port_range_min    = 22
+  protocol          = "tcp"
+  remote_ip_prefix  = "0.0.0.0/0"
+  security_group_id = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  description       = "allow SSH from anywhere"
+}
+`, testAccNetworkingV2SecGroupRule_base(rName))
+}
+
+func testAccNetworkingV2SecGroupRule_ports(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_networking_secgroup_rule_v2" "secgroup_rule_1" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  ports             = "22,443,8000-8080"
+  protocol          = "tcp"
+  action            = "allow"
+  priority          = 10
+  remote_ip_prefix  = "0.0.0.0/0"
+  security_group_id = flexibleengine_networking_secgroup_v2.secgroup_1.id
+  description       = "allow SSH and web traffic"
+}
+`, testAccNetworkingV2SecGroupRule_base(rName))
+}
+
+func testAccNetworkingV2SecGroupRule_addressGroup(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "flexibleengine_vpc_address_group" "test" {
+  name      = "%s"
+  addresses = ["192.168.10.10", "192.168.11.0/24"]
+}
+
+resource "flexibleengine_networking_secgroup_rule_v2" "secgroup_rule_1" {
+  direction               = "ingress"
+  ethertype               = "IPv4"
+  ports                   = "80,443"
+  protocol                = "tcp"
+  action                  = "deny"
+  remote_address_group_id = flexibleengine_vpc_address_group.test.id
+  security_group_id       = flexibleengine_networking_secgroup_v2.secgroup_1.id
+}
+`, testAccNetworkingV2SecGroupRule_base(rName), rName)
+}
+
 func testAccNetworkingV2SecGroupRule_remoteGroup(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -0,0 +1,226 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+)
+
+func TestDiffSecGroupRules(t *testing.T) {
+	ssh := secGroupRule{
+		Direction:      "ingress",
+		Ethertype:      "IPv4",
+		Protocol:       "tcp",
+		Ports:          "22",
+		RemoteIPPrefix: "10.0.0.0/8",
+		Action:         "allow",
+		Priority:       1,
+		Description:    "ssh",
+	}
+	web := secGroupRule{
+		Direction:      "ingress",
+		Ethertype:      "IPv4",
+		Protocol:       "tcp",
+		Ports:          "80,443",
+		RemoteIPPrefix: "0.0.0.0/0",
+		Action:         "allow",
+		Priority:       1,
+	}
+	existing := []rules.SecurityGroupRule{
+		// identical to ssh, the protocol case is normalized
+		{ID: "rule-ssh", Direction: "ingress", Ethertype: "IPv4", Protocol: "TCP", MultiPort: "22",
+			RemoteIpPrefix: "10.0.0.0/8", Action: "allow", Priority: 1, Description: "ssh"},
+		// same as web except the description
+		{ID: "rule-web", Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", MultiPort: "80,443",
+			RemoteIpPrefix: "0.0.0.0/0", Action: "allow", Priority: 1, Description: "old"},
+		// default egress rule added by the service
+		{ID: "rule-egress", Direction: "egress", Ethertype: "IPv4", Action: "allow", Priority: 1},
+		// duplicated ssh rule added out of band
+		{ID: "rule-dup", Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", MultiPort: "22",
+			RemoteIpPrefix: "10.0.0.0/8", Action: "allow", Priority: 1, Description: "ssh"},
+	}
+
+	changes := diffSecGroupRules([]secGroupRule{ssh, web}, existing)
+	expected := secGroupRuleChanges{
+		Create:             []secGroupRule{web},
+		DeleteBeforeCreate: []string{"rule-web"},
+		Delete:             []string{"rule-dup", "rule-egress"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("unexpected changes:\n got: %#v\nwant: %#v", changes, expected)
+	}
+
+	changes = diffSecGroupRules(nil, nil)
+	if len(changes.Create)+len(changes.DeleteBeforeCreate)+len(changes.Delete) != 0 {
+		t.Fatalf("expected no changes, got: %#v", changes)
+	}
+}
+
+func TestSecGroupRuleHash(t *testing.T) {
+	configured := map[string]interface{}{
+		"ethertype":        "IPv4",
+		"protocol":         "TCP",
+		"ports":            "80, 443",
+		"remote_ip_prefix": "0.0.0.0/0",
+		"action":           "allow",
+		"priority":         1,
+	}
+	remote := flattenSecGroupRules([]rules.SecurityGroupRule{
+		{Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", MultiPort: "80,443",
+			RemoteIpPrefix: "0.0.0.0/0", Action: "allow", Priority: 1},
+	}, "ingress")
+
+	if resourceSecGroupRuleHash(configured) != resourceSecGroupRuleHash(remote[0]) {
+		t.Fatalf("expected the same hash for %#v and %#v", configured, remote[0])
+	}
+}
+
+func TestAccNetworkingSecGroup_basic(t *testing.T) {
+	var secGroup groups.SecurityGroup
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_networking_secgroup.secgroup_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingSecGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingSecGroup_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingSecGroupExists(resourceName, &secGroup),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "created by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccNetworkingSecGroup_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &secGroup.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "baz"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingSecGroupDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	client, err := config.NetworkingV3Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "flexibleengine_networking_secgroup" {
+			continue
+		}
+
+		_, err := groups.Get(client, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Security group still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingSecGroupExists(n string, secGroup *groups.SecurityGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		client, err := config.NetworkingV3Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating FlexibleEngine networking v3 client: %s", err)
+		}
+
+		found, err := groups.Get(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Security group not found")
+		}
+
+		*secGroup = *found
+		return nil
+	}
+}
+
+func testAccNetworkingSecGroup_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_networking_secgroup" "secgroup_1" {
+  name        = "%s"
+  description = "created by acc test"
+
+  ingress {
+    protocol         = "tcp"
+    ports            = "22"
+    remote_ip_prefix = "10.0.0.0/8"
+    description      = "ssh"
+  }
+
+  ingress {
+    protocol         = "tcp"
+    ports            = "80,443"
+    remote_ip_prefix = "0.0.0.0/0"
+  }
+
+  egress {
+    remote_ip_prefix = "0.0.0.0/0"
+  }
+
+  tags = {
+    foo = "bar"
+  }
+}
+`, rName)
+}
+
+func testAccNetworkingSecGroup_update(rName string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_networking_secgroup" "secgroup_1" {
+  name = "%s-update"
+
+  ingress {
+    protocol         = "tcp"
+    ports            = "22"
+    remote_ip_prefix = "10.0.0.0/8"
+    action           = "deny"
+    priority         = 10
+  }
+
+  tags = {
+    foo = "baz"
+  }
+}
+`, rName)
+}
@@ -15,6 +15,10 @@ func resourceNetworkingVIPAssociateV2() *schema.Resource {
 		Read:   resourceNetworkingVIPAssociateV2Read,
 		Delete: resourceNetworkingVIPAssociateV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vip_id": {
 				Type:     schema.TypeString,
@@ -30,6 +30,11 @@ func TestAccNetworkingV2VIPAssociate_basic(t *testing.T) {
 						"flexibleengine_networking_vip_v2.vip_1", "id"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
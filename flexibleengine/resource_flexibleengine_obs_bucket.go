@@ -22,6 +22,8 @@ func resourceObsBucket() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -261,6 +263,8 @@ func resourceObsBucket() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"bucket_domain_name": {
 				Type:     schema.TypeString,
@@ -328,6 +332,12 @@ func resourceObsBucketUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChanges("tags", "tags_all") {
+		if err := resourceObsBucketTagsUpdate(obsClient, d, conf); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("versioning") {
 		if err := resourceObsBucketVersioningUpdate(obsClient, d); err != nil {
 			return err
@@ -408,6 +418,11 @@ func resourceObsBucketRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Read the tags
+	if err := setObsBucketTags(obsClient, d, conf); err != nil {
+		return err
+	}
+
 	// Read the versioning
 	if err := setObsBucketVersioning(obsClient, d); err != nil {
 		return err
@@ -469,12 +484,10 @@ func resourceObsBucketDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-/*
-func resourceObsBucketTagsUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+func resourceObsBucketTagsUpdate(obsClient *obs.ObsClient, d *schema.ResourceData, config *Config) error {
 	bucket := d.Get("bucket").(string)
-	tagmap := d.Get("tags").(map[string]interface{})
 	tagList := []obs.Tag{}
-	for k, v := range tagmap {
+	for k, v := range getResourceTags(d, config) {
 		tag := obs.Tag{
 			Key:   k,
 			Value: v.(string),
@@ -482,18 +495,40 @@ func resourceObsBucketTagsUpdate(obsClient *obs.ObsClient, d *schema.ResourceDat
 		tagList = append(tagList, tag)
 	}
 
+	// SetBucketTagging replaces all tags of the bucket, so the ones matched by the provider
+	// ignore_tags are read back and kept as they are
+	output, err := obsClient.GetBucketTagging(bucket)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); !ok || obsError.Code != "NoSuchTagSet" {
+			return getObsError("Error getting tags of OBS bucket", bucket, err)
+		}
+	} else {
+		for _, tag := range output.Tags {
+			if isIgnoredTag(config, tag.Key) {
+				tagList = append(tagList, tag)
+			}
+		}
+	}
+
+	if len(tagList) == 0 {
+		log.Printf("[DEBUG] delete tags of OBS bucket %s", bucket)
+		if _, err := obsClient.DeleteBucketTagging(bucket); err != nil {
+			return getObsError("Error deleting tags of OBS bucket", bucket, err)
+		}
+		return nil
+	}
+
 	req := &obs.SetBucketTaggingInput{}
 	req.Bucket = bucket
 	req.Tags = tagList
 	log.Printf("[DEBUG] set tags of OBS bucket %s: %#v", bucket, req)
 
-	_, err := obsClient.SetBucketTagging(req)
+	_, err = obsClient.SetBucketTagging(req)
 	if err != nil {
 		return getObsError("Error updating tags of OBS bucket", bucket, err)
 	}
 	return nil
 }
-*/
 
 func resourceObsBucketAclUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
@@ -1176,34 +1211,29 @@ func setObsBucketCorsRules(obsClient *obs.ObsClient, d *schema.ResourceData) err
 	return nil
 }
 
-/*
-func setObsBucketTags(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+func setObsBucketTags(obsClient *obs.ObsClient, d *schema.ResourceData, config *Config) error {
 	bucket := d.Id()
 	output, err := obsClient.GetBucketTagging(bucket)
 	if err != nil {
 		if obsError, ok := err.(obs.ObsError); ok {
 			if obsError.Code == "NoSuchTagSet" {
-				d.Set("tags", nil)
-				return nil
-			} else {
-				return fmt.Errorf("Error getting tags of OBS bucket %s: %s,\n Reason: %s",
-					bucket, obsError.Code, obsError.Message)
+				return setResourceTags(d, config, nil)
 			}
-		} else {
-			return err
+			return fmt.Errorf("Error getting tags of OBS bucket %s: %s,\n Reason: %s",
+				bucket, obsError.Code, obsError.Message)
 		}
+		return err
 	}
 
 	tagmap := make(map[string]string)
 	for _, tag := range output.Tags {
 		tagmap[tag.Key] = tag.Value
 	}
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := setResourceTags(d, config, tagmap); err != nil {
 		return fmt.Errorf("Error saving tags of OBS bucket %s: %s", bucket, err)
 	}
 	return nil
 }
-*/
 
 func deleteAllBucketObjects(obsClient *obs.ObsClient, bucket string) error {
 	listOpts := &obs.ListObjectsInput{
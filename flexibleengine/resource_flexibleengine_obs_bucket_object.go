@@ -19,6 +19,10 @@ func resourceObsBucketObject() *schema.Resource {
 		Update: resourceObsBucketObjectPut,
 		Delete: resourceObsBucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceObsBucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -282,3 +286,20 @@ func resourceObsBucketObjectDelete(d *schema.ResourceData, meta interface{}) err
 
 	return nil
 }
+
+func resourceObsBucketObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		err := fmt.Errorf("Invalid format specified for OBS bucket object. Format must be <bucket>/<key>")
+		return nil, err
+	}
+
+	bucket := parts[0]
+	key := parts[1]
+
+	d.SetId(key)
+	d.Set("bucket", bucket)
+	d.Set("key", key)
+
+	return []*schema.ResourceData{d}, nil
+}
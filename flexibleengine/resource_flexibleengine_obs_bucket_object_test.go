@@ -74,6 +74,15 @@ func TestAccObsBucketObject_content(t *testing.T) {
 						"flexibleengine_obs_bucket_object.object", "size", "19"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_obs_bucket_object.object",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("tf-object-test-bucket-%d/test-key", rInt),
+				ImportStateVerifyIgnore: []string{
+					"content",
+				},
+			},
 		},
 	})
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
 )
 
 func TestAccObsBucket_basic(t *testing.T) {
@@ -53,6 +55,45 @@ func TestAccObsBucket_basic(t *testing.T) {
 	})
 }
 
+func TestUnitObsBucket_basic(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	resourceName := "flexibleengine_obs_bucket.bucket"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockcloud.PreCheck(t) },
+		ProviderFactories: srv.ProviderFactories(Provider),
+		CheckDestroy:      srv.CheckDestroy("flexibleengine_obs_bucket", "buckets"),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testUnitObsBucket_basic("STANDARD", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "bucket", "tf-unit-test-bucket"),
+					resource.TestCheckResourceAttr(resourceName, "storage_class", "STANDARD"),
+					resource.TestCheckResourceAttr(resourceName, "versioning", "false"),
+					resource.TestCheckResourceAttr(resourceName, "encryption", "false"),
+					resource.TestCheckResourceAttr(resourceName, "region", mockcloud.Region),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testUnitObsBucket_basic("WARM", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "storage_class", "WARM"),
+					resource.TestCheckResourceAttr(resourceName, "versioning", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"acl",
+					"force_destroy",
+				},
+			},
+		},
+	})
+}
+
 func TestAccObsBucket_multiAZ(t *testing.T) {
 	rInt := acctest.RandInt()
 	resourceName := "flexibleengine_obs_bucket.bucket"
@@ -547,3 +588,14 @@ resource "flexibleengine_obs_bucket" "bucket" {
 }
 `, randInt)
 }
+
+func testUnitObsBucket_basic(class string, versioning bool) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_obs_bucket" "bucket" {
+  bucket        = "tf-unit-test-bucket"
+  storage_class = "%s"
+  versioning    = %t
+  acl           = "private"
+}
+`, class, versioning)
+}
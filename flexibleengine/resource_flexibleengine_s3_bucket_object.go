@@ -24,6 +24,10 @@ func resourceS3BucketObject() *schema.Resource {
 		Update: resourceS3BucketObjectPut,
 		Delete: resourceS3BucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceS3BucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -279,6 +283,23 @@ func resourceS3BucketObjectDelete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+func resourceS3BucketObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		err := fmt.Errorf("Invalid format specified for S3 bucket object. Format must be <bucket>/<key>")
+		return nil, err
+	}
+
+	bucket := parts[0]
+	key := parts[1]
+
+	d.SetId(key)
+	d.Set("bucket", bucket)
+	d.Set("key", key)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func validateS3BucketObjectAclType(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 
@@ -58,6 +58,16 @@ func TestAccS3BucketObject_content(t *testing.T) {
 				Config:    testAccS3BucketObjectConfigContent(rInt),
 				Check:     testAccCheckS3BucketObjectExists("flexibleengine_s3_bucket_object.object", &obj),
 			},
+			{
+				ResourceName:      "flexibleengine_s3_bucket_object.object",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("tf-object-test-bucket-%d/test-key", rInt),
+				ImportStateVerifyIgnore: []string{
+					"content",
+					"acl",
+				},
+			},
 		},
 	})
 }
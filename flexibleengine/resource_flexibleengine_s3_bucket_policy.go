@@ -19,6 +19,10 @@ func resourceS3BucketPolicy() *schema.Resource {
 		Update: resourceS3BucketPolicyPut,
 		Delete: resourceS3BucketPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -86,6 +90,8 @@ func resourceS3BucketPolicyRead(d *schema.ResourceData, meta interface{}) error
 		Bucket: aws.String(d.Id()),
 	})
 
+	d.Set("bucket", d.Id())
+
 	v := ""
 	if err == nil && pol.Policy != nil {
 		v = *pol.Policy
@@ -31,6 +31,14 @@ func TestAccS3BucketPolicy_basic(t *testing.T) {
 					testAccCheckS3BucketHasPolicy("flexibleengine_s3_bucket.bucket", expectedPolicyText),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_s3_bucket_policy.bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"policy",
+				},
+			},
 		},
 	})
 }
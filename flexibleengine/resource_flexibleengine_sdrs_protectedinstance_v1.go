@@ -17,6 +17,10 @@ func resourceSdrsProtectedInstanceV1() *schema.Resource {
 		Update: resourceSdrsProtectedInstanceV1Update,
 		Delete: resourceSdrsProtectedInstanceV1Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -139,6 +143,7 @@ func resourceSdrsProtectedInstanceV1Read(d *schema.ResourceData, meta interface{
 	d.Set("name", n.Name)
 	d.Set("description", n.Description)
 	d.Set("group_id", n.GroupID)
+	d.Set("server_id", n.SourceServer)
 	d.Set("target_server", n.TargetServer)
 
 	return nil
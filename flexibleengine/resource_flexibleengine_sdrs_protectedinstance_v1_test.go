@@ -34,6 +34,15 @@ func TestAccSdrsProtectedInstanceV1_basic(t *testing.T) {
 						"flexibleengine_sdrs_protectedinstance_v1.instance_1", "name", "instance_updated"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_sdrs_protectedinstance_v1.instance_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"delete_target_server",
+					"delete_target_eip",
+				},
+			},
 		},
 	})
 }
@@ -33,6 +33,10 @@ func resourceSdrsReplicationAttachV1() *schema.Resource {
 		Read:   resourceSdrsReplicationAttachV1Read,
 		Delete: resourceSdrsReplicationAttachV1Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -128,6 +132,7 @@ func resourceSdrsReplicationAttachV1Read(d *schema.ResourceData, meta interface{
 	}
 	log.Printf("[DEBUG] Retrieved replication attachment: %#v", attach)
 
+	d.Set("instance_id", instId)
 	d.Set("device", attach.Device)
 	d.Set("replication_id", attach.Replication)
 	d.Set("status", n.Status)
@@ -24,6 +24,11 @@ func TestAccSdrsReplicationAttachV1_basic(t *testing.T) {
 						"flexibleengine_sdrs_replication_attach_v1.attach_1", "device", "/dev/vdb"),
 				),
 			},
+			{
+				ResourceName:      "flexibleengine_sdrs_replication_attach_v1.attach_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
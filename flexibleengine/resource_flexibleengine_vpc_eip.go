@@ -26,9 +26,12 @@ func resourceVpcEIPV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -91,7 +94,9 @@ func resourceVpcEIPV1() *schema.Resource {
 					},
 				},
 			},
-			"tags": tagsSchema(),
+			"tags":                  tagsSchema(),
+			"tags_all":              tagsAllSchema(),
+			"enterprise_project_id": resourceEnterpriseProjectIDSchema(),
 
 			"address": {
 				Type:     schema.TypeString,
@@ -113,8 +118,9 @@ func resourceVpcEIPV1Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	createOpts := eips.ApplyOpts{
-		IP:        resourcePublicIP(d),
-		Bandwidth: resourceBandWidth(d),
+		IP:                  resourcePublicIP(d),
+		Bandwidth:           resourceBandWidth(d),
+		EnterpriseProjectID: config.GetEnterpriseProjectID(d),
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
@@ -140,7 +146,7 @@ func resourceVpcEIPV1Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config))
 		if err != nil {
@@ -194,6 +200,7 @@ func resourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("region", GetRegion(d, config))
 	d.Set("address", eIP.PublicAddress)
 	d.Set("status", normalizeEIPStatus(eIP.Status))
+	d.Set("enterprise_project_id", eIP.EnterpriseProjectID)
 
 	// save tags
 	vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config))
@@ -202,8 +209,9 @@ func resourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
 	}
 	resourceTags, err := tags.Get(vpcV2Client, "publicips", d.Id()).Extract()
 	if err == nil {
-		tagmap := tagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		if err := setResourceTags(d, config, tagsToMap(resourceTags.Tags)); err != nil {
+			return err
+		}
 	} else {
 		log.Printf("[WARN] fetching EIP %s tags failed: %s", d.Id(), err)
 	}
@@ -257,18 +265,24 @@ func resourceVpcEIPV1Update(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	//update tags
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		vpcV2Client, err := config.NetworkingV2Client(GetRegion(d, config))
 		if err != nil {
 			return fmt.Errorf("Error creating FlexibleEngine vpc client: %s", err)
 		}
 
-		tagErr := UpdateResourceTags(vpcV2Client, d, "publicips", d.Id())
+		tagErr := UpdateResourceTags(vpcV2Client, d, config, "publicips", d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of EIP %s: %s", d.Id(), tagErr)
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") {
+		if err := migrateEnterpriseProject(d, config, "eip", d.Id()); err != nil {
+			return err
+		}
+	}
+
 	return resourceVpcEIPV1Read(d, meta)
 }
 
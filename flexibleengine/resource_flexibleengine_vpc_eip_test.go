@@ -51,6 +51,34 @@ func TestAccVpcV1EIP_basic(t *testing.T) {
 	})
 }
 
+func TestAccVpcV1EIP_enterpriseProject(t *testing.T) {
+	var eip eips.PublicIp
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "flexibleengine_vpc_eip.eip_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEpsID(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcV1EIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcV1EIP_enterpriseProject(rName, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1EIPExists(resourceName, &eip),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_project_id", "0"),
+				),
+			},
+			{
+				Config: testAccVpcV1EIP_enterpriseProject(rName, OS_ENTERPRISE_PROJECT_ID_TEST),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1EIPExists(resourceName, &eip),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_project_id", OS_ENTERPRISE_PROJECT_ID_TEST),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcV1EIPDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	networkingClient, err := config.NetworkingV1Client(OS_REGION_NAME)
@@ -137,3 +165,19 @@ resource "flexibleengine_vpc_eip" "eip_1" {
 }
 `, rName)
 }
+
+func testAccVpcV1EIP_enterpriseProject(rName, epsID string) string {
+	return fmt.Sprintf(`
+resource "flexibleengine_vpc_eip" "eip_1" {
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    share_type = "PER"
+    name       = "%s"
+    size       = 5
+  }
+  enterprise_project_id = "%s"
+}
+`, rName, epsID)
+}
@@ -28,6 +28,10 @@ func resourceVPCEndpointApproval() *schema.Resource {
 		Update: resourceVPCEndpointApprovalUpdate,
 		Delete: resourceVPCEndpointApprovalDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
@@ -113,9 +117,19 @@ func resourceVPCEndpointApprovalRead(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error creating FlexibleEngine VPC endpoint client: %s", err)
 	}
 
-	serviceID := d.Get("service_id").(string)
+	serviceID := d.Id()
+	d.Set("region", GetRegion(d, config))
+	d.Set("service_id", serviceID)
 	if conns, err := flattenVPCEndpointConnections(vpcepClient, serviceID); err == nil {
 		d.Set("connections", conns)
+
+		accepted := make([]string, 0, len(conns))
+		for _, conn := range conns {
+			if conn["status"] == approvalActionStatusMap[actionReceive] {
+				accepted = append(accepted, conn["endpoint_id"].(string))
+			}
+		}
+		d.Set("endpoints", accepted)
 	}
 
 	return nil
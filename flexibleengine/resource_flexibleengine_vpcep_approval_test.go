@@ -39,6 +39,11 @@ func TestAccVPCEndpointApproval(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "connections.0.status", "rejected"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
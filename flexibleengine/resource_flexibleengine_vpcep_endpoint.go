@@ -26,6 +26,8 @@ func resourceVPCEndpoint() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTagsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -92,7 +94,8 @@ func resourceVPCEndpoint() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -125,7 +128,7 @@ func resourceVPCEndpointCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := getResourceTags(d, config)
 	if len(tagRaw) > 0 {
 		taglist := expandResourceTags(tagRaw)
 		createOpts.Tags = taglist
@@ -199,7 +202,9 @@ func resourceVPCEndpointRead(d *schema.ResourceData, meta interface{}) error {
 	for _, val := range ep.Tags {
 		tagmap[val.Key] = val.Value
 	}
-	d.Set("tags", tagmap)
+	if err := setResourceTags(d, config, tagmap); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -212,8 +217,8 @@ func resourceVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	//update tags
-	if d.HasChange("tags") {
-		tagErr := UpdateResourceTags(vpcepClient, d, tagVPCEP, d.Id())
+	if d.HasChanges("tags", "tags_all") {
+		tagErr := UpdateResourceTags(vpcepClient, d, config, tagVPCEP, d.Id())
 		if tagErr != nil {
 			return fmt.Errorf("Error updating tags of VPC endpoint service %s: %s", d.Id(), tagErr)
 		}
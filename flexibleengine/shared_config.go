@@ -0,0 +1,231 @@
+package flexibleengine
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultSharedProfile         = "default"
+	sharedProfileCredentialsName = "SharedProfileProvider"
+)
+
+// sharedProfile is a profile in the shared config file, which is either an OpenStack-style
+// clouds.yaml or an INI file with one section per profile.
+type sharedProfile struct {
+	Region        string
+	AuthURL       string
+	ProjectName   string
+	ProjectID     string
+	DomainName    string
+	DomainID      string
+	UserName      string
+	UserID        string
+	Password      string
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+}
+
+// cloudsYAML is the structure of clouds.yaml, the ak/sk and access_key/secret_key
+// are both supported in the auth block.
+type cloudsYAML struct {
+	Clouds map[string]struct {
+		RegionName string `yaml:"region_name"`
+		Auth       struct {
+			AuthURL           string `yaml:"auth_url"`
+			ProjectName       string `yaml:"project_name"`
+			ProjectID         string `yaml:"project_id"`
+			DomainName        string `yaml:"domain_name"`
+			DomainID          string `yaml:"domain_id"`
+			UserDomainName    string `yaml:"user_domain_name"`
+			UserDomainID      string `yaml:"user_domain_id"`
+			ProjectDomainName string `yaml:"project_domain_name"`
+			ProjectDomainID   string `yaml:"project_domain_id"`
+			Username          string `yaml:"username"`
+			UserID            string `yaml:"user_id"`
+			Password          string `yaml:"password"`
+			AK                string `yaml:"ak"`
+			SK                string `yaml:"sk"`
+			AccessKey         string `yaml:"access_key"`
+			SecretKey         string `yaml:"secret_key"`
+			SecurityToken     string `yaml:"security_token"`
+		} `yaml:"auth"`
+	} `yaml:"clouds"`
+}
+
+// loadSharedProfile reads the profile from the shared config file, the file is parsed as
+// clouds.yaml if the extension is .yaml or .yml, otherwise as an INI file.
+func loadSharedProfile(filename, profile string) (*sharedProfile, error) {
+	path, err := homedir.Expand(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding the path of shared config file %s: %s", filename, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading shared config file %s: %s", filename, err)
+	}
+	if profile == "" {
+		profile = defaultSharedProfile
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseCloudsYAML(content, profile)
+	default:
+		return parseINIProfile(content, profile)
+	}
+}
+
+func parseCloudsYAML(content []byte, profile string) (*sharedProfile, error) {
+	var clouds cloudsYAML
+	if err := yaml.Unmarshal(content, &clouds); err != nil {
+		return nil, fmt.Errorf("Error parsing clouds.yaml: %s", err)
+	}
+
+	cloud, ok := clouds.Clouds[profile]
+	if !ok {
+		return nil, fmt.Errorf("the cloud %s was not found in clouds.yaml", profile)
+	}
+
+	auth := cloud.Auth
+	return &sharedProfile{
+		Region:        cloud.RegionName,
+		AuthURL:       auth.AuthURL,
+		ProjectName:   auth.ProjectName,
+		ProjectID:     auth.ProjectID,
+		DomainName:    firstNonEmpty(auth.DomainName, auth.UserDomainName, auth.ProjectDomainName),
+		DomainID:      firstNonEmpty(auth.DomainID, auth.UserDomainID, auth.ProjectDomainID),
+		UserName:      auth.Username,
+		UserID:        auth.UserID,
+		Password:      auth.Password,
+		AccessKey:     firstNonEmpty(auth.AccessKey, auth.AK),
+		SecretKey:     firstNonEmpty(auth.SecretKey, auth.SK),
+		SecurityToken: auth.SecurityToken,
+	}, nil
+}
+
+func parseINIProfile(content []byte, profile string) (*sharedProfile, error) {
+	file, err := ini.Load(content)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing INI shared config file: %s", err)
+	}
+
+	section, err := file.GetSection(profile)
+	if err != nil {
+		return nil, fmt.Errorf("the profile %s was not found in the shared config file", profile)
+	}
+
+	value := func(keys ...string) string {
+		for _, key := range keys {
+			if v := section.Key(key).String(); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	return &sharedProfile{
+		Region:        value("region"),
+		AuthURL:       value("auth_url"),
+		ProjectName:   value("project_name", "tenant_name"),
+		ProjectID:     value("project_id", "tenant_id"),
+		DomainName:    value("domain_name"),
+		DomainID:      value("domain_id"),
+		UserName:      value("user_name"),
+		UserID:        value("user_id"),
+		Password:      value("password"),
+		AccessKey:     value("access_key"),
+		SecretKey:     value("secret_key"),
+		SecurityToken: value("security_token"),
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// loadSharedConfig fills the settings which are not specified in the provider
+// with the profile in the shared config file.
+func loadSharedConfig(c *Config) error {
+	profile, err := loadSharedProfile(c.SharedConfigFile, c.Profile)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Loaded the profile %s from the shared config file %s", c.Profile, c.SharedConfigFile)
+
+	for _, v := range []struct {
+		field *string
+		value string
+	}{
+		{&c.Region, profile.Region},
+		{&c.IdentityEndpoint, profile.AuthURL},
+		{&c.TenantName, profile.ProjectName},
+		{&c.TenantID, profile.ProjectID},
+		{&c.DomainName, profile.DomainName},
+		{&c.DomainID, profile.DomainID},
+	} {
+		if *v.field == "" {
+			*v.field = v.value
+		}
+	}
+
+	// the credentials in the profile are used as a whole, so that they are not
+	// mixed up with the ones specified in the provider
+	if c.Token != "" || c.Password != "" || c.AccessKey != "" {
+		return nil
+	}
+	if profile.AccessKey != "" {
+		c.AccessKey, c.SecretKey, c.SecurityToken = profile.AccessKey, profile.SecretKey, profile.SecurityToken
+	} else {
+		c.Username, c.UserID, c.Password = profile.UserName, profile.UserID, profile.Password
+	}
+	return nil
+}
+
+// sharedProfileCredentialsProvider retrieves the AK/SK of the profile in the shared config file
+// for the S3 credentials chain.
+type sharedProfileCredentialsProvider struct {
+	Filename string
+	Profile  string
+
+	retrieved bool
+}
+
+func (p *sharedProfileCredentialsProvider) Retrieve() (awsCredentials.Value, error) {
+	p.retrieved = false
+
+	value := awsCredentials.Value{ProviderName: sharedProfileCredentialsName}
+	if p.Filename == "" {
+		return value, fmt.Errorf("the shared config file is not specified")
+	}
+
+	profile, err := loadSharedProfile(p.Filename, p.Profile)
+	if err != nil {
+		return value, err
+	}
+	if profile.AccessKey == "" || profile.SecretKey == "" {
+		return value, fmt.Errorf("the AK/SK is not found in the profile %s", p.Profile)
+	}
+
+	value.AccessKeyID, value.SecretAccessKey, value.SessionToken =
+		profile.AccessKey, profile.SecretKey, profile.SecurityToken
+	p.retrieved = true
+	return value, nil
+}
+
+func (p *sharedProfileCredentialsProvider) IsExpired() bool {
+	return !p.retrieved
+}
@@ -0,0 +1,90 @@
+package flexibleengine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSharedProfile_cloudsYAML(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "clouds.yaml")
+	content := `clouds:
+  default:
+    region_name: eu-west-0
+    auth:
+      auth_url: https://iam.eu-west-0.prod-cloud-ocb.orange-business.com/v3
+      project_name: eu-west-0_dev
+      user_domain_name: my-domain
+      username: developer
+      password: secret
+  prod:
+    region_name: eu-west-0
+    auth:
+      project_id: 0123456789abcdef
+      ak: prod-ak
+      sk: prod-sk
+`
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatalf("Error writing clouds.yaml: %s", err)
+	}
+
+	profile, err := loadSharedProfile(filename, "")
+	if err != nil {
+		t.Fatalf("Error loading the default profile: %s", err)
+	}
+	expected := sharedProfile{
+		Region:      "eu-west-0",
+		AuthURL:     "https://iam.eu-west-0.prod-cloud-ocb.orange-business.com/v3",
+		ProjectName: "eu-west-0_dev",
+		DomainName:  "my-domain",
+		UserName:    "developer",
+		Password:    "secret",
+	}
+	if *profile != expected {
+		t.Errorf("expected the default profile to be %+v, got %+v", expected, *profile)
+	}
+
+	profile, err = loadSharedProfile(filename, "prod")
+	if err != nil {
+		t.Fatalf("Error loading the prod profile: %s", err)
+	}
+	if profile.AccessKey != "prod-ak" || profile.SecretKey != "prod-sk" || profile.ProjectID != "0123456789abcdef" {
+		t.Errorf("expected the AK/SK and project of the prod profile, got %+v", *profile)
+	}
+
+	if _, err := loadSharedProfile(filename, "test"); err == nil {
+		t.Errorf("expected an error when the profile does not exist")
+	}
+}
+
+func TestLoadSharedConfig_precedence(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	content := `[default]
+region = eu-west-0
+tenant_name = eu-west-0_dev
+access_key = profile-ak
+secret_key = profile-sk
+`
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatalf("Error writing the shared config file: %s", err)
+	}
+
+	// the settings specified in the provider take precedence over the profile,
+	// and the credentials in the profile are not mixed with them
+	config := &Config{
+		SharedConfigFile: filename,
+		TenantName:       "eu-west-0_prod",
+		Username:         "developer",
+		Password:         "secret",
+	}
+	if err := loadSharedConfig(config); err != nil {
+		t.Fatalf("Error loading the shared config file: %s", err)
+	}
+	if config.Region != "eu-west-0" || config.TenantName != "eu-west-0_prod" {
+		t.Errorf("expected region from the profile and tenant_name from the provider, got %s and %s",
+			config.Region, config.TenantName)
+	}
+	if config.AccessKey != "" || config.SecretKey != "" {
+		t.Errorf("expected the AK/SK in the profile to be ignored, got %s", config.AccessKey)
+	}
+}
@@ -1,16 +1,24 @@
 package flexibleengine
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
 	tagVPCEP        string = "endpoint"
 	tagVPCEPService string = "endpoint_service"
+
+	tagsNotReadMarker string = "__tags_not_read__"
 )
 
 // tagsSchema returns the schema to use for tags.
@@ -22,13 +30,134 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// tagsAllSchema returns the schema to use for tags_all, which contains the tags of the resource
+// merged with the provider default_tags.
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// isIgnoredTag returns true if the tag key is matched by the provider ignore_tags.
+func isIgnoredTag(config *Config, key string) bool {
+	metadata := getProviderMetadata(config)
+	for _, k := range metadata.IgnoreTagKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range metadata.IgnoreTagKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeDefaultTags returns the provider default_tags overridden by the given tags,
+// the tags matched by the provider ignore_tags are excluded.
+func mergeDefaultTags(config *Config, tagmap map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range getProviderMetadata(config).DefaultTags {
+		result[k] = v
+	}
+	for k, v := range tagmap {
+		result[k] = v
+	}
+
+	for k := range result {
+		if isIgnoredTag(config, k) {
+			delete(result, k)
+		}
+	}
+	return result
+}
+
+// getResourceTags returns all tags which should be bound to the resource, including the
+// provider default_tags. It expects the tags field to be named "tags"
+func getResourceTags(d *schema.ResourceData, config *Config) map[string]interface{} {
+	return mergeDefaultTags(config, d.Get("tags").(map[string]interface{}))
+}
+
+// resourceTagsCustomizeDiff computes tags_all during the plan, so that changing the provider
+// default_tags is reflected on the resources.
+func resourceTagsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	config, _ := meta.(*Config)
+	allTags := mergeDefaultTags(config, d.Get("tags").(map[string]interface{}))
+	if reflect.DeepEqual(allTags, d.Get("tags_all").(map[string]interface{})) {
+		return nil
+	}
+	return d.SetNew("tags_all", allTags)
+}
+
+// resourceTagsForceNewCustomizeDiff is the same as resourceTagsCustomizeDiff for the resources
+// whose tags can not be updated, so changing the provider default_tags creates a new resource.
+func resourceTagsForceNewCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("tags") {
+		return resourceTagsCustomizeDiff(ctx, d, meta)
+	}
+
+	// tags_all is absent if the resource was created by an older provider version
+	config, _ := meta.(*Config)
+	allTags := mergeDefaultTags(config, d.Get("tags").(map[string]interface{}))
+	oldTags := d.Get("tags_all").(map[string]interface{})
+	if len(oldTags) == 0 {
+		oldRaw, _ := d.GetChange("tags")
+		oldTags = oldRaw.(map[string]interface{})
+	}
+	if reflect.DeepEqual(allTags, oldTags) {
+		return nil
+	}
+
+	if err := d.SetNew("tags_all", allTags); err != nil {
+		return err
+	}
+	return d.ForceNew("tags_all")
+}
+
+// setResourceTags saves the tags bound to the resource into tags_all, and the same tags except
+// the ones only coming from the provider default_tags into tags, so there is no diff for them.
+func setResourceTags(d *schema.ResourceData, config *Config, tagmap map[string]string) error {
+	return saveResourceTags(d, config, d.Get("tags").(map[string]interface{}), tagmap)
+}
+
+// saveResourceTags is the same as setResourceTags, the configured tags are specified by the caller
+// as the tags of d may have been overwritten by the remote tags.
+func saveResourceTags(d *schema.ResourceData, config *Config, configured map[string]interface{},
+	tagmap map[string]string) error {
+	defaultTags := getProviderMetadata(config).DefaultTags
+
+	resourceTags := make(map[string]string)
+	for k, v := range tagmap {
+		if defaultValue, ok := defaultTags[k]; ok && defaultValue == v {
+			if _, ok := configured[k]; !ok {
+				continue
+			}
+		}
+		resourceTags[k] = v
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("tags", resourceTags),
+		d.Set("tags_all", tagmap),
+	)
+	return mErr.ErrorOrNil()
+}
+
 // UpdateResourceTags is a helper to update the tags for a resource.
-// It expects the tags field to be named "tags"
-func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
-	if d.HasChange("tags") {
-		oRaw, nRaw := d.GetChange("tags")
-		oMap := oRaw.(map[string]interface{})
-		nMap := nRaw.(map[string]interface{})
+// It expects the tags field to be named "tags", the provider default_tags are
+// merged into the new tags and the tags matched by ignore_tags are never changed.
+func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, config *Config,
+	resourceType, id string) error {
+	if d.HasChanges("tags", "tags_all") {
+		oMap := getOldResourceTags(d, config)
+		nMap := getResourceTags(d, config)
 
 		// remove old tags
 		if len(oMap) > 0 {
@@ -52,6 +181,26 @@ func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, r
 	return nil
 }
 
+// getOldResourceTags returns the tags bound to the resource before the update except the ones
+// matched by the provider ignore_tags. The tags is used if tags_all is absent, e.g. the resource
+// was created by an older provider version.
+func getOldResourceTags(d *schema.ResourceData, config *Config) map[string]interface{} {
+	oRaw, _ := d.GetChange("tags_all")
+	oMap := oRaw.(map[string]interface{})
+	if len(oMap) == 0 {
+		oRaw, _ = d.GetChange("tags")
+		oMap = oRaw.(map[string]interface{})
+	}
+
+	result := make(map[string]interface{})
+	for k, v := range oMap {
+		if !isIgnoredTag(config, k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 // tagsToMap returns the list of tags into a map.
 func tagsToMap(tags []tags.ResourceTag) map[string]string {
 	result := make(map[string]string)
@@ -96,3 +245,166 @@ func getDNSRecordSetTagType(zoneType string) (string, error) {
 	}
 	return "", fmt.Errorf("invalid zone type: %s", zoneType)
 }
+
+// resourceWithProviderTags makes the provider default_tags take effect on a resource which manages
+// the tags by itself, e.g. the resources imported from the huaweicloud provider. The tags_all is added
+// to the schema, the default tags are merged into tags before the resource is created or updated, and
+// the tags read from the cloud are saved into tags and tags_all as setResourceTags does.
+// The resources which already support tags_all or whose tags is not a map are returned unchanged.
+func resourceWithProviderTags(r *schema.Resource) *schema.Resource {
+	tagsSchema, ok := r.Schema["tags"]
+	if !ok || tagsSchema.Type != schema.TypeMap || !tagsSchema.Optional || r.Schema["tags_all"] != nil {
+		return r
+	}
+
+	r.Schema["tags_all"] = tagsAllSchema()
+	tagsDiff := resourceTagsCustomizeDiff
+	if tagsSchema.ForceNew || (r.Update == nil && r.UpdateContext == nil && r.UpdateWithoutTimeout == nil) {
+		tagsDiff = resourceTagsForceNewCustomizeDiff
+	}
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, tagsDiff)
+	} else {
+		r.CustomizeDiff = tagsDiff
+	}
+
+	// the inner function reads the tags from the cloud, the configured tags are saved in advance
+	wrapWrite := func(inner schema.CreateContextFunc, isCreate bool) schema.CreateContextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			config := meta.(*Config)
+			configured := d.Get("tags").(map[string]interface{})
+			if isCreate || d.HasChanges("tags", "tags_all") {
+				if err := d.Set("tags", getResourceTags(d, config)); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			diags := inner(ctx, d, meta)
+			if diags.HasError() {
+				d.Set("tags", configured)
+				return diags
+			}
+			return append(diags, saveInnerResourceTags(d, config, configured)...)
+		}
+	}
+	// some resources do not read the tags, a marker is set to find out whether the tags are read
+	wrapRead := func(inner schema.ReadContextFunc) schema.ReadContextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			configured := d.Get("tags").(map[string]interface{})
+			if err := d.Set("tags", map[string]interface{}{tagsNotReadMarker: ""}); err != nil {
+				return diag.FromErr(err)
+			}
+
+			diags := inner(ctx, d, meta)
+			if _, ok := d.Get("tags").(map[string]interface{})[tagsNotReadMarker]; ok || diags.HasError() || d.Id() == "" {
+				d.Set("tags", configured)
+				return diags
+			}
+			return append(diags, saveInnerResourceTags(d, meta.(*Config), configured)...)
+		}
+	}
+
+	wrapResourceFuncs(r, wrapWrite, wrapRead)
+	return r
+}
+
+// resourceWithIgnoredTags filters the tags matched by the provider ignore_tags out of tags and
+// tags_all after the resource or data source is read, created or updated. It is the only place
+// where ignore_tags takes effect on the read path, so the resources imported from other providers
+// are covered as well.
+func resourceWithIgnoredTags(r *schema.Resource) *schema.Resource {
+	tagKeys := make([]string, 0, 2)
+	for _, key := range []string{"tags", "tags_all"} {
+		if s, ok := r.Schema[key]; ok && s.Type == schema.TypeMap {
+			tagKeys = append(tagKeys, key)
+		}
+	}
+	if len(tagKeys) == 0 {
+		return r
+	}
+
+	filter := func(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		config, ok := meta.(*Config)
+		if !ok || d.Id() == "" {
+			return nil
+		}
+
+		var mErr *multierror.Error
+		for _, key := range tagKeys {
+			tagmap := d.Get(key).(map[string]interface{})
+			result := make(map[string]interface{})
+			for k, v := range tagmap {
+				if !isIgnoredTag(config, k) {
+					result[k] = v
+				}
+			}
+			if len(result) != len(tagmap) {
+				mErr = multierror.Append(mErr, d.Set(key, result))
+			}
+		}
+		return diag.FromErr(mErr.ErrorOrNil())
+	}
+
+	wrapWrite := func(inner schema.CreateContextFunc, _ bool) schema.CreateContextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := inner(ctx, d, meta)
+			return append(diags, filter(d, meta)...)
+		}
+	}
+	wrapRead := func(inner schema.ReadContextFunc) schema.ReadContextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := inner(ctx, d, meta)
+			return append(diags, filter(d, meta)...)
+		}
+	}
+
+	wrapResourceFuncs(r, wrapWrite, wrapRead)
+	return r
+}
+
+// wrapResourceFuncs wraps the create, update and read functions of the resource whichever
+// variant they are defined with, the functions without context are replaced by the context
+// aware ones.
+func wrapResourceFuncs(r *schema.Resource, wrapWrite func(schema.CreateContextFunc, bool) schema.CreateContextFunc,
+	wrapRead func(schema.ReadContextFunc) schema.ReadContextFunc) {
+	switch {
+	case r.CreateWithoutTimeout != nil:
+		r.CreateWithoutTimeout = wrapWrite(r.CreateWithoutTimeout, true)
+	case r.CreateContext != nil:
+		r.CreateContext = wrapWrite(r.CreateContext, true)
+	case r.Create != nil:
+		r.CreateContext, r.Create = wrapWrite(legacyContextFunc(r.Create), true), nil
+	}
+	switch {
+	case r.UpdateWithoutTimeout != nil:
+		r.UpdateWithoutTimeout = schema.UpdateContextFunc(wrapWrite(schema.CreateContextFunc(r.UpdateWithoutTimeout), false))
+	case r.UpdateContext != nil:
+		r.UpdateContext = schema.UpdateContextFunc(wrapWrite(schema.CreateContextFunc(r.UpdateContext), false))
+	case r.Update != nil:
+		r.UpdateContext, r.Update = schema.UpdateContextFunc(wrapWrite(legacyContextFunc(r.Update), false)), nil
+	}
+	switch {
+	case r.ReadWithoutTimeout != nil:
+		r.ReadWithoutTimeout = wrapRead(r.ReadWithoutTimeout)
+	case r.ReadContext != nil:
+		r.ReadContext = wrapRead(r.ReadContext)
+	case r.Read != nil:
+		r.ReadContext, r.Read = wrapRead(schema.ReadContextFunc(legacyContextFunc(r.Read))), nil
+	}
+}
+
+// legacyContextFunc converts a CRUD function without context to the context aware one.
+func legacyContextFunc(f func(*schema.ResourceData, interface{}) error) schema.CreateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(f(d, meta))
+	}
+}
+
+// saveInnerResourceTags saves the tags which have been read by the wrapped resource into tags and tags_all.
+func saveInnerResourceTags(d *schema.ResourceData, config *Config, configured map[string]interface{}) diag.Diagnostics {
+	tagmap := make(map[string]string)
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		tagmap[k] = v.(string)
+	}
+	return diag.FromErr(saveResourceTags(d, config, configured, tagmap))
+}
@@ -0,0 +1,230 @@
+package flexibleengine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/common/tags"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	th "github.com/chnsz/golangsdk/testhelper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/FlexibleEngineCloud/terraform-provider-flexibleengine/flexibleengine/internal/mockcloud"
+)
+
+func testTagsConfig(defaultTags map[string]string) *Config {
+	return &Config{
+		Metadata: &providerMetadata{
+			DefaultTags: defaultTags,
+		},
+	}
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	config := testTagsConfig(map[string]string{"owner": "platform", "env": "dev"})
+	tagmap := map[string]interface{}{"env": "prod", "app": "web"}
+
+	expected := map[string]interface{}{"owner": "platform", "env": "prod", "app": "web"}
+	if result := mergeDefaultTags(config, tagmap); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected the merged tags to be %v, got %v", expected, result)
+	}
+
+	// the Config which is not built by the provider has no default tags
+	if result := mergeDefaultTags(&Config{}, tagmap); !reflect.DeepEqual(result, tagmap) {
+		t.Errorf("expected the merged tags to be %v, got %v", tagmap, result)
+	}
+}
+
+func TestSetResourceTags(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"tags":     tagsSchema(),
+		"tags_all": tagsAllSchema(),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "platform", "app": "web"},
+	})
+	config := testTagsConfig(map[string]string{"owner": "platform", "env": "dev", "team": "ops"})
+
+	remoteTags := map[string]string{"owner": "platform", "env": "dev", "team": "sre", "app": "web"}
+	if err := setResourceTags(d, config, remoteTags); err != nil {
+		t.Fatalf("Error setting tags: %s", err)
+	}
+
+	// "env" only comes from the default tags, "owner" is also configured in the resource
+	// and "team" has been changed outside
+	expected := map[string]interface{}{"owner": "platform", "team": "sre", "app": "web"}
+	if result := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags to be %v, got %v", expected, result)
+	}
+	if result := d.Get("tags_all").(map[string]interface{}); len(result) != len(remoteTags) {
+		t.Errorf("expected tags_all to be %v, got %v", remoteTags, result)
+	}
+}
+
+func TestIgnoreTags(t *testing.T) {
+	config := &Config{
+		Metadata: &providerMetadata{
+			DefaultTags:          map[string]string{"owner": "platform", "CreatedBy": "terraform"},
+			IgnoreTagKeys:        []string{"CreatedBy"},
+			IgnoreTagKeyPrefixes: []string{"cost:"},
+		},
+	}
+
+	tagmap := map[string]interface{}{"app": "web", "cost:center": "1024"}
+	expectedAll := map[string]interface{}{"owner": "platform", "app": "web"}
+	if result := mergeDefaultTags(config, tagmap); !reflect.DeepEqual(result, expectedAll) {
+		t.Errorf("expected the merged tags to be %v, got %v", expectedAll, result)
+	}
+
+	remoteTags := map[string]string{"app": "web", "CreatedBy": "admin", "cost:center": "1024", "costly": "yes"}
+	resource := resourceWithIgnoredTags(&schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return setResourceTags(d, meta.(*Config), remoteTags)
+		},
+		Schema: map[string]*schema.Schema{
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	})
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{"app": "web"},
+	})
+	d.SetId("instance-id")
+	if diags := resource.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading the resource: %v", diags)
+	}
+
+	expected := map[string]interface{}{"app": "web", "costly": "yes"}
+	if result := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags to be %v, got %v", expected, result)
+	}
+	if result := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags_all to be %v, got %v", expected, result)
+	}
+}
+
+func TestUnitIgnoreTags_importedResource(t *testing.T) {
+	srv := mockcloud.NewServer(t)
+	testProvider, _ := srv.ProviderFactories(Provider)["flexibleengine"]()
+
+	raw := map[string]interface{}{
+		"region":     mockcloud.Region,
+		"auth_url":   srv.Endpoint + "/iam/v3",
+		"access_key": mockcloud.AccessKey,
+		"secret_key": mockcloud.SecretKey,
+		"endpoints": map[string]interface{}{
+			"iam": srv.Endpoint + "/iam/",
+			"vpc": srv.Endpoint + "/vpc/",
+		},
+		"ignore_tags": []interface{}{
+			map[string]interface{}{
+				"keys":         []interface{}{"CreatedBy"},
+				"key_prefixes": []interface{}{"cost:"},
+			},
+		},
+	}
+	diags := testProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error when configure FlexibleEngine provider: %s", diags[0].Summary)
+	}
+	config := testProvider.Meta().(*Config)
+
+	vpcClient, err := config.NetworkingV1Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+	vpc, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-mock", CIDR: "192.168.0.0/16"}).Extract()
+	th.AssertNoErr(t, err)
+
+	// the tags added outside of Terraform
+	vpcV2Client, err := config.NetworkingV2Client(mockcloud.Region)
+	th.AssertNoErr(t, err)
+	remoteTags := []tags.ResourceTag{
+		{Key: "app", Value: "web"},
+		{Key: "CreatedBy", Value: "admin"},
+		{Key: "cost:center", Value: "1024"},
+	}
+	th.AssertNoErr(t, tags.Create(vpcV2Client, "vpcs", vpc.ID, remoteTags).ExtractErr())
+
+	// flexibleengine_vpc_v1 is imported from the huaweicloud provider
+	resource := testProvider.ResourcesMap["flexibleengine_vpc_v1"]
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "vpc-mock",
+		"cidr": "192.168.0.0/16",
+		"tags": map[string]interface{}{"app": "web"},
+	})
+	d.SetId(vpc.ID)
+	if diags := resource.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading the VPC: %v", diags)
+	}
+
+	expected := map[string]interface{}{"app": "web"}
+	if result := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags to be %v, got %v", expected, result)
+	}
+	if result := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags_all to be %v, got %v", expected, result)
+	}
+}
+
+func TestResourceWithProviderTags(t *testing.T) {
+	// a resource which manages the tags by itself, like the ones imported from huaweicloud
+	remoteTags := make(map[string]string)
+	resource := &schema.Resource{
+		Create: func(d *schema.ResourceData, _ interface{}) error {
+			remoteTags = make(map[string]string)
+			for k, v := range d.Get("tags").(map[string]interface{}) {
+				remoteTags[k] = v.(string)
+			}
+			d.SetId("instance-id")
+			return nil
+		},
+		Read: func(d *schema.ResourceData, _ interface{}) error {
+			return d.Set("tags", remoteTags)
+		},
+		Update: func(*schema.ResourceData, interface{}) error { return nil },
+		Delete: func(*schema.ResourceData, interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+			"tags": tagsSchema(),
+		},
+	}
+	resourceWithProviderTags(resource)
+	if resource.Schema["tags_all"] == nil || resource.CreateContext == nil || resource.ReadContext == nil {
+		t.Fatalf("expected the resource to be wrapped with the provider tags")
+	}
+
+	config := testTagsConfig(map[string]string{"owner": "platform"})
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"tags": map[string]interface{}{"app": "web"},
+	})
+	if diags := resource.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error creating the resource: %v", diags)
+	}
+
+	expectedRemote := map[string]string{"owner": "platform", "app": "web"}
+	if !reflect.DeepEqual(remoteTags, expectedRemote) {
+		t.Errorf("expected the default tags to be created, got %v", remoteTags)
+	}
+	expected := map[string]interface{}{"app": "web"}
+	if result := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags to be %v, got %v", expected, result)
+	}
+	expectedAll := map[string]interface{}{"owner": "platform", "app": "web"}
+	if result := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(result, expectedAll) {
+		t.Errorf("expected tags_all to be %v, got %v", expectedAll, result)
+	}
+
+	// the resource which does not read the tags keeps the configured ones
+	resource.ReadContext = nil
+	resource.Read = func(*schema.ResourceData, interface{}) error { return nil }
+	delete(resource.Schema, "tags_all")
+	resourceWithProviderTags(resource)
+	if diags := resource.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading the resource: %v", diags)
+	}
+	if result := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected tags to be %v, got %v", expected, result)
+	}
+}
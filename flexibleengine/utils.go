@@ -220,3 +220,31 @@ func isStrContainsSliceElement(str string, sl []string, ignoreCase, isExcat bool
 	}
 	return false
 }
+
+// isParamConfigured checks the configuration rather than the state, the Optional and Computed
+// parameters are kept in the state with their computed values after they are removed from the configuration.
+func isParamConfigured(d *schema.ResourceData, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		_, ok := d.GetOk(key)
+		return ok
+	}
+	return !raw.GetAttr(key).IsNull()
+}
+
+// getConfiguredString returns an empty string if the parameter is removed from the
+// configuration, rather than the computed value kept in the state.
+func getConfiguredString(d *schema.ResourceData, key string) string {
+	if !isParamConfigured(d, key) {
+		return ""
+	}
+	return d.Get(key).(string)
+}
+
+// getConfiguredInt returns 0 if the parameter is removed from the configuration.
+func getConfiguredInt(d *schema.ResourceData, key string) int {
+	if !isParamConfigured(d, key) {
+		return 0
+	}
+	return d.Get(key).(int)
+}
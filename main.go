@@ -13,5 +13,5 @@ func main() {
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
 
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: flexibleengine.Provider})
+		GRPCProviderFunc: flexibleengine.ProviderServer})
 }